@@ -0,0 +1,238 @@
+package af
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dyrkin/bin"
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+//fakeAdapter implements the buffers of large outgoing and incoming messages
+type fakeAdapter struct {
+	*znptest.Adapter
+	buffer   []byte   //allocated by AfDataRequestExt
+	sent     [][]byte //messages sent over the air
+	held     []byte   //incoming message not fitting into a frame
+	retrieve int      //number of AfDataRetrieve requests
+
+	request *storedRequest //header of the last request
+	confirm znp.Status     //status of the AfDataConfirm sent after a message
+	apsf    []*znp.AfApsfConfigSet
+	reflect bool //report an AfReflectError instead of the confirm
+	routes  int  //number of route discoveries
+	onSend  func()
+}
+
+func (a *fakeAdapter) connect() *znp.Znp {
+	a.Adapter = znptest.New()
+	a.Handle(unp.S_ZDO, 0x45, func(*znptest.Request) []byte {
+		a.routes++
+		return nil
+	})
+	a.Handle(unp.S_AF, 0x13, func(r *znptest.Request) []byte {
+		req := &znp.AfApsfConfigSet{}
+		r.Decode(req)
+		a.apsf = append(a.apsf, req)
+		return nil
+	})
+	a.Handle(unp.S_AF, 0x02, func(r *znptest.Request) []byte {
+		a.request = &storedRequest{}
+		r.Decode(a.request)
+		length := int(binary.LittleEndian.Uint16(r.Payload[requestExtHeader-2:]))
+		if data := r.Payload[requestExtHeader:]; len(data) == length {
+			a.send(r, data)
+		} else if a.buffer != nil {
+			return []byte{0x02}
+		} else {
+			a.buffer = make([]byte, length)
+		}
+		return nil
+	})
+	a.Handle(unp.S_AF, 0x11, func(r *znptest.Request) []byte {
+		req := &znp.AfDataStore{}
+		r.Decode(req)
+		if len(req.Data) == 0 {
+			a.send(r, a.buffer)
+			a.buffer = nil
+		} else {
+			copy(a.buffer[req.Index:], req.Data)
+		}
+		return nil
+	})
+	a.Handle(unp.S_AF, 0x12, func(r *znptest.Request) []byte {
+		a.retrieve++
+		req := &znp.AfDataRetrieve{}
+		r.Decode(req)
+		if req.Length == 0 {
+			a.held = nil
+			return nil
+		}
+		return append([]byte{0x00, req.Length}, a.held[req.Index:int(req.Index)+int(req.Length)]...)
+	})
+	z := znp.New(a.Unp())
+	z.Start()
+	return z
+}
+
+//send sends the message over the air and reports the delivery after the response
+func (a *fakeAdapter) send(r *znptest.Request, data []byte) {
+	a.sent = append(a.sent, data)
+	if a.onSend != nil {
+		a.onSend()
+	}
+	if a.reflect {
+		r.Reply(unp.S_AF, 0x83, &znp.AfReflectError{Status: a.confirm, Endpoint: a.request.SrcEndpoint,
+			TransID: a.request.TransID, DstAddr: "0x5678"})
+	} else {
+		r.Reply(unp.S_AF, 0x80, &znp.AfDataConfirm{Status: a.confirm, Endpoint: a.request.SrcEndpoint,
+			TransID: a.request.TransID})
+	}
+}
+
+func data(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	return data
+}
+
+func (s *MySuite) TestSendExt(c *C) {
+	a := &fakeAdapter{}
+	z := a.connect()
+	req := &znp.AfDataRequestExt{DstAddrMode: znp.AddrModeAddr16Bit, DstAddr: "0x0000000000001a2b",
+		Options: &znp.AfDataRequestOptions{}, Data: data(MaxInline)}
+	c.Assert(SendExt(z, req), IsNil)
+	req.Data = data(600)
+	c.Assert(SendExt(z, req), IsNil)
+	c.Assert(a.sent, DeepEquals, [][]byte{data(MaxInline), data(600)})
+}
+
+func (s *MySuite) TestReceiver(c *C) {
+	a := &fakeAdapter{held: data(500)}
+	z := a.connect()
+	r := NewReceiver(z)
+	defer r.Stop()
+
+	small := &znp.AfIncomingMessageExt{SrcAddr: "0x0000000000001a2b", Timestamp: 1, Data: data(10)}
+	a.Send(unp.S_AF, 0x82, small)
+	large := &znp.AfIncomingMessageExt{SrcAddr: "0x0000000000001a2b", Timestamp: 2}
+	payload := bin.Encode(large)
+	binary.LittleEndian.PutUint16(payload[len(payload)-2:], 500)
+	a.Send(unp.S_AF, 0x82, payload)
+
+	for _, expected := range [][]byte{data(10), data(500)} {
+		select {
+		case async := <-r.Events():
+			c.Assert(async.(*znp.AfIncomingMessageExt).Data, DeepEquals, expected)
+		case <-time.After(time.Second):
+			c.Fatal("no message")
+		}
+	}
+	c.Assert(a.retrieve, Equals, 4)
+	c.Assert(a.held, IsNil)
+}
+
+func (s *MySuite) TestSendLarge(c *C) {
+	a := &fakeAdapter{}
+	z := a.connect()
+	sender := NewSender(z)
+	defer sender.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	dst := Destination{Addr: "0x1a2b", Endpoint: 1}
+	c.Assert(sender.SendLarge(ctx, dst, 0x0019, data(600)), IsNil)
+	c.Assert(a.request.DstAddr, Equals, "0x0000000000001a2b")
+	c.Assert(a.request.Options, DeepEquals, &znp.AfDataRequestOptions{APSAck: 1, DiscoverRoute: 1})
+	c.Assert(a.sent, DeepEquals, [][]byte{data(600)})
+
+	a.confirm = znp.StatusApsNoAck
+	sender.SetFragmentation(1, Fragmentation{WindowSize: 4})
+	c.Assert(sender.SendLarge(ctx, dst, 0x0019, data(100)), ErrorMatches, "af: delivery to 0x1a2b failed: .*")
+	c.Assert(a.request.TransID, Equals, uint8(1))
+	c.Assert(a.apsf, DeepEquals, []*znp.AfApsfConfigSet{
+		{Endpoint: 1, FrameDelay: 50, WindowSize: 1},
+		{Endpoint: 1, FrameDelay: 50, WindowSize: 4},
+	})
+}
+
+func (s *MySuite) TestSendAF(c *C) {
+	a := &fakeAdapter{}
+	z := a.connect()
+	sender := NewSender(z)
+	defer sender.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	dst := Destination{Addr: "0x1a2b", Endpoint: 1}
+	c.Assert(sender.SendAF(ctx, dst, 0x0006, []byte{0x01, 0x02, 0x01}, nil), IsNil)
+
+	a.confirm = znp.StatusMacNoACK
+	err := sender.SendAF(ctx, dst, 0x0006, []byte{0x01, 0x02, 0x01}, &SendOptions{Retries: 1, DiscoverRoute: true})
+	c.Assert(errors.Is(err, ErrNoAck), Equals, true)
+	c.Assert(a.sent, HasLen, 3)
+	c.Assert(a.routes, Equals, 1)
+
+	a.confirm, a.reflect = znp.StatusApsNoBoundDevice, true
+	err = sender.SendAF(ctx, dst, 0x0006, []byte{0x01, 0x02, 0x01}, &SendOptions{Retries: 1})
+	c.Assert(err, ErrorMatches, "af: reflection to 0x5678 failed: .*")
+	c.Assert(a.sent, HasLen, 4)
+}
+
+func (s *MySuite) TestSendAFIsNotRetriedWhenCanceled(c *C) {
+	a := &fakeAdapter{confirm: znp.StatusMacNoACK}
+	z := a.connect()
+	sender := NewSender(z)
+	defer sender.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a.onSend = cancel
+
+	dst := Destination{Addr: "0x1a2b", Endpoint: 1}
+	err := sender.SendAF(ctx, dst, 0x0006, []byte{0x01, 0x02, 0x01}, &SendOptions{Retries: 3, DiscoverRoute: true})
+	c.Assert(err, Equals, context.Canceled)
+	c.Assert(a.sent, HasLen, 1)
+	c.Assert(a.routes, Equals, 0)
+}
+
+func (s *MySuite) TestSendersShareTheTransIDs(c *C) {
+	a := &fakeAdapter{}
+	z := a.connect()
+	first, second := NewSender(z), NewSender(z)
+	defer first.Stop()
+	defer second.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	//the TransIDs of the endpoint await their confirmations
+	for i := 0; i <= 0xFF; i++ {
+		_, ok := z.TransIDs().Acquire(1)
+		c.Assert(ok, Equals, true)
+	}
+	dst := Destination{Addr: "0x1a2b", Endpoint: 1}
+	err := first.SendAF(ctx, dst, 0x0006, []byte{0x01, 0x02, 0x01}, nil)
+	c.Assert(err, Equals, ErrNoTransID)
+	c.Assert(a.sent, HasLen, 0)
+
+	z.TransIDs().Release(1, 7)
+	c.Assert(second.SendAF(ctx, dst, 0x0006, []byte{0x01, 0x02, 0x01}, nil), IsNil)
+	c.Assert(a.request.TransID, Equals, uint8(7))
+	//the confirmation freed the TransID for the other sender
+	c.Assert(first.SendAF(ctx, dst, 0x0006, []byte{0x01, 0x02, 0x01}, nil), IsNil)
+	c.Assert(a.request.TransID, Equals, uint8(7))
+	c.Assert(a.sent, HasLen, 2)
+}
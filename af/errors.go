@@ -0,0 +1,75 @@
+package af
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dyrkin/znp-go"
+)
+
+//Causes of failed deliveries, a *DeliveryError or a *StatusError unwraps to one of them
+var (
+	ErrNoAck   = errors.New("af: no acknowledgement")
+	ErrNoRoute = errors.New("af: no route")
+	ErrBusy    = errors.New("af: adapter busy")
+	ErrExpired = errors.New("af: message expired")
+)
+
+//ErrTooLong is returned when the data doesn't fit into the request
+var ErrTooLong = errors.New("af: data too long")
+
+//ErrNoTransID is returned when all the TransIDs of the source endpoint are awaiting their confirmation
+var ErrNoTransID = errors.New("af: no free transaction ID")
+
+var causes = map[znp.Status]error{
+	znp.StatusApsNoAck:                ErrNoAck,
+	znp.StatusMacNoACK:                ErrNoAck,
+	znp.StatusNwkNoAck:                ErrNoAck,
+	znp.StatusNwkNoRoute:              ErrNoRoute,
+	znp.StatusMemError:                ErrBusy,
+	znp.StatusBufferFull:              ErrBusy,
+	znp.StatusMacMemError:             ErrBusy,
+	znp.StatusMacChannelAccessFailure: ErrBusy,
+	znp.StatusMacTransactionOverFlow:  ErrBusy,
+	znp.StatusMacTransactionExpired:   ErrExpired,
+}
+
+//DeliveryError is the failure reported by AfDataConfirm or AfReflectError
+type DeliveryError struct {
+	Addr   string
+	Status znp.Status
+	//Reflect is set when the adapter failed to reflect the message to a bound device
+	Reflect *znp.AfReflectError
+}
+
+func (e *DeliveryError) Error() string {
+	if e.Reflect != nil {
+		return fmt.Sprintf("af: reflection to %s failed: %s", e.Reflect.DstAddr, e.Status)
+	}
+	return fmt.Sprintf("af: delivery to %s failed: %s", e.Addr, e.Status)
+}
+
+//Unwrap returns the cause of the failure, or nil when the status has no known cause
+func (e *DeliveryError) Unwrap() error {
+	return causes[e.Status]
+}
+
+//Temporary reports whether the delivery may succeed when retried
+func (e *DeliveryError) Temporary() bool {
+	return causes[e.Status] != nil
+}
+
+//StatusError is the failure status of a request rejected by the adapter
+type StatusError struct {
+	Command string
+	Status  znp.Status
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("af: %s failed: %s", e.Command, e.Status)
+}
+
+//Unwrap returns the cause of the failure, or nil when the status has no known cause
+func (e *StatusError) Unwrap() error {
+	return causes[e.Status]
+}
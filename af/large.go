@@ -0,0 +1,131 @@
+//Package af sends and receives AF messages longer than one MT frame, and fragmented APS messages.
+//
+//	err := af.SendExt(z, &znp.AfDataRequestExt{DstAddrMode: znp.AddrModeAddr16Bit, DstAddr: "0x0000000000001a2b",
+//		DstEndpoint: 1, SrcEndpoint: 1, ClusterID: 0x0019, Options: &znp.AfDataRequestOptions{}, Radius: 30,
+//		Data: image})
+//
+//	r := af.NewReceiver(z)
+//	for async := range r.Events() {
+//		if msg, ok := async.(*znp.AfIncomingMessageExt); ok {
+//			fmt.Println(len(msg.Data))
+//		}
+//	}
+//
+//	sender := af.NewSender(z)
+//	err = sender.SendLarge(ctx, af.Destination{Addr: "0x1a2b", Endpoint: 1}, 0x0019, image)
+package af
+
+import (
+	"fmt"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+)
+
+const (
+	maxPayload = 250 //longest payload of an MT frame
+
+	requestExtHeader  = 20 //AfDataRequestExt without the data
+	incomingExtHeader = 27 //AfIncomingMessageExt without the data
+	srcRtgHeader      = 11 //AfDataRequestSrcRtg without the relays and the data
+
+	chunk = 240 //longest chunk stored or retrieved at once
+)
+
+//MaxInline is the longest payload AfDataRequestExt carries in a single frame
+const MaxInline = maxPayload - requestExtHeader
+
+//storedRequest is AfDataRequestExt with the length of the data only, it makes the adapter allocate a buffer for
+//the data sent with AfDataStore
+type storedRequest struct {
+	DstAddrMode znp.AddrMode
+	DstAddr     string `hex:"8"`
+	DstEndpoint uint8
+	DstPanID    uint16
+	SrcEndpoint uint8
+	ClusterID   uint16
+	TransID     uint8
+	Options     *znp.AfDataRequestOptions
+	Radius      uint8
+	Length      uint16
+}
+
+//SendExt sends the request with AfDataRequestExt. When the data doesn't fit into one frame, the adapter allocates
+//a buffer for it, the data is copied there with AfDataStore and an empty AfDataStore sends the message. The adapter
+//holds one such message at a time and keeps it until it is sent, so a failed transfer is rejected by the next
+//large request until the adapter is reset.
+func SendExt(z *znp.Znp, req *znp.AfDataRequestExt) error {
+	if len(req.Data) <= MaxInline {
+		rsp, err := z.AfDataRequestExt(req.DstAddrMode, req.DstAddr, req.DstEndpoint, req.DstPanID, req.SrcEndpoint,
+			req.ClusterID, req.TransID, req.Options, req.Radius, req.Data)
+		return check("AfDataRequestExt", rsp, err)
+	}
+	if len(req.Data) > 0xFFFF {
+		return fmt.Errorf("%w: %d bytes", ErrTooLong, len(req.Data))
+	}
+	stored := &storedRequest{DstAddrMode: req.DstAddrMode, DstAddr: req.DstAddr, DstEndpoint: req.DstEndpoint,
+		DstPanID: req.DstPanID, SrcEndpoint: req.SrcEndpoint, ClusterID: req.ClusterID, TransID: req.TransID,
+		Options: req.Options, Radius: req.Radius, Length: uint16(len(req.Data))}
+	var rsp *znp.StatusResponse
+	err := z.ProcessRequest(unp.C_SREQ, unp.S_AF, 0x02, stored, &rsp)
+	if err := check("AfDataRequestExt", rsp, err); err != nil {
+		return err
+	}
+	for index := 0; index < len(req.Data); index += chunk {
+		rsp, err := z.AfDataStore(uint16(index), req.Data[index:min(index+chunk, len(req.Data))])
+		if err := check("AfDataStore", rsp, err); err != nil {
+			return err
+		}
+	}
+	rsp, err = z.AfDataStore(0, nil)
+	return check("AfDataStore", rsp, err)
+}
+
+//Held reports whether the payload of the message is held by the adapter. The message carries the length of the
+//payload only, Data is zeroed then.
+func Held(msg *znp.AfIncomingMessageExt) bool {
+	return incomingExtHeader+len(msg.Data) > maxPayload
+}
+
+//Retrieve returns a copy of the message with the payload held by the adapter, which is freed. Messages carrying
+//their payload are returned as is. The message itself isn't modified as it is shared by all subscriptions.
+func Retrieve(z *znp.Znp, msg *znp.AfIncomingMessageExt) (_ *znp.AfIncomingMessageExt, err error) {
+	if !Held(msg) {
+		return msg, nil
+	}
+	defer func() {
+		rsp, freeErr := z.AfDataRetrieve(msg.Timestamp, 0, 0)
+		if err == nil && freeErr != nil {
+			err = freeErr
+		} else if err == nil && rsp.Status != znp.StatusSuccess {
+			err = fmt.Errorf("af: AfDataRetrieve failed: %s", rsp.Status)
+		}
+	}()
+	retrieved := *msg
+	retrieved.Data = make([]uint8, len(msg.Data))
+	for index := 0; index < len(msg.Data); index += chunk {
+		length := min(chunk, len(msg.Data)-index)
+		rsp, err := z.AfDataRetrieve(msg.Timestamp, uint16(index), uint8(length))
+		if err != nil {
+			return nil, err
+		}
+		if rsp.Status != znp.StatusSuccess {
+			return nil, fmt.Errorf("af: AfDataRetrieve failed: %s", rsp.Status)
+		}
+		if len(rsp.Data) != length {
+			return nil, fmt.Errorf("af: AfDataRetrieve returned %d bytes instead of %d", len(rsp.Data), length)
+		}
+		copy(retrieved.Data[index:], rsp.Data)
+	}
+	return &retrieved, nil
+}
+
+func check(command string, rsp *znp.StatusResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return &StatusError{Command: command, Status: rsp.Status}
+	}
+	return nil
+}
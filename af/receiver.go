@@ -0,0 +1,60 @@
+package af
+
+import (
+	"github.com/dyrkin/znp-go"
+)
+
+//Receiver is a subscription which retrieves the payloads held by the adapter before delivering
+//AfIncomingMessageExt, so that every message carries its payload
+type Receiver struct {
+	z            *znp.Znp
+	subscription *znp.Subscription
+	events       chan interface{}
+	errors       chan error
+}
+
+//NewReceiver subscribes to the async commands of the adapter. Call Stop when it is no longer needed.
+func NewReceiver(z *znp.Znp) *Receiver {
+	r := &Receiver{
+		z:            z,
+		subscription: z.Subscribe(),
+		events:       make(chan interface{}, 100),
+		errors:       make(chan error, 100),
+	}
+	go r.receive()
+	return r
+}
+
+//Events returns the channel async commands are delivered to. The channel is closed after Stop.
+func (r *Receiver) Events() chan interface{} {
+	return r.events
+}
+
+//Errors returns the channel retrieval failures are delivered to, the message is dropped then. Errors are dropped
+//when nobody reads the channel.
+func (r *Receiver) Errors() chan error {
+	return r.errors
+}
+
+//Stop stops the delivery of async commands
+func (r *Receiver) Stop() {
+	r.subscription.Unsubscribe()
+}
+
+func (r *Receiver) receive() {
+	defer close(r.events)
+	for async := range r.subscription.Events() {
+		if msg, ok := async.(*znp.AfIncomingMessageExt); ok {
+			retrieved, err := Retrieve(r.z, msg)
+			if err != nil {
+				select {
+				case r.errors <- err:
+				default:
+				}
+				continue
+			}
+			async = retrieved
+		}
+		r.events <- async
+	}
+}
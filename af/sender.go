@@ -0,0 +1,295 @@
+package af
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dyrkin/znp-go"
+)
+
+var errStopped = errors.New("af: sender stopped")
+
+//DefaultRadius is the maximum number of hops of the messages
+const DefaultRadius = 30
+
+//Destination of a unicast message
+type Destination struct {
+	//Addr is a network address such as "0x1a2b" or an IEEE address such as "0x00124b0001020304"
+	Addr        string
+	Endpoint    uint8
+	SrcEndpoint uint8 //Endpoint of the adapter. Default is 1
+}
+
+func (d Destination) srcEndpoint() uint8 {
+	if d.SrcEndpoint == 0 {
+		return 1
+	}
+	return d.SrcEndpoint
+}
+
+//extAddr returns the address mode and the address padded to 8 bytes as expected by AfDataRequestExt
+func (d Destination) extAddr() (znp.AddrMode, string, error) {
+	switch len(d.Addr) {
+	case 6:
+		return znp.AddrModeAddr16Bit, "0x000000000000" + d.Addr[2:], nil
+	case 18:
+		return znp.AddrModeAddr64Bit, d.Addr, nil
+	}
+	return 0, "", fmt.Errorf("af: invalid address %q", d.Addr)
+}
+
+//SendOptions are the options of SendAF. The zero value is a valid configuration.
+type SendOptions struct {
+	//APSAck requests an acknowledgement from the destination, otherwise the confirmation reports the delivery to
+	//the next hop only
+	APSAck  bool
+	Retries int //Number of times a temporary failure is retried
+	//DiscoverRoute requests a route discovery with ZdoExtRouteDisc before a retry. Network addresses only
+	DiscoverRoute bool
+	Radius        uint8 //Default is DefaultRadius
+}
+
+func (o *SendOptions) radius() uint8 {
+	if o.Radius == 0 {
+		return DefaultRadius
+	}
+	return o.Radius
+}
+
+//Fragmentation configures the APS fragmentation of an endpoint
+type Fragmentation struct {
+	FrameDelay uint8 //Delay between the blocks of a window in ms. Default is 50
+	WindowSize uint8 //Number of blocks acknowledged at once, up to 8. Default is 1
+}
+
+type confirmKey struct {
+	endpoint uint8
+	transID  uint8
+}
+
+//outcome is the AfDataConfirm or the AfReflectError of a message
+type outcome struct {
+	status  znp.Status
+	reflect *znp.AfReflectError
+}
+
+//Sender matches the AfDataConfirm of the sent messages. The TransIDs are taken from the allocator of the Znp, so
+//that several senders of an adapter don't consume the confirmations of each other.
+type Sender struct {
+	z            *znp.Znp
+	subscription *znp.Subscription
+
+	mu            sync.Mutex
+	pending       map[confirmKey]chan outcome
+	fragmentation map[uint8]Fragmentation
+	configured    map[uint8]Fragmentation //last configuration sent to the adapter
+	stopped       bool
+}
+
+//NewSender returns a sender watching the AfDataConfirm of the adapter. Call Stop when it is no longer needed. Several
+//senders can share an adapter, a single one is enough though.
+func NewSender(z *znp.Znp) *Sender {
+	s := &Sender{
+		z:             z,
+		subscription:  z.Subscribe(),
+		pending:       map[confirmKey]chan outcome{},
+		fragmentation: map[uint8]Fragmentation{},
+		configured:    map[uint8]Fragmentation{},
+	}
+	go s.watch()
+	return s
+}
+
+//SetFragmentation sets the configuration of the endpoint, it is sent to the adapter before the next large message
+func (s *Sender) SetFragmentation(endpoint uint8, f Fragmentation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fragmentation[endpoint] = f
+}
+
+//Stop stops watching the confirmations, pending sends fail
+func (s *Sender) Stop() {
+	s.subscription.Unsubscribe()
+}
+
+//SendAF sends the message and waits for its AfDataConfirm. Failed deliveries are retried as configured by the
+//options, which may be nil. The error of a failed delivery is a *DeliveryError.
+func (s *Sender) SendAF(ctx context.Context, dst Destination, cluster uint16, data []byte, options *SendOptions) error {
+	if options == nil {
+		options = &SendOptions{}
+	}
+	for attempt := 0; ; attempt++ {
+		err := s.send(ctx, dst, cluster, data, &znp.AfDataRequestOptions{APSAck: boolToUint8(options.APSAck)},
+			options.radius())
+		var delivery *DeliveryError
+		if !errors.As(err, &delivery) || !delivery.Temporary() || attempt >= options.Retries {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if options.DiscoverRoute && len(dst.Addr) == 6 {
+			rsp, err := s.z.ZdoExtRouteDisc(dst.Addr, 0, options.radius())
+			if err := check("ZdoExtRouteDisc", rsp, err); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+//SendLarge sends the payload, fragmented by the adapter when it exceeds the APS payload of a single frame, and
+//waits for the AfDataConfirm of the whole transfer. The fragmentation of the source endpoint is configured with
+//AfApsfConfigSet first. Fragmented messages are acknowledged, so the destination must be a single device.
+//The reassembly of incoming fragmented messages is done by the adapter, they are delivered as
+//AfIncomingMessageExt through a Receiver.
+func (s *Sender) SendLarge(ctx context.Context, dst Destination, cluster uint16, payload []byte) error {
+	if err := s.configure(dst.srcEndpoint()); err != nil {
+		return err
+	}
+	return s.send(ctx, dst, cluster, payload, &znp.AfDataRequestOptions{APSAck: 1, DiscoverRoute: 1},
+		DefaultRadius)
+}
+
+//SendSourceRouted sends the message through the relays with AfDataRequestSrcRtg and waits for its AfDataConfirm.
+//The relays are in the order reported by ZdoSrcRtgInd. Network addresses only.
+func (s *Sender) SendSourceRouted(ctx context.Context, dst Destination, relays []string, cluster uint16,
+	data []byte, options *SendOptions) error {
+	if len(dst.Addr) != 6 {
+		return fmt.Errorf("af: invalid network address %q", dst.Addr)
+	}
+	if len(data) > maxPayload-srcRtgHeader-2*len(relays) {
+		return fmt.Errorf("%w: %d bytes for %d relays", ErrTooLong, len(data), len(relays))
+	}
+	if options == nil {
+		options = &SendOptions{}
+	}
+	endpoint := dst.srcEndpoint()
+	return s.await(ctx, dst, func(transID uint8) error {
+		rsp, err := s.z.AfDataRequestSrcRtg(dst.Addr, dst.Endpoint, endpoint, cluster, transID,
+			&znp.AfDataRequestSrcRtgOptions{APSAck: boolToUint8(options.APSAck)}, options.radius(), relays, data)
+		return check("AfDataRequestSrcRtg", rsp, err)
+	})
+}
+
+func (s *Sender) send(ctx context.Context, dst Destination, cluster uint16, data []byte,
+	options *znp.AfDataRequestOptions, radius uint8) error {
+	addrMode, addr, err := dst.extAddr()
+	if err != nil {
+		return err
+	}
+	endpoint := dst.srcEndpoint()
+	return s.await(ctx, dst, func(transID uint8) error {
+		return SendExt(s.z, &znp.AfDataRequestExt{DstAddrMode: addrMode, DstAddr: addr, DstEndpoint: dst.Endpoint,
+			SrcEndpoint: endpoint, ClusterID: cluster, TransID: transID, Options: options, Radius: radius, Data: data})
+	})
+}
+
+//await sends the request with a new TransID of the source endpoint and waits for its confirmation
+func (s *Sender) await(ctx context.Context, dst Destination, request func(transID uint8) error) error {
+	endpoint := dst.srcEndpoint()
+	transID, confirm, err := s.register(endpoint)
+	if err != nil {
+		return err
+	}
+	defer s.unregister(endpoint, transID)
+	if err := request(transID); err != nil {
+		return err
+	}
+	select {
+	case outcome, ok := <-confirm:
+		if !ok {
+			return errStopped
+		}
+		if outcome.status != znp.StatusSuccess {
+			return &DeliveryError{Addr: dst.Addr, Status: outcome.status, Reflect: outcome.reflect}
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Sender) configure(endpoint uint8) error {
+	s.mu.Lock()
+	f := s.fragmentation[endpoint]
+	configured, ok := s.configured[endpoint]
+	s.mu.Unlock()
+	if f.FrameDelay == 0 {
+		f.FrameDelay = 50
+	}
+	if f.WindowSize == 0 {
+		f.WindowSize = 1
+	}
+	if ok && configured == f {
+		return nil
+	}
+	rsp, err := s.z.AfApsfConfigSet(endpoint, f.FrameDelay, f.WindowSize)
+	if err := check("AfApsfConfigSet", rsp, err); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.configured[endpoint] = f
+	s.mu.Unlock()
+	return nil
+}
+
+//register allocates the next TransID of the endpoint which isn't awaiting its confirmation. It fails with
+//ErrNoTransID when all of them are.
+func (s *Sender) register(endpoint uint8) (uint8, chan outcome, error) {
+	transID, ok := s.z.TransIDs().Acquire(endpoint)
+	if !ok {
+		return 0, nil, ErrNoTransID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	confirm := make(chan outcome, 1)
+	if s.stopped {
+		close(confirm)
+	} else {
+		s.pending[confirmKey{endpoint, transID}] = confirm
+	}
+	return transID, confirm, nil
+}
+
+func (s *Sender) unregister(endpoint uint8, transID uint8) {
+	s.mu.Lock()
+	delete(s.pending, confirmKey{endpoint, transID})
+	s.mu.Unlock()
+	s.z.TransIDs().Release(endpoint, transID)
+}
+
+func (s *Sender) watch() {
+	for async := range s.subscription.Events() {
+		switch async := async.(type) {
+		case *znp.AfDataConfirm:
+			s.complete(confirmKey{async.Endpoint, async.TransID}, outcome{status: async.Status})
+		case *znp.AfReflectError:
+			s.complete(confirmKey{async.Endpoint, async.TransID}, outcome{status: async.Status, reflect: async})
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	for key, pending := range s.pending {
+		close(pending)
+		delete(s.pending, key)
+	}
+}
+
+func (s *Sender) complete(key confirmKey, o outcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pending, ok := s.pending[key]; ok {
+		pending <- o
+		delete(s.pending, key)
+	}
+}
+
+func boolToUint8(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,63 @@
+package channels
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dyrkin/znp-go"
+)
+
+//changeScanDuration requests a channel change instead of a scan
+const changeScanDuration = 0xFE
+
+var (
+	pollInterval  = 500 * time.Millisecond
+	changeTimeout = 15 * time.Second
+)
+
+//Change moves the network to the channel. The request is broadcast to all devices, the adapter includes its
+//nwkUpdateId incremented by one, so that devices ignore repeated requests. The adapter waits for the broadcast to
+//be delivered before switching itself, which is checked with ZdoExtNwkInfo. When it doesn't switch, the request is
+//sent to it directly.
+func Change(z *znp.Znp, channel uint8) error {
+	if channel < 11 || channel > 26 {
+		return fmt.Errorf("channels: invalid channel %d", channel)
+	}
+	current, err := currentChannel(z)
+	if err != nil {
+		return err
+	}
+	if current == channel {
+		return nil
+	}
+	for _, target := range []struct {
+		addrMode znp.AddrMode
+		dstAddr  string
+	}{
+		{znp.AddrModeAddrBroadcast, "0xffff"},
+		{znp.AddrModeAddr16Bit, "0x0000"},
+	} {
+		rsp, err := z.ZdoMgmtNwkUpdateReq(target.dstAddr, target.addrMode, Mask(channel), changeScanDuration, 0,
+			"0x0000")
+		if err != nil {
+			return err
+		}
+		if rsp.Status != znp.StatusSuccess {
+			return fmt.Errorf("channels: change request to %s failed: %s", target.dstAddr, rsp.Status)
+		}
+		for deadline := time.Now().Add(changeTimeout); time.Now().Before(deadline); time.Sleep(pollInterval) {
+			if current, err = currentChannel(z); err == nil && current == channel {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("channels: the adapter is still on channel %d", current)
+}
+
+func currentChannel(z *znp.Znp) (uint8, error) {
+	info, err := z.ZdoExtNwkInfo()
+	if err != nil {
+		return 0, err
+	}
+	return info.Channel, nil
+}
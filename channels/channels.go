@@ -0,0 +1,181 @@
+//Package channels surveys the energy on the ZigBee channels and moves the network to another channel.
+//
+//	survey, err := (&channels.Scanner{}).Scan(z, "0x0000", "0x1a2b")
+//	err = channels.Change(z, survey.Recommended)
+package channels
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyrkin/bin"
+	"github.com/dyrkin/znp-go"
+)
+
+//All are the channels of the 2.4 GHz band
+var All = []uint8{11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26}
+
+//preferred are the channels least overlapping with Wi-Fi, they win ties
+var preferred = []uint8{15, 20, 25, 11}
+
+//Mask converts channel numbers into a channel mask
+func Mask(channels ...uint8) *znp.Channels {
+	var m uint32
+	for _, channel := range channels {
+		m |= 1 << channel
+	}
+	mask := &znp.Channels{}
+	bin.Decode(binary.LittleEndian.AppendUint32(nil, m), mask)
+	return mask
+}
+
+//FromMask converts a channel mask into channel numbers in ascending order
+func FromMask(mask *znp.Channels) []uint8 {
+	m := binary.LittleEndian.Uint32(bin.Encode(mask))
+	var channels []uint8
+	for _, channel := range All {
+		if m&(1<<channel) != 0 {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+//Scanner contains options of the energy scan. The zero value is a valid configuration.
+type Scanner struct {
+	Channels     []uint8 //Channels to scan. Default is All
+	ScanDuration uint8   //Exponent of the time spent on each channel, (2^n+1)*15.36ms, up to 5. Default is 3
+	//ScanCount is the number of scans. Default is 1
+	ScanCount uint8
+	Timeout   time.Duration //Time the routers have to report. Default is 30s
+}
+
+//Report is the energy measured by a router
+type Report struct {
+	Router               string
+	Energy               map[uint8]uint8 //Energy per channel, 0x00 is quiet and 0xFF saturated
+	TotalTransmissions   uint16
+	TransmissionFailures uint16
+}
+
+//Survey combines the reports of the routers
+type Survey struct {
+	Reports []*Report
+	//Missing are the routers which didn't report
+	Missing []string
+	Average map[uint8]float64
+	Max     map[uint8]uint8
+	//Recommended is the channel with the lowest average energy. Ties are resolved by the maximum energy and then
+	//by the channels least overlapping with Wi-Fi
+	Recommended uint8
+}
+
+//Scan asks the routers, including "0x0000" for the adapter, to measure the energy on the channels and waits
+//for their reports
+func (s *Scanner) Scan(z *znp.Znp, routers ...string) (*Survey, error) {
+	channels := s.Channels
+	if len(channels) == 0 {
+		channels = All
+	}
+	subscription := z.Subscribe()
+	defer subscription.Unsubscribe()
+
+	pending := map[string]bool{}
+	for _, router := range routers {
+		rsp, err := z.ZdoMgmtNwkUpdateReq(router, znp.AddrModeAddr16Bit, Mask(channels...),
+			orDefault(s.ScanDuration, 3), orDefault(s.ScanCount, 1), "0x0000")
+		if err != nil {
+			return nil, err
+		}
+		if rsp.Status != znp.StatusSuccess {
+			return nil, fmt.Errorf("channels: scan request to %s failed: %s", router, rsp.Status)
+		}
+		pending[strings.ToLower(router)] = true
+	}
+
+	survey := &Survey{}
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	deadline := time.After(timeout)
+wait:
+	for len(pending) > 0 {
+		select {
+		case async := <-subscription.Events():
+			notify, ok := async.(*znp.ZdoMgmtNwkUpdateNotify)
+			if !ok || !pending[notify.SrcAddr] || notify.Status != znp.StatusSuccess {
+				continue
+			}
+			delete(pending, notify.SrcAddr)
+			report := &Report{Router: notify.SrcAddr, Energy: map[uint8]uint8{},
+				TotalTransmissions: notify.TotalTransmissions, TransmissionFailures: notify.TransmissionFailures}
+			for i, channel := range FromMask(notify.ScannedChannels) {
+				if i < len(notify.EnergyValues) {
+					report.Energy[channel] = notify.EnergyValues[i]
+				}
+			}
+			survey.Reports = append(survey.Reports, report)
+		case <-deadline:
+			break wait
+		}
+	}
+	for _, router := range routers {
+		if pending[strings.ToLower(router)] {
+			survey.Missing = append(survey.Missing, router)
+		}
+	}
+	if len(survey.Reports) == 0 {
+		return survey, errors.New("channels: no router reported")
+	}
+	survey.summarize(channels)
+	return survey, nil
+}
+
+func (s *Survey) summarize(channels []uint8) {
+	s.Average, s.Max = map[uint8]float64{}, map[uint8]uint8{}
+	var candidates []uint8
+	for _, channel := range channels {
+		var sum, n int
+		for _, report := range s.Reports {
+			if energy, ok := report.Energy[channel]; ok {
+				sum, n = sum+int(energy), n+1
+				s.Max[channel] = max(s.Max[channel], energy)
+			}
+		}
+		if n > 0 {
+			s.Average[channel] = float64(sum) / float64(n)
+			candidates = append(candidates, channel)
+		}
+	}
+	rank := func(channel uint8) int {
+		for i, p := range preferred {
+			if p == channel {
+				return i
+			}
+		}
+		return len(preferred)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if s.Average[a] != s.Average[b] {
+			return s.Average[a] < s.Average[b]
+		}
+		if s.Max[a] != s.Max[b] {
+			return s.Max[a] < s.Max[b]
+		}
+		return rank(a) < rank(b)
+	})
+	s.Recommended = candidates[0]
+}
+
+func orDefault(v uint8, def uint8) uint8 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,91 @@
+package channels
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+//fakeAdapter reports the energy of the routers and switches the channel when the request is sent to it directly
+type fakeAdapter struct {
+	energy map[string][]uint8
+
+	mu       sync.Mutex
+	channel  uint8
+	requests []*znp.ZdoMgmtNwkUpdateReq
+}
+
+func (a *fakeAdapter) connect() *znp.Znp {
+	adapter := znptest.New()
+	adapter.Handle(unp.S_ZDO, 0x50, func(*znptest.Request) []byte {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return append(make([]byte, 22), a.channel)
+	})
+	adapter.Handle(unp.S_ZDO, 0x37, func(r *znptest.Request) []byte {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		req := &znp.ZdoMgmtNwkUpdateReq{}
+		r.Decode(req)
+		a.requests = append(a.requests, req)
+		if req.ScanDuration == changeScanDuration && req.DstAddr == "0x0000" {
+			a.channel = FromMask(req.ChannelMask)[0]
+		}
+		if energy, ok := a.energy[req.DstAddr]; ok && req.ScanDuration <= 5 {
+			r.Reply(unp.S_ZDO, 0xB8, &znp.ZdoMgmtNwkUpdateNotify{SrcAddr: req.DstAddr,
+				ScannedChannels: req.ChannelMask, EnergyValues: energy})
+		}
+		return nil
+	})
+	z := znp.New(adapter.Unp())
+	z.Start()
+	return z
+}
+
+func (s *MySuite) TestMask(c *C) {
+	c.Assert(Mask(11, 26), DeepEquals, &znp.Channels{Channel11: 1, Channel26: 1})
+	c.Assert(FromMask(Mask(All...)), DeepEquals, All)
+}
+
+func (s *MySuite) TestScan(c *C) {
+	a := &fakeAdapter{energy: map[string][]uint8{
+		"0x0000": {0x80, 0x10, 0x10, 0x40},
+		"0x1234": {0x90, 0x20, 0x30, 0x10},
+	}}
+	z := a.connect()
+
+	scanner := &Scanner{Channels: []uint8{11, 15, 20, 25}, Timeout: 100 * time.Millisecond}
+	survey, err := scanner.Scan(z, "0x0000", "0x1234", "0x5678")
+	c.Assert(err, IsNil)
+	c.Assert(survey.Reports, HasLen, 2)
+	c.Assert(survey.Missing, DeepEquals, []string{"0x5678"})
+	c.Assert(survey.Average, DeepEquals, map[uint8]float64{11: 0x88, 15: 0x18, 20: 0x20, 25: 0x28})
+	c.Assert(survey.Max[25], Equals, uint8(0x40))
+	c.Assert(survey.Recommended, Equals, uint8(15))
+	c.Assert(a.requests[0].ScanDuration, Equals, uint8(3))
+}
+
+func (s *MySuite) TestChange(c *C) {
+	pollInterval, changeTimeout = time.Millisecond, 20*time.Millisecond
+	a := &fakeAdapter{channel: 11}
+	z := a.connect()
+
+	c.Assert(Change(z, 25), IsNil)
+	c.Assert(a.channel, Equals, uint8(25))
+	c.Assert(a.requests, HasLen, 2)
+	c.Assert(a.requests[0].DstAddr, Equals, "0xffff")
+	c.Assert(a.requests[0].DstAddrMode, Equals, znp.AddrModeAddrBroadcast)
+	c.Assert(a.requests[1].DstAddr, Equals, "0x0000")
+	c.Assert(Change(z, 27), ErrorMatches, "channels: invalid channel 27")
+}
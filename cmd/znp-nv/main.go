@@ -0,0 +1,94 @@
+//znp-nv dumps the NV memory of an adapter and compares dumps.
+//
+//	znp-nv -port /dev/ttyACM0 dump > a.json
+//	znp-nv -tcp 192.168.1.10:6638 dump > b.json
+//	znp-nv diff a.json b.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	unp "github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/nv"
+	"github.com/dyrkin/znp-go/tcp"
+	"go.bug.st/serial.v1"
+)
+
+func main() {
+	portName := flag.String("port", "/dev/ttyACM0", "serial port of the adapter")
+	baudRate := flag.Int("baud", 115200, "baud rate")
+	address := flag.String("tcp", "", "address of a network attached adapter, used instead of -port")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] dump | diff OLD NEW\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	switch {
+	case flag.Arg(0) == "dump" && flag.NArg() == 1:
+		dump(open(*portName, *baudRate, *address))
+	case flag.Arg(0) == "diff" && flag.NArg() == 3:
+		diff(load(flag.Arg(1)), load(flag.Arg(2)))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func open(portName string, baudRate int, address string) io.ReadWriter {
+	if address != "" {
+		conn, err := tcp.Dial(address)
+		if err != nil {
+			log.Fatalf("Can't connect to adapter. Reason: %s", err)
+		}
+		return conn
+	}
+	port, err := serial.Open(portName, &serial.Mode{BaudRate: baudRate})
+	if err != nil {
+		log.Fatalf("Can't open port. Reason: %s", err)
+	}
+	port.SetRTS(true)
+	return port
+}
+
+func dump(port io.ReadWriter) {
+	z := znp.New(unp.New(1, port))
+	z.Start()
+	if z.DeviceInfo() == nil {
+		log.Fatal("Adapter doesn't respond")
+	}
+	snapshot, err := nv.Dump(z)
+	if err != nil {
+		log.Fatalf("Can't dump NV memory. Reason: %s", err)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(snapshot)
+}
+
+func load(name string) *nv.Snapshot {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		log.Fatalf("Can't read dump. Reason: %s", err)
+	}
+	snapshot := &nv.Snapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		log.Fatalf("Can't parse %s. Reason: %s", name, err)
+	}
+	return snapshot
+}
+
+func diff(old *nv.Snapshot, new *nv.Snapshot) {
+	if old.Product != new.Product || old.Version != new.Version {
+		fmt.Printf("Firmware: %s %s -> %s %s\n\n", old.Product, old.Version, new.Product, new.Version)
+	}
+	for _, change := range nv.Diff(old, new) {
+		fmt.Println(change)
+	}
+}
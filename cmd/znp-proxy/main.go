@@ -0,0 +1,98 @@
+//znp-proxy exposes a local serial adapter over TCP, so that it can be used with tcp.Dial from another host.
+//
+//	znp-proxy -port /dev/ttyACM0 -listen :6638
+//
+//By default clients are served one at a time: a client connecting while another one is active waits until the active
+//client disconnects. With -clients N up to N clients are served simultaneously through the mux package.
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"net"
+	"sync"
+
+	unp "github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go/mux"
+	"go.bug.st/serial.v1"
+)
+
+func main() {
+	portName := flag.String("port", "/dev/ttyACM0", "serial port of the adapter")
+	baudRate := flag.Int("baud", 115200, "baud rate")
+	listen := flag.String("listen", ":6638", "tcp address to listen on")
+	clients := flag.Int("clients", 1, "number of simultaneous clients")
+	flag.Parse()
+
+	port, err := serial.Open(*portName, &serial.Mode{BaudRate: *baudRate})
+	if err != nil {
+		log.Fatalf("Can't open port. Reason: %s", err)
+	}
+	port.SetRTS(true)
+
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		log.Fatalf("Can't listen. Reason: %s", err)
+	}
+	log.Printf("Serving %s on %s", *portName, listener.Addr())
+
+	if *clients > 1 {
+		m := mux.New(unp.New(1, port), *clients)
+		m.Start()
+		log.Fatal(m.Serve(listener))
+	}
+
+	p := &proxy{port: port}
+	go p.pumpSerial()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Fatalf("Can't accept connection. Reason: %s", err)
+		}
+		p.serve(conn)
+	}
+}
+
+type proxy struct {
+	port serial.Port
+
+	mu     sync.Mutex
+	client net.Conn
+}
+
+//pumpSerial forwards everything received from the adapter to the active client. Data received while no client is
+//connected is dropped.
+func (p *proxy) pumpSerial() {
+	buf := make([]byte, 256)
+	for {
+		n, err := p.port.Read(buf)
+		if err != nil {
+			log.Fatalf("Can't read from port. Reason: %s", err)
+		}
+		p.mu.Lock()
+		client := p.client
+		p.mu.Unlock()
+		if client != nil {
+			client.Write(buf[:n])
+		}
+	}
+}
+
+func (p *proxy) serve(conn net.Conn) {
+	log.Printf("Client %s connected", conn.RemoteAddr())
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		tcp.SetNoDelay(true)
+	}
+	p.mu.Lock()
+	p.client = conn
+	p.mu.Unlock()
+
+	_, err := io.Copy(p.port, conn)
+
+	p.mu.Lock()
+	p.client = nil
+	p.mu.Unlock()
+	conn.Close()
+	log.Printf("Client %s disconnected: %v", conn.RemoteAddr(), err)
+}
@@ -1,38 +1,41 @@
+// Code generated by mtgen from internal/mtgen/spec. DO NOT EDIT.
+
 package znp
 
 import unp "github.com/dyrkin/unp-go"
 
 // =======AF=======
 
-func (znp *Znp) AfRegister(endPoint uint8, appProfID uint16, appDeviceID uint16, addDevVer uint8,
-	latencyReq Latency, appInClusterList []uint16, appOutClusterList []uint16) (rsp *StatusResponse, err error) {
-	req := &AfRegister{EndPoint: endPoint, AppProfID: appProfID, AppDeviceID: appDeviceID,
-		AddDevVer: addDevVer, LatencyReq: latencyReq, AppInClusterList: appInClusterList, AppOutClusterList: appOutClusterList}
+func (znp *Znp) AfRegister(endPoint uint8, appProfID uint16, appDeviceID uint16, addDevVer uint8, latencyReq Latency,
+	appInClusterList []uint16, appOutClusterList []uint16) (rsp *StatusResponse, err error) {
+	req := &AfRegister{EndPoint: endPoint, AppProfID: appProfID, AppDeviceID: appDeviceID, AddDevVer: addDevVer,
+		LatencyReq: latencyReq, AppInClusterList: appInClusterList, AppOutClusterList: appOutClusterList}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_AF, 0x00, req, &rsp)
 	return
 }
 
-func (znp *Znp) AfDataRequest(dstAddr string, dstEndpoint uint8, srcEndpoint uint8, clusterId uint16,
-	transId uint8, options *AfDataRequestOptions, radius uint8, data []uint8) (rsp *StatusResponse, err error) {
-	req := &AfDataRequest{DstAddr: dstAddr, DstEndpoint: dstEndpoint, SrcEndpoint: srcEndpoint,
-		ClusterID: clusterId, TransID: transId, Options: options, Radius: radius, Data: data}
+func (znp *Znp) AfDataRequest(dstAddr string, dstEndpoint uint8, srcEndpoint uint8, clusterID uint16, transID uint8,
+	options *AfDataRequestOptions, radius uint8, data []uint8) (rsp *StatusResponse, err error) {
+	req := &AfDataRequest{DstAddr: dstAddr, DstEndpoint: dstEndpoint, SrcEndpoint: srcEndpoint, ClusterID: clusterID,
+		TransID: transID, Options: options, Radius: radius, Data: data}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_AF, 0x01, req, &rsp)
 	return
 }
 
-func (znp *Znp) AfDataRequestExt(dstAddrMode AddrMode, dstAddr string, dstEndpoint uint8, dstPanId uint16,
-	srcEndpoint uint8, clusterId uint16, transId uint8, options *AfDataRequestOptions, radius uint8,
+func (znp *Znp) AfDataRequestExt(dstAddrMode AddrMode, dstAddr string, dstEndpoint uint8, dstPanID uint16,
+	srcEndpoint uint8, clusterID uint16, transID uint8, options *AfDataRequestOptions, radius uint8,
 	data []uint8) (rsp *StatusResponse, err error) {
-	req := &AfDataRequestExt{DstAddrMode: dstAddrMode, DstAddr: dstAddr, DstEndpoint: dstEndpoint, DstPanID: dstPanId, SrcEndpoint: srcEndpoint,
-		ClusterID: clusterId, TransID: transId, Options: options, Radius: radius, Data: data}
+	req := &AfDataRequestExt{DstAddrMode: dstAddrMode, DstAddr: dstAddr, DstEndpoint: dstEndpoint, DstPanID: dstPanID,
+		SrcEndpoint: srcEndpoint, ClusterID: clusterID, TransID: transID, Options: options, Radius: radius, Data: data}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_AF, 0x02, req, &rsp)
 	return
 }
 
-func (znp *Znp) AfDataRequestSrcRtg(dstAddr string, dstEndpoint uint8, srcEndpoint uint8, clusterId uint16,
-	transId uint8, options *AfDataRequestSrcRtgOptions, radius uint8, relayList []string, data []uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) AfDataRequestSrcRtg(dstAddr string, dstEndpoint uint8, srcEndpoint uint8, clusterID uint16,
+	transID uint8, options *AfDataRequestSrcRtgOptions, radius uint8, relayList []string,
+	data []uint8) (rsp *StatusResponse, err error) {
 	req := &AfDataRequestSrcRtg{DstAddr: dstAddr, DstEndpoint: dstEndpoint, SrcEndpoint: srcEndpoint,
-		ClusterID: clusterId, TransID: transId, Options: options, Radius: radius, RelayList: relayList, Data: data}
+		ClusterID: clusterID, TransID: transID, Options: options, Radius: radius, RelayList: relayList, Data: data}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_AF, 0x03, req, &rsp)
 	return
 }
@@ -43,12 +46,16 @@ func (znp *Znp) AfInterPanCtl(command InterPanCommand, data AfInterPanCtlData) (
 	return
 }
 
+//AfDataStore copies a chunk of a message longer than one frame into the buffer allocated by AfDataRequestExt.
+//An empty chunk sends the message. See the af package.
 func (znp *Znp) AfDataStore(index uint16, data []uint8) (rsp *StatusResponse, err error) {
 	req := &AfDataStore{Index: index, Data: data}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_AF, 0x11, req, &rsp)
 	return
 }
 
+//AfDataRetrieve reads a chunk of an incoming message held by the adapter because it didn't fit into
+//AfIncomingMessageExt. A zero length frees the message.
 func (znp *Znp) AfDataRetrieve(timestamp uint32, index uint16, length uint8) (rsp *AfDataRetrieveResponse, err error) {
 	req := &AfDataRetrieve{Timestamp: timestamp, Index: index, Length: length}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_AF, 0x12, req, &rsp)
@@ -65,22 +72,23 @@ func (znp *Znp) AfApsfConfigSet(endpoint uint8, frameDelay uint8, windowSize uin
 
 func (znp *Znp) AppMsg(appEndpoint uint8, dstAddr string, dstEndpoint uint8, clusterID uint16,
 	message []uint8) (rsp *StatusResponse, err error) {
-	req := &AppMsg{AppEndpoint: appEndpoint, DstAddr: dstAddr, DstEndpoint: dstEndpoint,
-		ClusterID: clusterID, Message: message}
+	req := &AppMsg{AppEndpoint: appEndpoint, DstAddr: dstAddr, DstEndpoint: dstEndpoint, ClusterID: clusterID,
+		Message: message}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP, 0x00, req, &rsp)
 	return
 }
 
-func (znp *Znp) AppUserTest(srcEndpoint uint8, commandId uint16, parameter1 uint16, parameter2 uint16) (rsp *StatusResponse, err error) {
-	req := &AppUserTest{SrcEndpoint: srcEndpoint, CommandID: commandId, Parameter1: parameter1, Parameter2: parameter2}
+func (znp *Znp) AppUserTest(srcEndpoint uint8, commandID uint16, parameter1 uint16,
+	parameter2 uint16) (rsp *StatusResponse, err error) {
+	req := &AppUserTest{SrcEndpoint: srcEndpoint, CommandID: commandID, Parameter1: parameter1, Parameter2: parameter2}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP, 0x01, req, &rsp)
 	return
 }
 
 // =======DEBUG=======
 
-func (znp *Znp) DebugSetThreshold(componentId uint8, threshold uint8) (rsp *StatusResponse, err error) {
-	req := &DebugSetThreshold{ComponentID: componentId, Threshold: threshold}
+func (znp *Znp) DebugSetThreshold(componentID uint8, threshold uint8) (rsp *StatusResponse, err error) {
+	req := &DebugSetThreshold{ComponentID: componentID, Threshold: threshold}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_DBG, 0x00, req, &rsp)
 	return
 }
@@ -92,6 +100,74 @@ func (znp *Znp) DebugMsg(str string) error {
 
 // =======MAC======= is not supported on my device
 
+// =======NWK=======
+
+//NwkInit initializes the network layer. Requires a firmware built with MT_NWK_FUNC.
+func (znp *Znp) NwkInit() (rsp *StatusResponse, err error) {
+	err = znp.ProcessRequest(unp.C_SREQ, unp.S_NWK, 0x00, nil, &rsp)
+	return
+}
+
+//NwkNldeDataReq sends a network layer data frame. The result is reported with NwkNldeDataCnf.
+func (znp *Znp) NwkNldeDataReq(dstAddr string, nsdu []uint8, nsduHandle uint8, nsduHandleOptions uint16,
+	securityEnable uint8, discoverRoute uint8, radiusCounter uint8) (rsp *StatusResponse, err error) {
+	req := &NwkNldeDataReq{DstAddr: dstAddr, Nsdu: nsdu, NsduHandle: nsduHandle, NsduHandleOptions: nsduHandleOptions,
+		SecurityEnable: securityEnable, DiscoverRoute: discoverRoute, RadiusCounter: radiusCounter}
+	err = znp.ProcessRequest(unp.C_SREQ, unp.S_NWK, 0x01, req, &rsp)
+	return
+}
+
+//NwkNlmeNetworkFormationReq forms a new network as a coordinator. The result is reported with
+//NwkNlmeNetworkFormationCnf.
+func (znp *Znp) NwkNlmeNetworkFormationReq(panID uint16, channelList *Channels, scanDuration uint8, beaconOrder uint8,
+	superframeOrder uint8, batteryLifeExtension uint8) (rsp *StatusResponse, err error) {
+	req := &NwkNlmeNetworkFormationReq{PanID: panID, ChannelList: channelList, ScanDuration: scanDuration,
+		BeaconOrder: beaconOrder, SuperframeOrder: superframeOrder, BatteryLifeExtension: batteryLifeExtension}
+	err = znp.ProcessRequest(unp.C_SREQ, unp.S_NWK, 0x02, req, &rsp)
+	return
+}
+
+//NwkNlmePermitJoiningReq allows devices to join through this device for the given duration.
+func (znp *Znp) NwkNlmePermitJoiningReq(permitDuration uint8) (rsp *StatusResponse, err error) {
+	req := &NwkNlmePermitJoiningReq{PermitDuration: permitDuration}
+	err = znp.ProcessRequest(unp.C_SREQ, unp.S_NWK, 0x03, req, &rsp)
+	return
+}
+
+//NwkNlmeJoinReq joins the network previously found with NwkNlmeNetworkDiscoveryReq. The result is
+//reported with NwkNlmeJoinCnf.
+func (znp *Znp) NwkNlmeJoinReq(extendedPanID string, panID uint16, channel uint8,
+	capabilityInformation *CapInfo) (rsp *StatusResponse, err error) {
+	req := &NwkNlmeJoinReq{ExtendedPanID: extendedPanID, PanID: panID, Channel: channel,
+		CapabilityInformation: capabilityInformation}
+	err = znp.ProcessRequest(unp.C_SREQ, unp.S_NWK, 0x04, req, &rsp)
+	return
+}
+
+//NwkNlmeLeaveReq requests the device with the extended address to leave the network. Use the
+//address of this device to leave itself. The result is reported with NwkNlmeLeaveCnf.
+func (znp *Znp) NwkNlmeLeaveReq(extendedAddress string, removeChildren uint8,
+	rejoin uint8) (rsp *StatusResponse, err error) {
+	req := &NwkNlmeLeaveReq{ExtendedAddress: extendedAddress, RemoveChildren: removeChildren, Rejoin: rejoin}
+	err = znp.ProcessRequest(unp.C_SREQ, unp.S_NWK, 0x05, req, &rsp)
+	return
+}
+
+//NwkNlmeNetworkDiscoveryReq scans the channels for networks. They are reported with NwkNlmeNetworkDiscoveryCnf.
+func (znp *Znp) NwkNlmeNetworkDiscoveryReq(scanChannels *Channels,
+	scanDuration uint8) (rsp *StatusResponse, err error) {
+	req := &NwkNlmeNetworkDiscoveryReq{ScanChannels: scanChannels, ScanDuration: scanDuration}
+	err = znp.ProcessRequest(unp.C_SREQ, unp.S_NWK, 0x09, req, &rsp)
+	return
+}
+
+//NwkNlmeRouteDiscoveryReq initiates the discovery of a route to the destination.
+func (znp *Znp) NwkNlmeRouteDiscoveryReq(dstAddr string, options uint8, radius uint8) (rsp *StatusResponse, err error) {
+	req := &NwkNlmeRouteDiscoveryReq{DstAddr: dstAddr, Options: options, Radius: radius}
+	err = znp.ProcessRequest(unp.C_SREQ, unp.S_NWK, 0x0A, req, &rsp)
+	return
+}
+
 // =======SAPI=======
 
 func (znp *Znp) SapiZbSystemReset() error {
@@ -109,8 +185,8 @@ func (znp *Znp) SapiZbPermitJoiningRequest(destination string, timeout uint8) (r
 	return
 }
 
-func (znp *Znp) SapiZbBindDevice(create uint8, commandId uint16, destination string) (rsp *EmptyResponse, err error) {
-	req := &SapiZbBindDevice{Create: create, CommandID: commandId, Destination: destination}
+func (znp *Znp) SapiZbBindDevice(create uint8, commandID uint16, destination string) (rsp *EmptyResponse, err error) {
+	req := &SapiZbBindDevice{Create: create, CommandID: commandID, Destination: destination}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SAPI, 0x01, req, &rsp)
 	return
 }
@@ -121,10 +197,10 @@ func (znp *Znp) SapiZbAllowBind(timeout uint8) (rsp *EmptyResponse, err error) {
 	return
 }
 
-func (znp *Znp) SapiZbSendDataRequest(destination string, commandID uint16, handle uint8,
-	ack uint8, radius uint8, data []uint8) (rsp *EmptyResponse, err error) {
-	req := &SapiZbSendDataRequest{Destination: destination, CommandID: commandID,
-		Handle: handle, Ack: ack, Radius: radius, Data: data}
+func (znp *Znp) SapiZbSendDataRequest(destination string, commandID uint16, handle uint8, ack uint8, radius uint8,
+	data []uint8) (rsp *EmptyResponse, err error) {
+	req := &SapiZbSendDataRequest{Destination: destination, CommandID: commandID, Handle: handle, Ack: ack,
+		Radius: radius, Data: data}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SAPI, 0x03, req, &rsp)
 	return
 }
@@ -157,7 +233,7 @@ func (znp *Znp) SapiZbFindDeviceRequest(searchKey string) (rsp *EmptyResponse, e
 
 //SysReset is sent by the tester to reset the target device
 func (znp *Znp) SysResetReq(resetType byte) error {
-	req := &SysResetReq{resetType}
+	req := &SysResetReq{ResetType: resetType}
 	return znp.ProcessRequest(unp.C_AREQ, unp.S_SYS, 0x00, req, nil)
 }
 
@@ -173,8 +249,8 @@ func (znp *Znp) SysVersion() (rsp *SysVersionResponse, err error) {
 }
 
 //SysSetExtAddr is used to set the extended address of the device
-func (znp *Znp) SysSetExtAddr(extAddr string) (rsp *StatusResponse, err error) {
-	req := &SysSetExtAddr{ExtAddress: extAddr}
+func (znp *Znp) SysSetExtAddr(extAddress string) (rsp *StatusResponse, err error) {
+	req := &SysSetExtAddr{ExtAddress: extAddress}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x03, req, &rsp)
 	return
 }
@@ -187,8 +263,8 @@ func (znp *Znp) SysGetExtAddr() (rsp *SysGetExtAddrResponse, err error) {
 
 //SysRamRead is used by the tester to read a single memory location in the target RAM. The
 //command accepts an address value and returns the memory value present in the target RAM at that address.
-func (znp *Znp) SysRamRead(address uint16, len uint8) (rsp *SysRamReadResponse, err error) {
-	req := &SysRamRead{Address: address, Len: len}
+func (znp *Znp) SysRamRead(address uint16, length uint8) (rsp *SysRamReadResponse, err error) {
+	req := &SysRamRead{Address: address, Len: length}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x05, req, &rsp)
 	return
 }
@@ -205,7 +281,7 @@ func (znp *Znp) SysRamWrite(address uint16, value []uint8) (rsp *StatusResponse,
 //SysOsalNvRead is used by the tester to read a single memory item from the target non-volatile
 //memory. The command accepts an attribute Id value and data offset and returns the memory value
 //present in the target for the specified attribute Id.
-func (znp *Znp) SysOsalNvRead(id uint16, offset uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) SysOsalNvRead(id uint16, offset uint8) (rsp *SysOsalNvReadResponse, err error) {
 	req := &SysOsalNvRead{ID: id, Offset: offset}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x08, req, &rsp)
 	return
@@ -287,8 +363,8 @@ func (znp *Znp) SysGpio(operation Operation, value uint8) (rsp *SysGpioResponse,
 
 //SysSetTime is used by the tester to set the target system date and time. The time can be
 //specified in “seconds since 00:00:00 on January 1, 2000” or in parsed date/time components
-func (znp *Znp) SysSetTime(utcTime uint32, hour uint8, minute uint8, second uint8,
-	month uint8, day uint8, year uint16) (rsp *StatusResponse, err error) {
+func (znp *Znp) SysSetTime(utcTime uint32, hour uint8, minute uint8, second uint8, month uint8, day uint8,
+	year uint16) (rsp *StatusResponse, err error) {
 	req := &SysTime{UTCTime: utcTime, Hour: hour, Minute: minute, Second: second, Month: month, Day: day, Year: year}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x10, req, &rsp)
 	return
@@ -344,6 +420,9 @@ func (znp *Znp) SysZDiagsSaveStatsToNv() (rsp *SysZDiagsSaveStatsToNvResponse, e
 
 //SysNvCreate is used to attempt to create an item in non-volatile memory.
 func (znp *Znp) SysNvCreate(sysID uint8, itemID uint16, subID uint16, length uint32) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("SysNvCreate", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &SysNvCreate{SysID: sysID, ItemID: itemID, SubID: subID, Length: length}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x30, req, &rsp)
 	return
@@ -351,6 +430,9 @@ func (znp *Znp) SysNvCreate(sysID uint8, itemID uint16, subID uint16, length uin
 
 //SysNvDelete is used to attempt to delete an item in non-volatile memory.
 func (znp *Znp) SysNvDelete(sysID uint8, itemID uint16, subID uint16) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("SysNvDelete", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &SysNvDelete{SysID: sysID, ItemID: itemID, SubID: subID}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x31, req, &rsp)
 	return
@@ -358,20 +440,31 @@ func (znp *Znp) SysNvDelete(sysID uint8, itemID uint16, subID uint16) (rsp *Stat
 
 //SysNvLength is used to get the length of an item in non-volatile memory.
 func (znp *Znp) SysNvLength(sysID uint8, itemID uint16, subID uint16) (rsp *SysNvLengthResponse, err error) {
+	if err = znp.requireProduct("SysNvLength", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &SysNvLength{SysID: sysID, ItemID: itemID, SubID: subID}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x32, req, &rsp)
 	return
 }
 
 //SysNvRead is used to read an item in non-volatile memory
-func (znp *Znp) SysNvRead(sysID uint8, itemID uint16, subID uint16, offset uint16, length uint8) (rsp *SysNvReadResponse, err error) {
+func (znp *Znp) SysNvRead(sysID uint8, itemID uint16, subID uint16, offset uint16,
+	length uint8) (rsp *SysNvReadResponse, err error) {
+	if err = znp.requireProduct("SysNvRead", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &SysNvRead{SysID: sysID, ItemID: itemID, SubID: subID, Offset: offset, Length: length}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x33, req, &rsp)
 	return
 }
 
 //SysNvWrite is used to write an item in non-volatile memory
-func (znp *Znp) SysNvWrite(sysID uint8, itemID uint16, subID uint16, offset uint16, value []uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) SysNvWrite(sysID uint8, itemID uint16, subID uint16, offset uint16,
+	value []uint8) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("SysNvWrite", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &SysNvWrite{SysID: sysID, ItemID: itemID, SubID: subID, Offset: offset, Value: value}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x34, req, &rsp)
 	return
@@ -379,6 +472,9 @@ func (znp *Znp) SysNvWrite(sysID uint8, itemID uint16, subID uint16, offset uint
 
 //SysNvUpdate is used to update an item in non-volatile memory
 func (znp *Znp) SysNvUpdate(sysID uint8, itemID uint16, subID uint16, value []uint8) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("SysNvUpdate", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &SysNvUpdate{SysID: sysID, ItemID: itemID, SubID: subID, Value: value}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x35, req, &rsp)
 	return
@@ -386,6 +482,9 @@ func (znp *Znp) SysNvUpdate(sysID uint8, itemID uint16, subID uint16, value []ui
 
 //SysNvCompact is used to compact the active page in non-volatile memory
 func (znp *Znp) SysNvCompact(threshold uint16) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("SysNvCompact", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &SysNvCompact{Threshold: threshold}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x36, req, &rsp)
 	return
@@ -393,17 +492,23 @@ func (znp *Znp) SysNvCompact(threshold uint16) (rsp *StatusResponse, err error)
 
 //SysNvReadExt is used by the tester to read a single memory item from the target non-volatile
 //memory. The command accepts an attribute Id value and data offset and returns the memory value
-//present in the target for the specified attribute Id.
+//present in the target for the specified attribute Id. Unlike SysOsalNvRead it takes a 16-bit offset.
 func (znp *Znp) SysNvReadExt(id uint16, offset uint16) (rsp *SysNvReadResponse, err error) {
+	if err = znp.requireProduct("SysNvReadExt", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &SysNvReadExt{ID: id, Offset: offset}
-	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x08, req, &rsp)
+	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x1C, req, &rsp)
 	return
 }
 
-//SysNvWrite is used to write an item in non-volatile memory
+//SysNvWriteExt is used to write an item in non-volatile memory at a 16-bit offset
 func (znp *Znp) SysNvWriteExt(id uint16, offset uint16, value []uint8) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("SysNvWriteExt", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &SysNvWriteExt{ID: id, Offset: offset, Value: value}
-	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x09, req, &rsp)
+	err = znp.ProcessRequest(unp.C_SREQ, unp.S_SYS, 0x1D, req, &rsp)
 	return
 }
 
@@ -423,8 +528,8 @@ func (znp *Znp) UtilGetNvInfo() (rsp *UtilGetNvInfoResponse, err error) {
 }
 
 //UtilSetPanId stores a PanId value into non-volatile memory to be used the next time the target device resets.
-func (znp *Znp) UtilSetPanId(panId uint16) (rsp *StatusResponse, err error) {
-	req := &UtilSetPanId{PanID: panId}
+func (znp *Znp) UtilSetPanId(panID uint16) (rsp *StatusResponse, err error) {
+	req := &UtilSetPanId{PanID: panID}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_UTIL, 0x02, req, &rsp)
 	return
 }
@@ -508,22 +613,23 @@ func (znp *Znp) UtilSrcMatchEnable() (rsp *StatusResponse, err error) {
 }
 
 //UtilSrcMatchAddEntry is used to add a short or extended address to the source address table
-func (znp *Znp) UtilSrcMatchAddEntry(addrMode AddrMode, address string, panId uint16) (rsp *StatusResponse, err error) {
-	req := &UtilSrcMatchAddEntry{AddrMode: addrMode, Address: address, PanID: panId}
+func (znp *Znp) UtilSrcMatchAddEntry(addrMode AddrMode, address string, panID uint16) (rsp *StatusResponse, err error) {
+	req := &UtilSrcMatchAddEntry{AddrMode: addrMode, Address: address, PanID: panID}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_UTIL, 0x21, req, &rsp)
 	return
 }
 
 //UtilSrcMatchDelEntry is used to delete a short or extended address from the source address table.
-func (znp *Znp) UtilSrcMatchDelEntry(addrMode AddrMode, address string, panId uint16) (rsp *StatusResponse, err error) {
-	req := &UtilSrcMatchDelEntry{AddrMode: addrMode, Address: address, PanID: panId}
+func (znp *Znp) UtilSrcMatchDelEntry(addrMode AddrMode, address string, panID uint16) (rsp *StatusResponse, err error) {
+	req := &UtilSrcMatchDelEntry{AddrMode: addrMode, Address: address, PanID: panID}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_UTIL, 0x22, req, &rsp)
 	return
 }
 
 //UtilSrcMatchCheckSrcAddr is used to delete a short or extended address from the source address table.
-func (znp *Znp) UtilSrcMatchCheckSrcAddr(addrMode AddrMode, address string, panId uint16) (rsp *StatusResponse, err error) {
-	req := &UtilSrcMatchCheckSrcAddr{AddrMode: addrMode, Address: address, PanID: panId}
+func (znp *Znp) UtilSrcMatchCheckSrcAddr(addrMode AddrMode, address string,
+	panID uint16) (rsp *StatusResponse, err error) {
+	req := &UtilSrcMatchCheckSrcAddr{AddrMode: addrMode, Address: address, PanID: panID}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_UTIL, 0x23, req, &rsp)
 	return
 }
@@ -599,15 +705,17 @@ func (znp *Znp) UtilAssocGetWithAddr(extAddr string, nwkAddr string) (rsp *UtilA
 }
 
 //UtilBindAddEntry is a proxy call to the bindAddEntry() function
-func (znp *Znp) UtilBindAddEntry(addrMode AddrMode, dstAddr string, dstEndpoint uint8, clusterIds []uint16) (rsp *UtilBindAddEntryResponse, err error) {
-	req := &UtilBindAddEntry{AddrMode: addrMode, DstAddr: dstAddr, DstEndpoint: dstEndpoint, ClusterIDs: clusterIds}
+func (znp *Znp) UtilBindAddEntry(addrMode AddrMode, dstAddr string, dstEndpoint uint8,
+	clusterIDs []uint16) (rsp *UtilBindAddEntryResponse, err error) {
+	req := &UtilBindAddEntry{AddrMode: addrMode, DstAddr: dstAddr, DstEndpoint: dstEndpoint, ClusterIDs: clusterIDs}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_UTIL, 0x4D, req, &rsp)
 	return
 }
 
 //UtilZclKeyEstInitEst is a proxy call to zclGeneral_KeyEstablish_InitiateKeyEstablishment().
-func (znp *Znp) UtilZclKeyEstInitEst(taskId uint8, seqNum uint8, endPoint uint8, addrMode AddrMode, addr string) (rsp *StatusResponse, err error) {
-	req := &UtilZclKeyEstInitEst{TaskID: taskId, SeqNum: seqNum, EndPoint: endPoint, AddrMode: addrMode, Addr: addr}
+func (znp *Znp) UtilZclKeyEstInitEst(taskID uint8, seqNum uint8, endPoint uint8, addrMode AddrMode,
+	addr string) (rsp *StatusResponse, err error) {
+	req := &UtilZclKeyEstInitEst{TaskID: taskID, SeqNum: seqNum, EndPoint: endPoint, AddrMode: addrMode, Addr: addr}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_UTIL, 0x80, req, &rsp)
 	return
 }
@@ -628,9 +736,8 @@ func (znp *Znp) UtilSrngGen() (rsp *UtilSrngGenResponse, err error) {
 }
 
 //UtilSyncReq is an asynchronous request/response handshake.
-func (znp *Znp) UtilSyncReq() (err error) {
-	err = znp.ProcessRequest(unp.C_AREQ, unp.S_UTIL, 0xE0, nil, nil)
-	return
+func (znp *Znp) UtilSyncReq() error {
+	return znp.ProcessRequest(unp.C_AREQ, unp.S_UTIL, 0xE0, nil, nil)
 }
 
 // =======ZDO=======
@@ -673,7 +780,8 @@ func (znp *Znp) ZdoPowerDescReq(dstAddr string, nwkAddrOfInterest string) (rsp *
 
 //ZdoSimpleDescReq is generated to inquire as to the Simple Descriptor of the destination device’s
 //Endpoint.
-func (znp *Znp) ZdoSimpleDescReq(dstAddr string, nwkAddrOfInterest string, endpoint uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) ZdoSimpleDescReq(dstAddr string, nwkAddrOfInterest string,
+	endpoint uint8) (rsp *StatusResponse, err error) {
 	req := &ZdoSimpleDescReq{DstAddr: dstAddr, NWKAddrOfInterest: nwkAddrOfInterest, Endpoint: endpoint}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x04, req, &rsp)
 	return
@@ -687,9 +795,9 @@ func (znp *Znp) ZdoActiveEpReq(dstAddr string, nwkAddrOfInterest string) (rsp *S
 }
 
 //ZdoMatchDescReq is generated to request the device match descriptor
-func (znp *Znp) ZdoMatchDescReq(dstAddr string, nwkAddrOfInterest string, profileId uint16,
-	inClusterList []uint16, outClusterList []uint16) (rsp *StatusResponse, err error) {
-	req := &ZdoMatchDescReq{DstAddr: dstAddr, NWKAddrOfInterest: nwkAddrOfInterest, ProfileID: profileId,
+func (znp *Znp) ZdoMatchDescReq(dstAddr string, nwkAddrOfInterest string, profileID uint16, inClusterList []uint16,
+	outClusterList []uint16) (rsp *StatusResponse, err error) {
+	req := &ZdoMatchDescReq{DstAddr: dstAddr, NWKAddrOfInterest: nwkAddrOfInterest, ProfileID: profileID,
 		InClusterList: inClusterList, OutClusterList: outClusterList}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x06, req, &rsp)
 	return
@@ -711,14 +819,16 @@ func (znp *Znp) ZdoUserDescReq(dstAddr string, nwkAddrOfInterest string) (rsp *S
 
 //ZdoEndDeviceAnnce will cause the device to issue an “End device announce” broadcast packet to the
 //network. This is typically used by an end-device to announce itself to the network.
-func (znp *Znp) ZdoEndDeviceAnnce(nwkAddr string, ieeeAddr string, capabilities *CapInfo) (rsp *StatusResponse, err error) {
+func (znp *Znp) ZdoEndDeviceAnnce(nwkAddr string, ieeeAddr string,
+	capabilities *CapInfo) (rsp *StatusResponse, err error) {
 	req := &ZdoEndDeviceAnnce{NwkAddr: nwkAddr, IEEEAddr: ieeeAddr, Capabilities: capabilities}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x0A, req, &rsp)
 	return
 }
 
 //ZdoUserDescSet is generated to write a User Descriptor value to the targeted device.
-func (znp *Znp) ZdoUserDescSet(dstAddr string, nwkAddrOfInterest string, userDescriptor string) (rsp *StatusResponse, err error) {
+func (znp *Znp) ZdoUserDescSet(dstAddr string, nwkAddrOfInterest string,
+	userDescriptor string) (rsp *StatusResponse, err error) {
 	req := &ZdoUserDescSet{DstAddr: dstAddr, NWKAddrOfInterest: nwkAddrOfInterest, UserDescriptor: userDescriptor}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x0B, req, &rsp)
 	return
@@ -735,34 +845,36 @@ func (znp *Znp) ZdoServerDiscReq(serverMask *ServerMask) (rsp *StatusResponse, e
 
 //ZdoEndDeviceBindReq is generated to request an End Device Bind with the destination device.
 func (znp *Znp) ZdoEndDeviceBindReq(dstAddr string, localCoordinatorAddr string, ieeeAddr string, endpoint uint8,
-	profileId uint16, inClusterList []uint16, outClusterList []uint16) (rsp *StatusResponse, err error) {
+	profileID uint16, inClusterList []uint16, outClusterList []uint16) (rsp *StatusResponse, err error) {
 	req := &ZdoEndDeviceBindReq{DstAddr: dstAddr, LocalCoordinatorAddr: localCoordinatorAddr, IEEEAddr: ieeeAddr,
-		Endpoint: endpoint, ProfileID: profileId, InClusterList: inClusterList, OutClusterList: outClusterList}
+		Endpoint: endpoint, ProfileID: profileID, InClusterList: inClusterList, OutClusterList: outClusterList}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x20, req, &rsp)
 	return
 }
 
 //ZdoBindReq is generated to request an End Device Bind with the destination device.
-func (znp *Znp) ZdoBindReq(dstAddr string, srcAddress string, srcEndpoint uint8, clusterId uint16,
-	dstAddrMode AddrMode, dstAddress string, dstEndpoint uint8) (rsp *StatusResponse, err error) {
-	req := &ZdoBindUnbindReq{DstAddr: dstAddr, SrcAddress: srcAddress, SrcEndpoint: srcEndpoint, ClusterID: clusterId,
+func (znp *Znp) ZdoBindReq(dstAddr string, srcAddress string, srcEndpoint uint8, clusterID uint16, dstAddrMode AddrMode,
+	dstAddress string, dstEndpoint uint8) (rsp *StatusResponse, err error) {
+	req := &ZdoBindUnbindReq{DstAddr: dstAddr, SrcAddress: srcAddress, SrcEndpoint: srcEndpoint, ClusterID: clusterID,
 		DstAddrMode: dstAddrMode, DstAddress: dstAddress, DstEndpoint: dstEndpoint}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x21, req, &rsp)
 	return
 }
 
 //ZdoUnbindReq is generated to request a un-bind.
-func (znp *Znp) ZdoUnbindReq(dstAddr string, srcAddress string, srcEndpoint uint8, clusterId uint16,
+func (znp *Znp) ZdoUnbindReq(dstAddr string, srcAddress string, srcEndpoint uint8, clusterID uint16,
 	dstAddrMode AddrMode, dstAddress string, dstEndpoint uint8) (rsp *StatusResponse, err error) {
-	req := &ZdoBindUnbindReq{DstAddr: dstAddr, SrcAddress: srcAddress, SrcEndpoint: srcEndpoint, ClusterID: clusterId,
+	req := &ZdoBindUnbindReq{DstAddr: dstAddr, SrcAddress: srcAddress, SrcEndpoint: srcEndpoint, ClusterID: clusterID,
 		DstAddrMode: dstAddrMode, DstAddress: dstAddress, DstEndpoint: dstEndpoint}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x22, req, &rsp)
 	return
 }
 
 //ZdoMgmtNwkDiskReq is generated to request the destination device to perform a network discovery
-func (znp *Znp) ZdoMgmtNwkDiskReq(dstAddr string, scanChannels *Channels, scanDuration uint8, startIndex uint8) (rsp *StatusResponse, err error) {
-	req := &ZdoMgmtNwkDiskReq{DstAddr: dstAddr, ScanChannels: scanChannels, ScanDuration: scanDuration, StartIndex: startIndex}
+func (znp *Znp) ZdoMgmtNwkDiskReq(dstAddr string, scanChannels *Channels, scanDuration uint8,
+	startIndex uint8) (rsp *StatusResponse, err error) {
+	req := &ZdoMgmtNwkDiskReq{DstAddr: dstAddr, ScanChannels: scanChannels, ScanDuration: scanDuration,
+		StartIndex: startIndex}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x30, req, &rsp)
 	return
 }
@@ -790,7 +902,8 @@ func (znp *Znp) ZdoMgmtBindReq(dstAddr string, startIndex uint8) (rsp *StatusRes
 }
 
 //ZdoMgmtLeaveReq is generated to request a Management Leave Request for the target device
-func (znp *Znp) ZdoMgmtLeaveReq(dstAddr string, deviceAddr string, removeChildrenRejoin *RemoveChildrenRejoin) (rsp *StatusResponse, err error) {
+func (znp *Znp) ZdoMgmtLeaveReq(dstAddr string, deviceAddr string,
+	removeChildrenRejoin *RemoveChildrenRejoin) (rsp *StatusResponse, err error) {
 	req := &ZdoMgmtLeaveReq{DstAddr: dstAddr, DeviceAddr: deviceAddr, RemoveChildrenRejoin: removeChildrenRejoin}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x34, req, &rsp)
 	return
@@ -798,39 +911,44 @@ func (znp *Znp) ZdoMgmtLeaveReq(dstAddr string, deviceAddr string, removeChildre
 
 //ZdoMgmtDirectJoinReq is generated to request the Management Direct Join Request of a designated
 //device.
-func (znp *Znp) ZdoMgmtDirectJoinReq(dstAddr string, deviceAddr string, capInfo *CapInfo) (rsp *StatusResponse, err error) {
+func (znp *Znp) ZdoMgmtDirectJoinReq(dstAddr string, deviceAddr string,
+	capInfo *CapInfo) (rsp *StatusResponse, err error) {
 	req := &ZdoMgmtDirectJoinReq{DstAddr: dstAddr, DeviceAddr: deviceAddr, CapInfo: capInfo}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x35, req, &rsp)
 	return
 }
 
 //ZdoMgmtPermitJoinReq is generated to set the Permit Join for the destination device.
-func (znp *Znp) ZdoMgmtPermitJoinReq(addrMode AddrMode, dstAddr string, duration uint8, tcSignificance uint8) (rsp *StatusResponse, err error) {
-	req := &ZdoMgmtPermitJoinReq{AddrMode: addrMode, DstAddr: dstAddr, Duration: duration, TCSignificance: tcSignificance}
+func (znp *Znp) ZdoMgmtPermitJoinReq(addrMode AddrMode, dstAddr string, duration uint8,
+	tcSignificance uint8) (rsp *StatusResponse, err error) {
+	req := &ZdoMgmtPermitJoinReq{AddrMode: addrMode, DstAddr: dstAddr, Duration: duration,
+		TCSignificance: tcSignificance}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x36, req, &rsp)
 	return
 }
 
 //ZdoMgmtNwkUpdateReq is provided to allow updating of network configuration parameters or to request
 //information from devices on network conditions in the local operating environment.
-func (znp *Znp) ZdoMgmtNwkUpdateReq(dstAddr string, dstAddrMode AddrMode, channelMask *Channels, scanDuration uint8) (rsp *StatusResponse, err error) {
-	req := &ZdoMgmtNwkUpdateReq{DstAddr: dstAddr, DstAddrMode: dstAddrMode, ChannelMask: channelMask, ScanDuration: scanDuration}
+func (znp *Znp) ZdoMgmtNwkUpdateReq(dstAddr string, dstAddrMode AddrMode, channelMask *Channels, scanDuration uint8,
+	scanCount uint8, nwkManagerAddr string) (rsp *StatusResponse, err error) {
+	req := &ZdoMgmtNwkUpdateReq{DstAddr: dstAddr, DstAddrMode: dstAddrMode, ChannelMask: channelMask,
+		ScanDuration: scanDuration, ScanCount: scanCount, NwkManagerAddr: nwkManagerAddr}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x37, req, &rsp)
 	return
 }
 
 //ZdoMsgCbRegister registers for a ZDO callback (see reference [3], “6. ZDO Message Requests” for
 //example usage).
-func (znp *Znp) ZdoMsgCbRegister(clusterId uint16) (rsp *StatusResponse, err error) {
-	req := &ZdoMsgCbRegister{ClusterID: clusterId}
+func (znp *Znp) ZdoMsgCbRegister(clusterID uint16) (rsp *StatusResponse, err error) {
+	req := &ZdoMsgCbRegister{ClusterID: clusterID}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x3E, req, &rsp)
 	return
 }
 
 //ZdoMsgCbRemove removes a registration for a ZDO callback (see reference [3], “6. ZDO Message
 //Requests” for example usage).
-func (znp *Znp) ZdoMsgCbRemove(clusterId uint16) (rsp *StatusResponse, err error) {
-	req := &ZdoMsgCbRemove{ClusterID: clusterId}
+func (znp *Znp) ZdoMsgCbRemove(clusterID uint16) (rsp *StatusResponse, err error) {
+	req := &ZdoMsgCbRemove{ClusterID: clusterID}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x3F, req, &rsp)
 	return
 }
@@ -842,8 +960,9 @@ func (znp *Znp) ZdoStartupFromApp(startDelay uint16) (rsp *ZdoStartupFromAppResp
 	return
 }
 
-//ZdoSetLinkKey starts the device in the network.
-func (znp *Znp) ZdoSetLinkKey(shortAddr string, ieeeAddr string, linkKeyData [16]uint8) (rsp *StatusResponse, err error) {
+//ZdoSetLinkKey sets the application link key of a given device.
+func (znp *Znp) ZdoSetLinkKey(shortAddr string, ieeeAddr string,
+	linkKeyData [16]uint8) (rsp *StatusResponse, err error) {
 	req := &ZdoSetLinkKey{ShortAddr: shortAddr, IEEEAddr: ieeeAddr, LinkKeyData: linkKeyData}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x23, req, &rsp)
 	return
@@ -872,9 +991,9 @@ func (znp *Znp) ZdoNwkDiscoveryReq(scanChannels *Channels, scanDuration uint8) (
 }
 
 //ZdoJoinReq is used to request the device to join itself to a parent device on a network.
-func (znp *Znp) ZdoJoinReq(logicalChannel uint8, panId uint16, extendedPanId uint64,
-	chosenParent string, parentDepth uint8, stackProfile uint8) (rsp *StatusResponse, err error) {
-	req := &ZdoJoinReq{LogicalChannel: logicalChannel, PanID: panId, ExtendedPanID: extendedPanId,
+func (znp *Znp) ZdoJoinReq(logicalChannel uint8, panID uint16, extendedPanID uint64, chosenParent string,
+	parentDepth uint8, stackProfile uint8) (rsp *StatusResponse, err error) {
+	req := &ZdoJoinReq{LogicalChannel: logicalChannel, PanID: panID, ExtendedPanID: extendedPanID,
 		ChosenParent: chosenParent, ParentDepth: parentDepth, StackProfile: stackProfile}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x27, req, &rsp)
 	return
@@ -888,14 +1007,16 @@ func (znp *Znp) ZdoSetRejoinParameters(backoffDuration uint32, scanDuration uint
 }
 
 //ZdoSecAddLinkKey handles the ZDO security add link key extension message.
-func (znp *Znp) ZdoSecAddLinkKey(shortAddress string, extendedAddress string, key [16]uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) ZdoSecAddLinkKey(shortAddress string, extendedAddress string,
+	key [16]uint8) (rsp *StatusResponse, err error) {
 	req := &ZdoSecAddLinkKey{ShortAddress: shortAddress, ExtendedAddress: extendedAddress, Key: key}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x42, req, &rsp)
 	return
 }
 
 //ZdoSecEntryLookupExt handles the ZDO security entry lookup extended extension message
-func (znp *Znp) ZdoSecEntryLookupExt(extendedAddress string, entry [5]uint8) (rsp *ZdoSecEntryLookupExtResponse, err error) {
+func (znp *Znp) ZdoSecEntryLookupExt(extendedAddress string,
+	entry [5]uint8) (rsp *ZdoSecEntryLookupExtResponse, err error) {
 	req := &ZdoSecEntryLookupExt{ExtendedAddress: extendedAddress, Entry: entry}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x43, req, &rsp)
 	return
@@ -909,22 +1030,24 @@ func (znp *Znp) ZdoSecDeviceRemove(extendedAddress string) (rsp *StatusResponse,
 }
 
 //ZdoExtRouteDisc handles the ZDO route discovery extension message.
-func (znp *Znp) ZdoExtRouteDisc(destinationAddress string, options uint8, radius uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) ZdoExtRouteDisc(destinationAddress string, options uint8,
+	radius uint8) (rsp *StatusResponse, err error) {
 	req := &ZdoExtRouteDisc{DestinationAddress: destinationAddress, Options: options, Radius: radius}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x45, req, &rsp)
 	return
 }
 
 //ZdoExtRouteCheck handles the ZDO route check extension message.
-func (znp *Znp) ZdoExtRouteCheck(destinationAddress string, rtStatus uint8, options uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) ZdoExtRouteCheck(destinationAddress string, rtStatus uint8,
+	options uint8) (rsp *StatusResponse, err error) {
 	req := &ZdoExtRouteCheck{DestinationAddress: destinationAddress, RTStatus: rtStatus, Options: options}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x46, req, &rsp)
 	return
 }
 
 //ZdoExtRemoveGroup handles the ZDO extended remove group extension message.
-func (znp *Znp) ZdoExtRemoveGroup(endpoint uint8, groupId uint16) (rsp *StatusResponse, err error) {
-	req := &ZdoExtRemoveGroup{Endpoint: endpoint, GroupID: groupId}
+func (znp *Znp) ZdoExtRemoveGroup(endpoint uint8, groupID uint16) (rsp *StatusResponse, err error) {
+	req := &ZdoExtRemoveGroup{Endpoint: endpoint, GroupID: groupID}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x47, req, &rsp)
 	return
 }
@@ -937,7 +1060,8 @@ func (znp *Znp) ZdoExtRemoveAllGroup(endpoint uint8) (rsp *StatusResponse, err e
 }
 
 //ZdoExtFindAllGroupsEndpoint handles the ZDO extension find all groups for endpoint message
-func (znp *Znp) ZdoExtFindAllGroupsEndpoint(endpoint uint8, groupList []uint16) (rsp *ZdoExtFindAllGroupsEndpointResponse, err error) {
+func (znp *Znp) ZdoExtFindAllGroupsEndpoint(endpoint uint8,
+	groupList []uint16) (rsp *ZdoExtFindAllGroupsEndpointResponse, err error) {
 	req := &ZdoExtFindAllGroupsEndpoint{Endpoint: endpoint, GroupList: groupList}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x49, req, &rsp)
 	return
@@ -964,14 +1088,15 @@ func (znp *Znp) ZdoExtCountAllGroups() (rsp *ZdoExtCountAllGroupsResponse, err e
 }
 
 //ZdoExtRxIdle handles the ZDO extension Get/Set RxOnIdle to ZMac message
-func (znp *Znp) ZdoExtRxIdle(setFlag uint8, setValue uint8) (rsp *StatusResponse, err error) { //very unclear from the docs and the code
+func (znp *Znp) ZdoExtRxIdle(setFlag uint8, setValue uint8) (rsp *StatusResponse, err error) {
 	req := &ZdoExtRxIdle{SetFlag: setFlag, SetValue: setValue}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x4D, req, &rsp)
 	return
 }
 
 //ZdoExtUpdateNwkKey handles the ZDO security update network key extension message.
-func (znp *Znp) ZdoExtUpdateNwkKey(destinationAddress string, keySeqNum uint8, key [128]uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) ZdoExtUpdateNwkKey(destinationAddress string, keySeqNum uint8,
+	key [16]uint8) (rsp *StatusResponse, err error) {
 	req := &ZdoExtUpdateNwkKey{DestinationAddress: destinationAddress, KeySeqNum: keySeqNum, Key: key}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x4E, req, &rsp)
 	return
@@ -991,8 +1116,10 @@ func (znp *Znp) ZdoExtNwkInfo() (rsp *ZdoExtNwkInfoResponse, err error) {
 }
 
 //ZdoExtSeqApsRemoveReq handles the ZDO extension Security Manager APS Remove Request message.
-func (znp *Znp) ZdoExtSeqApsRemoveReq(nwkAddress string, extendedAddress string, parentAddress string) (rsp *StatusResponse, err error) {
-	req := &ZdoExtSeqApsRemoveReq{NwkAddress: nwkAddress, ExtendedAddress: extendedAddress, ParentAddress: parentAddress}
+func (znp *Znp) ZdoExtSeqApsRemoveReq(nwkAddress string, extendedAddress string,
+	parentAddress string) (rsp *StatusResponse, err error) {
+	req := &ZdoExtSeqApsRemoveReq{NwkAddress: nwkAddress, ExtendedAddress: extendedAddress,
+		ParentAddress: parentAddress}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x51, req, &rsp)
 	return
 }
@@ -1011,7 +1138,8 @@ func (znp *Znp) ZdoExtSetParams(useMulticast uint8) (rsp *StatusResponse, err er
 }
 
 //ZdoNwkAddrOfInterestReq handles ZDO network address of interest request.
-func (znp *Znp) ZdoNwkAddrOfInterestReq(destAddr string, nwkAddrOfInterest string, cmd uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) ZdoNwkAddrOfInterestReq(destAddr string, nwkAddrOfInterest string,
+	cmd uint8) (rsp *StatusResponse, err error) {
 	req := &ZdoNwkAddrOfInterestReq{DestAddr: destAddr, NwkAddrOfInterest: nwkAddrOfInterest, Cmd: cmd}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_ZDO, 0x29, req, &rsp)
 	return
@@ -1022,7 +1150,10 @@ func (znp *Znp) ZdoNwkAddrOfInterestReq(destAddr string, nwkAddrOfInterest strin
 //AppCnfSetNwkFrameCounter sets the network frame counter to the value specified in the Frame Counter Value.
 //For projects with multiple instances of frame counter, the message sets the frame counter of the
 //current network.
-func (znp *Znp) AppCnfSetNwkFrameCounter(frameCounterValue uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) AppCnfSetNwkFrameCounter(frameCounterValue uint32) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("AppCnfSetNwkFrameCounter", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &AppCnfSetNwkFrameCounter{FrameCounterValue: frameCounterValue}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP_CNF, 0xFF, req, &rsp)
 	return
@@ -1030,6 +1161,9 @@ func (znp *Znp) AppCnfSetNwkFrameCounter(frameCounterValue uint8) (rsp *StatusRe
 
 //AppCnfSetDefaultEndDeviceTimeout sets the default value used by parent device to expire legacy child devices.
 func (znp *Znp) AppCnfSetDefaultEndDeviceTimeout(timeout Timeout) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("AppCnfSetDefaultEndDeviceTimeout", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &AppCnfSetDefaultEndDeviceTimeout{Timeout: timeout}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP_CNF, 0x01, req, &rsp)
 	return
@@ -1037,6 +1171,9 @@ func (znp *Znp) AppCnfSetDefaultEndDeviceTimeout(timeout Timeout) (rsp *StatusRe
 
 //AppCnfSetEndDeviceTimeout sets in ZED the timeout value to be send to parent device for child expiring.
 func (znp *Znp) AppCnfSetEndDeviceTimeout(timeout Timeout) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("AppCnfSetEndDeviceTimeout", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &AppCnfSetEndDeviceTimeout{Timeout: timeout}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP_CNF, 0x02, req, &rsp)
 	return
@@ -1044,6 +1181,9 @@ func (znp *Znp) AppCnfSetEndDeviceTimeout(timeout Timeout) (rsp *StatusResponse,
 
 //AppCnfSetAllowRejoinTcPolicy sets the AllowRejoin TC policy.
 func (znp *Znp) AppCnfSetAllowRejoinTcPolicy(allowRejoin uint8) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("AppCnfSetAllowRejoinTcPolicy", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &AppCnfSetAllowRejoinTcPolicy{AllowRejoin: allowRejoin}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP_CNF, 0x03, req, &rsp)
 	return
@@ -1052,6 +1192,9 @@ func (znp *Znp) AppCnfSetAllowRejoinTcPolicy(allowRejoin uint8) (rsp *StatusResp
 //AppCnfBdbStartCommissioning set the commissioning methods to be executed. Initialization of BDB is executed with this call,
 //regardless of its parameters.
 func (znp *Znp) AppCnfBdbStartCommissioning(commissioningMode CommissioningMode) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("AppCnfBdbStartCommissioning", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &AppCnfBdbStartCommissioning{CommissioningMode: commissioningMode}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP_CNF, 0x05, req, &rsp)
 	return
@@ -1059,13 +1202,20 @@ func (znp *Znp) AppCnfBdbStartCommissioning(commissioningMode CommissioningMode)
 
 //AppCnfBdbSetChannel sets  BDB primary or secondary channel masks.
 func (znp *Znp) AppCnfBdbSetChannel(isPrimary uint8, channel *Channels) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("AppCnfBdbSetChannel", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &AppCnfBdbSetChannel{IsPrimary: isPrimary, Channel: channel}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP_CNF, 0x08, req, &rsp)
 	return
 }
 
 //AppCnfBdbAddInstallCode add a preconfigured key (plain key or IC) to Trust Center device.
-func (znp *Znp) AppCnfBdbAddInstallCode(installCodeFormat InstallCodeFormat, ieeeAddr string, installCode []uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) AppCnfBdbAddInstallCode(installCodeFormat InstallCodeFormat, ieeeAddr string,
+	installCode []uint8) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("AppCnfBdbAddInstallCode", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &AppCnfBdbAddInstallCode{InstallCodeFormat: installCodeFormat, IEEEAddr: ieeeAddr, InstallCode: installCode}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP_CNF, 0x04, req, &rsp)
 	return
@@ -1073,6 +1223,9 @@ func (znp *Znp) AppCnfBdbAddInstallCode(installCodeFormat InstallCodeFormat, iee
 
 //AppCnfBdbSetTcRequireKeyExchange sets the policy flag on Trust Center device to mandate or not the TCLK exchange procedure.
 func (znp *Znp) AppCnfBdbSetTcRequireKeyExchange(bdbTrustCenterRequireKeyExchange uint8) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("AppCnfBdbSetTcRequireKeyExchange", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &AppCnfBdbSetTcRequireKeyExchange{BdbTrustCenterRequireKeyExchange: bdbTrustCenterRequireKeyExchange}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP_CNF, 0x09, req, &rsp)
 	return
@@ -1080,20 +1233,30 @@ func (znp *Znp) AppCnfBdbSetTcRequireKeyExchange(bdbTrustCenterRequireKeyExchang
 
 //AppCnfBdbSetJoinUsesInstallCodeKey sets the policy to mandate or not the usage of an Install Code upon joining.
 func (znp *Znp) AppCnfBdbSetJoinUsesInstallCodeKey(bdbJoinUsesInstallCodeKey uint8) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("AppCnfBdbSetJoinUsesInstallCodeKey", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &AppCnfBdbSetJoinUsesInstallCodeKey{BdbJoinUsesInstallCodeKey: bdbJoinUsesInstallCodeKey}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP_CNF, 0x06, req, &rsp)
 	return
 }
 
 //AppCnfBdbSetActiveDefaultCentralizedKey on joining devices, set the default key or an install code to attempt to join the network.
-func (znp *Znp) AppCnfBdbSetActiveDefaultCentralizedKey(useGlobal uint8, installCode [18]uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) AppCnfBdbSetActiveDefaultCentralizedKey(useGlobal uint8,
+	installCode [18]uint8) (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("AppCnfBdbSetActiveDefaultCentralizedKey", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	req := &AppCnfBdbSetActiveDefaultCentralizedKey{UseGlobal: useGlobal, InstallCode: installCode}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP_CNF, 0x07, req, &rsp)
 	return
 }
 
 //AppCnfBdbZedAttemptRecoverNwk instruct the ZED to try to rejoin its previews network. Use only in ZED devices.
-func (znp *Znp) AppCnfBdbZedAttemptRecoverNwk(useGlobal uint8, installCode [18]uint8) (rsp *StatusResponse, err error) {
+func (znp *Znp) AppCnfBdbZedAttemptRecoverNwk() (rsp *StatusResponse, err error) {
+	if err = znp.requireProduct("AppCnfBdbZedAttemptRecoverNwk", "2.7.0", ProductZStack3x0, ProductZStack30x); err != nil {
+		return
+	}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_APP_CNF, 0x0A, nil, &rsp)
 	return
 }
@@ -1101,25 +1264,24 @@ func (znp *Znp) AppCnfBdbZedAttemptRecoverNwk(useGlobal uint8, installCode [18]u
 // =======GP=======
 
 //GpDataReq callback to receive notifications from BDB process.
-func (znp *Znp) GpDataReq(action GpAction, txOptions *TxOptions, applicationId uint8, srcId uint32,
-	gpdIEEEAddress string, endpoint uint8, gpdCommandId uint8, gpdasdu []uint8,
-	gpepHandle uint8, gpTxQueueEntryLifetime uint32) (rsp *StatusResponse, err error) {
-	req := &GpDataReq{Action: action, TxOptions: txOptions, ApplicationID: applicationId,
-		SrcID: srcId, GPDIEEEAddress: gpdIEEEAddress, Endpoint: endpoint,
-		GPDCommandID: gpdCommandId, GPDASDU: gpdasdu, GPEPHandle: gpepHandle,
-		GPTxQueueEntryLifetime: gpTxQueueEntryLifetime}
+func (znp *Znp) GpDataReq(action GpAction, txOptions *TxOptions, applicationID uint8, srcID uint32,
+	gpdieeeAddress string, endpoint uint8, gpdCommandID uint8, gpdasdu []uint8, gpepHandle uint8,
+	gpTxQueueEntryLifetime uint32) (rsp *StatusResponse, err error) {
+	req := &GpDataReq{Action: action, TxOptions: txOptions, ApplicationID: applicationID, SrcID: srcID,
+		GPDIEEEAddress: gpdieeeAddress, Endpoint: endpoint, GPDCommandID: gpdCommandID, GPDASDU: gpdasdu,
+		GPEPHandle: gpepHandle, GPTxQueueEntryLifetime: gpTxQueueEntryLifetime}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_GP, 0x01, req, &rsp)
 	return
 }
 
 //GpSecRsp provides a mechanism for the Green Power EndPoint to provide security data into
 //the dGP stub.
-func (znp *Znp) GpSecRsp(status GpStatus, dGPStubHandle uint8, applicationID uint8, srcID uint32,
-	gpdIEEEAddress string, endpoint uint8, gpdFSecurityLevel uint8, gpdFKeyType uint8,
-	gpdKey [16]uint8, gpdSecurityFrameCounter uint32) (rsp *StatusResponse, err error) {
-	req := &GpSecRsp{Status: status, DGPStubHandle: dGPStubHandle, ApplicationID: applicationID,
-		SrcID: srcID, GPDIEEEAddress: gpdIEEEAddress, Endpoint: endpoint, GPDFSecurityLevel: gpdFSecurityLevel,
-		GPDFKeyType: gpdFKeyType, GPDKey: gpdKey, GPDSecurityFrameCounter: gpdSecurityFrameCounter}
+func (znp *Znp) GpSecRsp(status GpStatus, dgpStubHandle uint8, applicationID uint8, srcID uint32, gpdieeeAddress string,
+	endpoint uint8, gpdfSecurityLevel uint8, gpdfKeyType uint8, gpdKey [16]uint8,
+	gpdSecurityFrameCounter uint32) (rsp *StatusResponse, err error) {
+	req := &GpSecRsp{Status: status, DGPStubHandle: dgpStubHandle, ApplicationID: applicationID, SrcID: srcID,
+		GPDIEEEAddress: gpdieeeAddress, Endpoint: endpoint, GPDFSecurityLevel: gpdfSecurityLevel,
+		GPDFKeyType: gpdfKeyType, GPDKey: gpdKey, GPDSecurityFrameCounter: gpdSecurityFrameCounter}
 	err = znp.ProcessRequest(unp.C_SREQ, unp.S_GP, 0x02, req, &rsp)
 	return
 }
@@ -1141,6 +1303,18 @@ func init() {
 	//DEBUG
 	asyncCommandRegistry[key{unp.S_DBG, 0x00}] = &DebugMsg{}
 
+	//NWK
+	asyncCommandRegistry[key{unp.S_NWK, 0x80}] = &NwkNldeDataCnf{}
+	asyncCommandRegistry[key{unp.S_NWK, 0x81}] = &NwkNldeDataInd{}
+	asyncCommandRegistry[key{unp.S_NWK, 0x82}] = &NwkNlmeNetworkFormationCnf{}
+	asyncCommandRegistry[key{unp.S_NWK, 0x83}] = &NwkNlmeJoinCnf{}
+	asyncCommandRegistry[key{unp.S_NWK, 0x84}] = &NwkNlmeJoinInd{}
+	asyncCommandRegistry[key{unp.S_NWK, 0x85}] = &NwkNlmeLeaveCnf{}
+	asyncCommandRegistry[key{unp.S_NWK, 0x86}] = &NwkNlmeLeaveInd{}
+	asyncCommandRegistry[key{unp.S_NWK, 0x87}] = &NwkNlmePollCnf{}
+	asyncCommandRegistry[key{unp.S_NWK, 0x88}] = &NwkNlmeSyncInd{}
+	asyncCommandRegistry[key{unp.S_NWK, 0x89}] = &NwkNlmeNetworkDiscoveryCnf{}
+
 	//SAPI
 	asyncCommandRegistry[key{unp.S_SAPI, 0x80}] = &SapiZbStartConfirm{}
 	asyncCommandRegistry[key{unp.S_SAPI, 0x81}] = &SapiZbBindConfirm{}
@@ -1179,6 +1353,7 @@ func init() {
 	asyncCommandRegistry[key{unp.S_ZDO, 0xB4}] = &ZdoMgmtLeaveRsp{}
 	asyncCommandRegistry[key{unp.S_ZDO, 0xB5}] = &ZdoMgmtDirectJoinRsp{}
 	asyncCommandRegistry[key{unp.S_ZDO, 0xB6}] = &ZdoMgmtPermitJoinRsp{}
+	asyncCommandRegistry[key{unp.S_ZDO, 0xB8}] = &ZdoMgmtNwkUpdateNotify{}
 	asyncCommandRegistry[key{unp.S_ZDO, 0xC0}] = &ZdoStateChangeInd{}
 	asyncCommandRegistry[key{unp.S_ZDO, 0xC1}] = &ZdoEndDeviceAnnceInd{}
 	asyncCommandRegistry[key{unp.S_ZDO, 0xC2}] = &ZdoMatchDescRpsSent{}
@@ -1192,7 +1367,7 @@ func init() {
 	asyncCommandRegistry[key{unp.S_ZDO, 0xCA}] = &ZdoTcDevInd{}
 	asyncCommandRegistry[key{unp.S_ZDO, 0xCB}] = &ZdoPermitJoinInd{}
 
-	//APP
+	//APP_CNF
 	asyncCommandRegistry[key{unp.S_APP_CNF, 0x80}] = &AppCnfBdbCommissioningNotification{}
 
 	//GP
@@ -294,8 +294,8 @@ const (
 type InstallCodeFormat uint8
 
 const (
-	InstallCodeFormatCodePlusCrc               InstallCodeFormat = 0x00
-	InstallCodeFormatKeyDerivedFromInstallCode InstallCodeFormat = 0x01
+	InstallCodeFormatCodePlusCrc               InstallCodeFormat = 0x01
+	InstallCodeFormatKeyDerivedFromInstallCode InstallCodeFormat = 0x02
 )
 
 type CommissioningMode uint8
@@ -378,3 +378,12 @@ const (
 	LogicalTypeRouter      LogicalType = 1
 	LogicalTypeeEndDevice  LogicalType = 2
 )
+
+//Product is the Z-Stack family of the adapter firmware, see SysVersionResponse
+type Product uint8
+
+const (
+	ProductZStack12  Product = 0
+	ProductZStack3x0 Product = 1
+	ProductZStack30x Product = 2
+)
@@ -342,8 +342,9 @@ const _InstallCodeFormat_name = "InstallCodeFormatCodePlusCrcInstallCodeFormatKe
 var _InstallCodeFormat_index = [...]uint8{0, 28, 70}
 
 func (i InstallCodeFormat) String() string {
+	i -= 1
 	if i >= InstallCodeFormat(len(_InstallCodeFormat_index)-1) {
-		return "InstallCodeFormat(" + strconv.FormatInt(int64(i), 10) + ")"
+		return "InstallCodeFormat(" + strconv.FormatInt(int64(i+1), 10) + ")"
 	}
 	return _InstallCodeFormat_name[_InstallCodeFormat_index[i]:_InstallCodeFormat_index[i+1]]
 }
@@ -436,3 +437,14 @@ func (i LogicalType) String() string {
 	}
 	return _LogicalType_name[_LogicalType_index[i]:_LogicalType_index[i+1]]
 }
+
+const _Product_name = "ProductZStack12ProductZStack3x0ProductZStack30x"
+
+var _Product_index = [...]uint8{0, 15, 31, 47}
+
+func (i Product) String() string {
+	if i >= Product(len(_Product_index)-1) {
+		return "Product(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Product_name[_Product_index[i]:_Product_index[i+1]]
+}
@@ -0,0 +1,165 @@
+package znp
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dyrkin/unp-go"
+)
+
+//ErrUnsupported is returned by commands which the firmware of the adapter doesn't implement. They are rejected
+//without being sent to the adapter.
+var ErrUnsupported = errors.New("command is not supported by the adapter")
+
+//DeviceInfo describes the firmware of the adapter
+type DeviceInfo struct {
+	//Product is the Z-Stack family of the firmware
+	Product Product
+	//Version is the release of the firmware, e.g. 2.7.1
+	Version      string
+	Build        uint32 //Build date, e.g. 20210708. ZNP 1.2 images don't report it
+	TransportRev uint8
+	//Subsystems are the MT subsystems compiled into the firmware
+	Subsystems []unp.Subsystem
+}
+
+//Supports tells whether the subsystem is compiled into the firmware
+func (d *DeviceInfo) Supports(subsystem unp.Subsystem) bool {
+	for _, s := range d.Subsystems {
+		if s == subsystem {
+			return true
+		}
+	}
+	return false
+}
+
+//Probe reads the version and the capabilities of the adapter with SysPing and SysVersion. It is run by Start in the
+//background, call it again when the adapter was flashed with another firmware.
+func (znp *Znp) Probe() (*DeviceInfo, error) {
+	znp.setDeviceInfo(nil)
+	ping, err := znp.SysPing()
+	if err != nil {
+		return nil, err
+	}
+	version, err := znp.SysVersion()
+	if err != nil {
+		return nil, err
+	}
+	info := &DeviceInfo{
+		Product:      version.Product,
+		Version:      fmt.Sprintf("%d.%d.%d", version.MajorRel, version.MinorRel, version.MaintRel),
+		Build:        version.Revision,
+		TransportRev: version.TransportRev,
+		Subsystems:   subsystems(ping.Capabilities, version.Product),
+	}
+	znp.setDeviceInfo(info)
+	znp.log(slog.LevelInfo, "adapter probed", "product", info.Product.String(), "version", info.Version, "build",
+		info.Build)
+	return info, nil
+}
+
+//DeviceInfo returns the description of the adapter read by Probe. It waits for the probe of Start and is nil when
+//the adapter couldn't be probed.
+func (znp *Znp) DeviceInfo() *DeviceInfo {
+	znp.awaitProbe()
+	znp.deviceInfoLock.RLock()
+	defer znp.deviceInfoLock.RUnlock()
+	return znp.deviceInfo
+}
+
+//probeOnStart probes the adapter without blocking Start. The commands gated by the firmware wait until it ends.
+func (znp *Znp) probeOnStart(done chan struct{}) {
+	defer close(done)
+	if _, err := znp.Probe(); err != nil {
+		znp.log(slog.LevelWarn, "failed to probe the adapter, all commands are allowed", "error", err)
+	}
+}
+
+//awaitProbe waits for the probe of Start
+func (znp *Znp) awaitProbe() {
+	if znp.probing != nil {
+		<-znp.probing
+	}
+}
+
+func (znp *Znp) setDeviceInfo(info *DeviceInfo) {
+	znp.deviceInfoLock.Lock()
+	znp.deviceInfo = info
+	znp.deviceInfoLock.Unlock()
+}
+
+//subsystems converts the capabilities reported by SysPing. MT_APP_CONFIG and MT_GP have no capability bit, they
+//are implemented by the Z-Stack 3.x families.
+func subsystems(capabilities *Capabilities, product Product) []unp.Subsystem {
+	var s []unp.Subsystem
+	for _, c := range []struct {
+		bit       uint16
+		subsystem unp.Subsystem
+	}{
+		{capabilities.Sys, unp.S_SYS},
+		{capabilities.Mac, unp.S_MAC},
+		{capabilities.Nwk, unp.S_NWK},
+		{capabilities.Af, unp.S_AF},
+		{capabilities.Zdo, unp.S_ZDO},
+		{capabilities.Sapi, unp.S_SAPI},
+		{capabilities.Util, unp.S_UTIL},
+		{capabilities.Debug, unp.S_DBG},
+		{capabilities.App, unp.S_APP},
+		{capabilities.Zoad, unp.S_OTA},
+	} {
+		if c.bit != 0 {
+			s = append(s, c.subsystem)
+		}
+	}
+	if product == ProductZStack3x0 || product == ProductZStack30x {
+		s = append(s, unp.S_APP_CNF, unp.S_GP)
+	}
+	return s
+}
+
+//requireSubsystem returns ErrUnsupported when the subsystem isn't compiled into the firmware. Requests are let
+//through when the adapter couldn't be probed. SYS is compiled into every firmware, its requests don't wait for the
+//probe, which is made of them.
+func (znp *Znp) requireSubsystem(subsystem unp.Subsystem) error {
+	if subsystem == unp.S_SYS {
+		return nil
+	}
+	if info := znp.DeviceInfo(); info != nil && !info.Supports(subsystem) {
+		return fmt.Errorf("%w: %s isn't compiled into the firmware", ErrUnsupported, subsystem)
+	}
+	return nil
+}
+
+//requireProduct returns ErrUnsupported unless the adapter runs one of the products in a release not older than
+//minVersion, which is ignored when empty. Requests are let through when the adapter couldn't be probed.
+func (znp *Znp) requireProduct(command string, minVersion string, products ...Product) error {
+	info := znp.DeviceInfo()
+	if info == nil {
+		return nil
+	}
+	for _, product := range products {
+		if info.Product != product {
+			continue
+		}
+		if minVersion != "" && compareVersions(info.Version, minVersion) < 0 {
+			return fmt.Errorf("%w: %s needs release %s, the adapter runs %s", ErrUnsupported, command, minVersion,
+				info.Version)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %s isn't implemented by %s", ErrUnsupported, command, info.Product)
+}
+
+//compareVersions compares releases such as 2.7.1 number by number
+func compareVersions(a string, b string) int {
+	var va, vb [3]int
+	fmt.Sscanf(a, "%d.%d.%d", &va[0], &va[1], &va[2])
+	fmt.Sscanf(b, "%d.%d.%d", &vb[0], &vb[1], &vb[2])
+	for i := range va {
+		if va[i] != vb[i] {
+			return va[i] - vb[i]
+		}
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+package znp
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+//connect returns a started and probed Znp connected to the adapter. The frames sent by the probe are skipped.
+func connect(a *znptest.Adapter) *Znp {
+	z := New(a.Unp())
+	z.Start()
+	z.DeviceInfo()
+	for len(a.Received()) > 0 {
+		<-a.Received()
+	}
+	return z
+}
+
+func (s *MySuite) TestProbe(c *C) {
+	z := connect(znptest.New())
+
+	info := z.DeviceInfo()
+	c.Assert(info, NotNil)
+	c.Assert(info.Product, Equals, ProductZStack3x0)
+	c.Assert(info.Version, Equals, "2.7.1")
+	c.Assert(info.Build, Equals, uint32(20210708))
+	c.Assert(info.Subsystems, DeepEquals, []unp.Subsystem{unp.S_SYS, unp.S_AF, unp.S_ZDO, unp.S_SAPI, unp.S_UTIL,
+		unp.S_APP, unp.S_APP_CNF, unp.S_GP})
+}
+
+func (s *MySuite) TestStartDoesNotWaitForTheAdapter(c *C) {
+	hostSide, _ := net.Pipe()
+	z := New(unp.New(1, hostSide))
+	started := make(chan bool)
+	go func() {
+		z.Start()
+		started <- true
+	}()
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		c.Fatal("Start blocked")
+	}
+}
+
+func (s *MySuite) TestAbsentSubsystemIsNotSent(c *C) {
+	adapter := znptest.New()
+	z := connect(adapter)
+
+	_, err := z.NwkInit()
+	c.Assert(errors.Is(err, ErrUnsupported), Equals, true)
+	c.Assert(adapter.Received(), HasLen, 0)
+}
+
+func (s *MySuite) TestUnsupportedProductIsNotSent(c *C) {
+	adapter := znptest.New()
+	adapter.Respond(unp.S_SYS, 0x02, []byte{0x02, 0x00, 0x02, 0x06, 0x03})
+	z := connect(adapter)
+
+	c.Assert(z.DeviceInfo().Build, Equals, uint32(0))
+	_, err := z.AppCnfBdbStartCommissioning(CommissioningModeNetworkFormation)
+	c.Assert(errors.Is(err, ErrUnsupported), Equals, true)
+	c.Assert(err, ErrorMatches, ".*AppCnfBdbStartCommissioning isn't implemented by ProductZStack12")
+	c.Assert(adapter.Received(), HasLen, 0)
+}
+
+func (s *MySuite) TestUnsupportedReleaseIsNotSent(c *C) {
+	adapter := znptest.New()
+	adapter.Respond(unp.S_SYS, 0x02, []byte{0x02, 0x01, 0x02, 0x06, 0x03, 0x14, 0x64, 0x34, 0x01})
+	z := connect(adapter)
+
+	_, err := z.AppCnfBdbStartCommissioning(CommissioningModeNetworkFormation)
+	c.Assert(errors.Is(err, ErrUnsupported), Equals, true)
+	c.Assert(err, ErrorMatches, ".*AppCnfBdbStartCommissioning needs release 2.7.0, the adapter runs 2.6.3")
+	c.Assert(adapter.Received(), HasLen, 0)
+}
+
+func (s *MySuite) TestCommandsWaitForTheProbeOfStart(c *C) {
+	adapter := znptest.New()
+	adapter.Respond(unp.S_SYS, 0x02, []byte{0x02, 0x00, 0x02, 0x06, 0x03})
+	z := New(adapter.Unp())
+	z.Start()
+
+	_, err := z.AppCnfBdbStartCommissioning(CommissioningModeNetworkFormation)
+	c.Assert(errors.Is(err, ErrUnsupported), Equals, true)
+	for len(adapter.Received()) > 0 {
+		c.Assert((<-adapter.Received()).Subsystem, Equals, unp.S_SYS)
+	}
+}
+
+func (s *MySuite) TestSupportedCommandIsSent(c *C) {
+	adapter := znptest.New()
+	z := connect(adapter)
+
+	rsp, err := z.AppCnfSetNwkFrameCounter(0x00010000)
+	c.Assert(err, IsNil)
+	c.Assert(rsp.Status, Equals, StatusSuccess)
+	frame := <-adapter.Received()
+	c.Assert(frame.Subsystem, Equals, unp.S_APP_CNF)
+	c.Assert(frame.Payload, DeepEquals, []byte{0x00, 0x00, 0x01, 0x00})
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"log"
+	"log/slog"
+	"os"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
@@ -26,6 +28,8 @@ func main() {
 
 	u := unp.New(1, port)
 	z := znp.New(u)
+	z.SetLogger(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	z.SetLogPayloads(true)
 	z.Start()
 
 	go func() {
@@ -39,17 +43,6 @@ func main() {
 		}
 	}()
 
-	go func() {
-		for {
-			select {
-			case frame := <-z.OutFramesLog():
-				fmt.Printf("Frame sent: %s\n", spew.Sdump(frame))
-			case frame := <-z.InFramesLog():
-				fmt.Printf("Frame received: %s\n", spew.Sdump(frame))
-			}
-		}
-	}()
-
 	// z.SysResetReq(1)
 
 	var res interface{}
@@ -228,7 +221,7 @@ func main() {
 
 	PrintStruct(res)
 
-	res, err = z.ZdoMgmtNwkUpdateReq("0x25cc", znp.AddrModeAddr16Bit, &znp.Channels{Channel11: 1, Channel12: 1, Channel13: 1, Channel14: 1}, 1)
+	res, err = z.ZdoMgmtNwkUpdateReq("0x25cc", znp.AddrModeAddr16Bit, &znp.Channels{Channel11: 1, Channel12: 1, Channel13: 1, Channel14: 1}, 1, 1, "0x0000")
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -1,11 +1,21 @@
 module github.com/dyrkin/znp-go
 
+go 1.21
+
 require (
 	github.com/davecgh/go-spew v1.1.1
 	github.com/dyrkin/bin v0.0.0-20190204210718-06bd23f8c0ce
 	github.com/dyrkin/unp-go v1.0.2
-	github.com/dyrkin/zcl-go v0.0.0-20190204225456-fc857835ed35
+	github.com/gorilla/websocket v1.5.0
 	go.bug.st/serial.v1 v0.0.0-20180827123349-5f7892a7bb45
-	golang.org/x/sys v0.0.0-20190124100055-b90733256f2e
+	golang.org/x/net v0.17.0
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127
 )
+
+require (
+	github.com/creack/goselect v0.0.0-20180501195510-58854f77ee8d // indirect
+	github.com/dyrkin/composer v0.0.0-20190128134258-5621a9fdcec9 // indirect
+	github.com/kr/pretty v0.1.0 // indirect
+	github.com/kr/text v0.1.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+)
@@ -0,0 +1,69 @@
+package greenpower
+
+//commandNames are the names of the GPD commands, GP specification table 49
+var commandNames = map[uint8]string{
+	0x00: "Identify",
+	0x10: "Recall Scene 0",
+	0x11: "Recall Scene 1",
+	0x12: "Recall Scene 2",
+	0x13: "Recall Scene 3",
+	0x14: "Recall Scene 4",
+	0x15: "Recall Scene 5",
+	0x16: "Recall Scene 6",
+	0x17: "Recall Scene 7",
+	0x18: "Store Scene 0",
+	0x19: "Store Scene 1",
+	0x1A: "Store Scene 2",
+	0x1B: "Store Scene 3",
+	0x1C: "Store Scene 4",
+	0x1D: "Store Scene 5",
+	0x1E: "Store Scene 6",
+	0x1F: "Store Scene 7",
+	0x20: "Off",
+	0x21: "On",
+	0x22: "Toggle",
+	0x23: "Release",
+	0x30: "Move Up",
+	0x31: "Move Down",
+	0x32: "Step Up",
+	0x33: "Step Down",
+	0x34: "Level Control/Stop",
+	0x35: "Move Up (with On/Off)",
+	0x36: "Move Down (with On/Off)",
+	0x37: "Step Up (with On/Off)",
+	0x38: "Step Down (with On/Off)",
+	0x40: "Move Hue Stop",
+	0x41: "Move Hue Up",
+	0x42: "Move Hue Down",
+	0x43: "Step Hue Up",
+	0x44: "Step Hue Down",
+	0x45: "Move Saturation Stop",
+	0x46: "Move Saturation Up",
+	0x47: "Move Saturation Down",
+	0x48: "Step Saturation Up",
+	0x49: "Step Saturation Down",
+	0x4A: "Move Color",
+	0x4B: "Step Color",
+	0x60: "Press 1 of 1",
+	0x61: "Release 1 of 1",
+	0x62: "Press 1 of 2",
+	0x63: "Release 1 of 2",
+	0x64: "Press 2 of 2",
+	0x65: "Release 2 of 2",
+	0x66: "Short press 1 of 1",
+	0x67: "Short press 1 of 2",
+	0x68: "Short press 2 of 2",
+	0x69: "8 bit Vector Press",
+	0x6A: "8 bit Vector Release",
+	0xA0: "Attribute Reporting",
+	0xA1: "Manufacturer Specific Attribute Reporting",
+	0xA2: "Multi-cluster Reporting",
+	0xA3: "Manufacturer Specific Multi-cluster Reporting",
+	0xA4: "Request Attributes",
+	0xA5: "Read Attributes Response",
+	0xAF: "Any GPD sensor command",
+	0xE0: "Commissioning",
+	0xE1: "Decommissioning",
+	0xE2: "Success",
+	0xE3: "Channel Request",
+}
@@ -0,0 +1,187 @@
+package greenpower
+
+import (
+	"crypto/aes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+//Application IDs of a GPD
+const (
+	ApplicationSrcID uint8 = 0x00
+	ApplicationIEEE  uint8 = 0x02
+)
+
+//Commands of the GPD frames handled by the sink
+const (
+	CommandCommissioning   uint8 = 0xE0
+	CommandDecommissioning uint8 = 0xE1
+)
+
+//Frame is a GPD frame as carried by GpDataInd.GPMPDU, starting with the NWK frame control
+type Frame struct {
+	FrameType         uint8 //0 for data, 1 for maintenance frames
+	AutoCommissioning bool
+	ApplicationID     uint8
+	SecurityLevel     uint8
+	KeyType           uint8 //0 for a shared and 1 for an individual key
+	RxAfterTx         bool
+	SrcID             uint32
+	Endpoint          uint8
+	FrameCounter      uint32
+	CommandID         uint8
+	Payload           []byte
+}
+
+var errShortFrame = errors.New("greenpower: frame is too short")
+
+//ParseFrame decodes the headers of the GPD frame. The MIC is removed from the payload.
+func ParseFrame(b []byte) (*Frame, error) {
+	r := &reader{b: b}
+	f := &Frame{}
+	control := r.byte()
+	f.FrameType = control & 0x03
+	f.AutoCommissioning = control&0x40 != 0
+	extended := control&0x80 != 0
+	if extended {
+		ext := r.byte()
+		f.ApplicationID = ext & 0x07
+		f.SecurityLevel = ext >> 3 & 0x03
+		f.KeyType = ext >> 5 & 0x01
+		f.RxAfterTx = ext&0x40 != 0
+	}
+	if f.ApplicationID == ApplicationSrcID && (f.FrameType == 0 || f.FrameType == 1 && extended) {
+		f.SrcID = r.uint32()
+	}
+	if f.ApplicationID == ApplicationIEEE {
+		f.Endpoint = r.byte()
+	}
+	if f.SecurityLevel >= 2 {
+		f.FrameCounter = r.uint32()
+	}
+	f.CommandID = r.byte()
+	if r.err != nil {
+		return nil, r.err
+	}
+	payload := r.rest()
+	switch mic := micLength(f.SecurityLevel); {
+	case len(payload) < mic:
+		return nil, errShortFrame
+	default:
+		f.Payload = payload[:len(payload)-mic]
+	}
+	return f, nil
+}
+
+func micLength(securityLevel uint8) int {
+	switch securityLevel {
+	case 0:
+		return 0
+	case 1:
+		return 2
+	}
+	return 4
+}
+
+//Commissioning is the payload of the commissioning command
+type Commissioning struct {
+	DeviceID            uint8
+	MACSeqNumCapability bool
+	RxOnCapability      bool
+	PANIDRequest        bool
+	KeyRequest          bool
+	FixedLocation       bool
+	//SecurityLevel is the minimal security level the GPD supports
+	SecurityLevel uint8
+	KeyType       uint8
+	Key           *[16]uint8 //nil when the GPD doesn't send its key
+	KeyEncrypted  bool
+	KeyMIC        uint32
+	//FrameCounter is the outgoing frame counter of the GPD, when HasFrameCounter is set
+	FrameCounter    uint32
+	HasFrameCounter bool
+}
+
+//ParseCommissioning decodes the payload of the commissioning command
+func ParseCommissioning(b []byte) (*Commissioning, error) {
+	r := &reader{b: b}
+	c := &Commissioning{DeviceID: r.byte()}
+	options := r.byte()
+	c.MACSeqNumCapability = options&0x01 != 0
+	c.RxOnCapability = options&0x02 != 0
+	c.PANIDRequest = options&0x10 != 0
+	c.KeyRequest = options&0x20 != 0
+	c.FixedLocation = options&0x40 != 0
+	if options&0x80 != 0 {
+		ext := r.byte()
+		c.SecurityLevel = ext & 0x03
+		c.KeyType = ext >> 2 & 0x07
+		if ext&0x20 != 0 {
+			var key [16]uint8
+			copy(key[:], r.bytes(16))
+			c.Key = &key
+			if ext&0x40 != 0 {
+				c.KeyEncrypted = true
+				c.KeyMIC = r.uint32()
+			}
+		}
+		if ext&0x80 != 0 {
+			c.FrameCounter, c.HasFrameCounter = r.uint32(), true
+		}
+	}
+	return c, r.err
+}
+
+//DecryptKey decrypts the key sent by the GPD with the AES-CCM* keystream of the link key. The MIC isn't checked.
+func DecryptKey(linkKey [16]uint8, srcID uint32, encrypted [16]uint8) ([16]uint8, error) {
+	var nonce [13]byte
+	binary.LittleEndian.PutUint32(nonce[0:], srcID)
+	binary.LittleEndian.PutUint32(nonce[4:], srcID)
+	binary.LittleEndian.PutUint32(nonce[8:], srcID)
+	nonce[12] = 0x05
+	//A1 = flags with a 2 bytes counter, the nonce and the counter 1
+	var block [16]byte
+	block[0] = 0x01
+	copy(block[1:], nonce[:])
+	block[15] = 0x01
+	cipher, err := aes.NewCipher(linkKey[:])
+	if err != nil {
+		return encrypted, fmt.Errorf("greenpower: %w", err)
+	}
+	cipher.Encrypt(block[:], block[:])
+	var key [16]uint8
+	for i := range key {
+		key[i] = encrypted[i] ^ block[i]
+	}
+	return key, nil
+}
+
+type reader struct {
+	b   []byte
+	err error
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil || len(r.b) < n {
+		r.err = errShortFrame
+		return make([]byte, n)
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *reader) byte() uint8 {
+	return r.bytes(1)[0]
+}
+
+func (r *reader) uint32() uint32 {
+	return binary.LittleEndian.Uint32(r.bytes(4))
+}
+
+func (r *reader) rest() []byte {
+	b := r.b
+	r.b = nil
+	return b
+}
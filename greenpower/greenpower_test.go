@@ -0,0 +1,125 @@
+package greenpower
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+//fakeAdapter forwards the security responses of the sink
+type fakeAdapter struct {
+	*znptest.Adapter
+	responses chan *znp.GpSecRsp
+}
+
+func newFakeAdapter() *fakeAdapter {
+	a := &fakeAdapter{Adapter: znptest.New(), responses: make(chan *znp.GpSecRsp, 1)}
+	a.Handle(unp.S_GP, 0x02, func(r *znptest.Request) []byte {
+		rsp := &znp.GpSecRsp{}
+		r.Decode(rsp)
+		a.responses <- rsp
+		return nil
+	})
+	return a
+}
+
+func (a *fakeAdapter) send(command uint8, async interface{}) {
+	a.Send(unp.S_GP, command, async)
+}
+
+func (a *fakeAdapter) indicate(status znp.GpDataIndStatus, gpmpdu []byte) {
+	a.send(0x04, &znp.GpDataInd{Status: status, SrcAddress: "0x0000000000000000", DstAddress: "0x0000000000000000",
+		GPMPDU: gpmpdu})
+}
+
+func next(c *C, s *Sink) interface{} {
+	select {
+	case event := <-s.Events():
+		return event
+	case <-time.After(time.Second):
+		c.Fatal("no event")
+		return nil
+	}
+}
+
+func (s *MySuite) TestParseFrame(c *C) {
+	frame, err := ParseFrame([]byte{0x8C, 0x30, 0x78, 0x56, 0x34, 0x12, 0x05, 0x00, 0x00, 0x00, 0x22,
+		0xAA, 0xBB, 0xCC, 0xDD})
+	c.Assert(err, IsNil)
+	c.Assert(frame, DeepEquals, &Frame{SecurityLevel: 2, KeyType: 1, SrcID: 0x12345678, FrameCounter: 5,
+		CommandID: 0x22, Payload: []byte{}})
+	_, err = ParseFrame([]byte{0x8C, 0x30, 0x78})
+	c.Assert(err, NotNil)
+}
+
+func (s *MySuite) TestSink(c *C) {
+	a := newFakeAdapter()
+	z := znp.New(a.Unp())
+	z.Start()
+	sink := NewSink(z)
+	defer sink.Stop()
+	sink.SetCommissioning(true)
+
+	const srcID = 0x12345678
+	key := [16]uint8{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	encrypted, _ := DecryptKey(DefaultLinkKey, srcID, key)
+	commissioning := []byte{0x8C, 0x00, 0x78, 0x56, 0x34, 0x12, CommandCommissioning, 0x02, 0x81, 0xF2}
+	commissioning = append(commissioning, encrypted[:]...)
+	commissioning = append(commissioning, 0x00, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00)
+	a.indicate(znp.GpDataIndStatusNoSecurity, commissioning)
+
+	id := ID{SrcID: srcID}
+	device := Device{ID: id, DeviceID: 0x02, SecurityLevel: 2, KeyType: 4, Key: key, FrameCounter: 4}
+	c.Assert(next(c, sink), DeepEquals, &Commissioned{Device: device})
+	c.Assert(sink.Devices(), DeepEquals, []Device{device})
+
+	//the key is returned for new frames, replayed ones are dropped
+	req := &znp.GpSecReq{SrcID: srcID, GPDIEEEAddress: "0x0000000000000000", GPDFSecurityLevel: 2,
+		GPDSecurityFrameCounter: 5, DGPStubHandle: 7}
+	a.send(0x03, req)
+	rsp := <-a.responses
+	c.Assert(rsp.Status, Equals, znp.GpStatusMatch)
+	c.Assert(rsp.GPDKey, Equals, key)
+	c.Assert(rsp.DGPStubHandle, Equals, uint8(7))
+	req.GPDSecurityFrameCounter = 4
+	a.send(0x03, req)
+	c.Assert((<-a.responses).Status, Equals, znp.GpStatusDropFrame)
+
+	toggle := func(counter uint8) []byte {
+		return []byte{0x8C, 0x30, 0x78, 0x56, 0x34, 0x12, counter, 0x00, 0x00, 0x00, 0x22, 0xAA, 0xBB, 0xCC, 0xDD}
+	}
+	a.indicate(znp.GpDataIndStatusSecuritySuccess, toggle(5))
+	a.indicate(znp.GpDataIndStatusSecuritySuccess, toggle(5))
+	a.indicate(znp.GpDataIndStatusAuthFailure, toggle(6))
+	a.indicate(znp.GpDataIndStatusSecuritySuccess, []byte{0x8C, 0x30, 0x78, 0x56, 0x34, 0x12, 0x07, 0x00, 0x00, 0x00,
+		CommandDecommissioning, 0xAA, 0xBB, 0xCC, 0xDD})
+	command := next(c, sink).(*Command)
+	c.Assert(command.Name(), Equals, "Toggle")
+	c.Assert(command.FrameCounter, Equals, uint32(5))
+	c.Assert(next(c, sink), DeepEquals, &Decommissioned{ID: id})
+	c.Assert(sink.Devices(), HasLen, 0)
+}
+
+func (s *MySuite) TestRejectedSecurityResponse(c *C) {
+	a := newFakeAdapter()
+	a.Respond(unp.S_GP, 0x02, []byte{0x01})
+	z := znp.New(a.Unp())
+	z.Start()
+	sink := NewSink(z)
+	defer sink.Stop()
+
+	a.send(0x03, &znp.GpSecReq{SrcID: 0x12345678, GPDIEEEAddress: "0x0000000000000000"})
+	failed := next(c, sink).(*SecurityFailed)
+	c.Assert(failed.ID, Equals, ID{SrcID: 0x12345678})
+	c.Assert(failed.Err, ErrorMatches, "greenpower: security response failed: .*")
+}
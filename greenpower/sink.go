@@ -0,0 +1,271 @@
+//Package greenpower is a Green Power sink. It commissions GPDs such as battery-less switches, answers the security
+//requests of the adapter with their keys and reports their commands.
+//
+//	s := greenpower.NewSink(z)
+//	s.SetCommissioning(true)
+//	for event := range s.Events() {
+//		switch e := event.(type) {
+//		case *greenpower.Commissioned:
+//			save(e.Device)
+//		case *greenpower.Command:
+//			fmt.Println(e.ID, e.Name())
+//		}
+//	}
+//
+//Only unidirectional GPDs are supported, the sink doesn't reply to GPDs requesting a key or a channel. Encrypted
+//keys are only accepted from GPDs with application ID 0.
+package greenpower
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dyrkin/znp-go"
+)
+
+//DefaultLinkKey is the "ZigBeeAlliance09" key GPDs encrypt their keys with by default
+var DefaultLinkKey = [16]uint8{0x5A, 0x69, 0x67, 0x42, 0x65, 0x65, 0x41, 0x6C, 0x6C, 0x69, 0x61, 0x6E, 0x63, 0x65,
+	0x30, 0x39}
+
+//ID identifies a GPD by its SrcID or, for application ID 2, by its IEEE address and endpoint
+type ID struct {
+	ApplicationID uint8
+	SrcID         uint32
+	IEEEAddr      string
+	Endpoint      uint8
+}
+
+//Device is a commissioned GPD. Persist it and restore it with Add after a restart.
+type Device struct {
+	ID
+	DeviceID      uint8
+	SecurityLevel uint8
+	KeyType       uint8
+	Key           [16]uint8
+	//FrameCounter is the last security frame counter received, frames with a lower or equal one are dropped
+	FrameCounter uint32
+}
+
+//Commissioned is emitted when a GPD was commissioned or commissioned again
+type Commissioned struct {
+	Device Device
+}
+
+//Decommissioned is emitted when a GPD left. It is removed from the sink.
+type Decommissioned struct {
+	ID ID
+}
+
+//SecurityFailed is emitted when the answer to a security request of the adapter wasn't accepted. The frame of the
+//GPD is lost then.
+type SecurityFailed struct {
+	ID  ID
+	Err error
+}
+
+//Command is a command sent by a commissioned GPD
+type Command struct {
+	ID           ID
+	CommandID    uint8
+	Payload      []byte
+	FrameCounter uint32
+	LinkQuality  uint8
+}
+
+//Name returns the name of the command from the GPD command table, or an empty string
+func (c *Command) Name() string {
+	return commandNames[c.CommandID]
+}
+
+//Sink keeps the commissioned GPDs and reacts to GpSecReq and GpDataInd
+type Sink struct {
+	z            *znp.Znp
+	subscription *znp.Subscription
+	events       chan interface{}
+
+	mu            sync.Mutex
+	devices       map[ID]*Device
+	linkKey       [16]uint8
+	commissioning bool
+}
+
+//NewSink returns a sink watching the Green Power indications of the adapter. Call Stop when it is no longer needed.
+func NewSink(z *znp.Znp) *Sink {
+	s := &Sink{
+		z:            z,
+		subscription: z.Subscribe(),
+		events:       make(chan interface{}, 100),
+		devices:      map[ID]*Device{},
+		linkKey:      DefaultLinkKey,
+	}
+	go s.watch()
+	return s
+}
+
+//Events returns the channel *Commissioned, *Decommissioned, *Command and *SecurityFailed are delivered to. Events
+//are dropped when nobody reads the channel.
+func (s *Sink) Events() chan interface{} {
+	return s.events
+}
+
+//SetLinkKey sets the key GPDs encrypt their keys with during commissioning. Default is DefaultLinkKey.
+func (s *Sink) SetLinkKey(key [16]uint8) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.linkKey = key
+}
+
+//SetCommissioning allows unknown GPDs to commission
+func (s *Sink) SetCommissioning(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commissioning = enabled
+}
+
+//Add adds or replaces a GPD
+func (s *Sink) Add(device Device) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.devices[device.ID] = &device
+}
+
+//Remove removes a GPD
+func (s *Sink) Remove(id ID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.devices, id)
+}
+
+//Devices returns copies of the commissioned GPDs with their current frame counters
+func (s *Sink) Devices() []Device {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	devices := make([]Device, 0, len(s.devices))
+	for _, device := range s.devices {
+		devices = append(devices, *device)
+	}
+	return devices
+}
+
+//Stop stops watching the indications and closes the events channel
+func (s *Sink) Stop() {
+	s.subscription.Unsubscribe()
+}
+
+func (s *Sink) watch() {
+	defer close(s.events)
+	for async := range s.subscription.Events() {
+		switch async := async.(type) {
+		case *znp.GpSecReq:
+			if id, err := s.secure(async); err != nil {
+				s.emit(&SecurityFailed{ID: id, Err: err})
+			}
+		case *znp.GpDataInd:
+			s.indicated(async)
+		}
+	}
+}
+
+//secure answers the security request with the key of the GPD, or drops the frame. Frames of unknown GPDs are
+//passed unprocessed while commissioning, so that the commissioning frame is indicated.
+func (s *Sink) secure(req *znp.GpSecReq) (ID, error) {
+	id := ID{ApplicationID: req.ApplicationID}
+	if req.ApplicationID == ApplicationIEEE {
+		id.IEEEAddr, id.Endpoint = req.GPDIEEEAddress, req.Endpoint
+	} else {
+		id.SrcID = req.SrcID
+	}
+	s.mu.Lock()
+	status, device := znp.GpStatusDropFrame, Device{}
+	if d, ok := s.devices[id]; ok {
+		if req.GPDSecurityFrameCounter > d.FrameCounter {
+			status, device = znp.GpStatusMatch, *d
+		}
+	} else if s.commissioning {
+		status = znp.GpStatusPassUnprocessed
+	}
+	s.mu.Unlock()
+	if device.SecurityLevel == 0 {
+		device.SecurityLevel, device.KeyType = req.GPDFSecurityLevel, req.GPDFKeyType
+	}
+	rsp, err := s.z.GpSecRsp(status, req.DGPStubHandle, req.ApplicationID, req.SrcID, req.GPDIEEEAddress,
+		req.Endpoint, device.SecurityLevel, device.KeyType, device.Key, req.GPDSecurityFrameCounter)
+	if err != nil {
+		return id, err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return id, fmt.Errorf("greenpower: security response failed: %s", rsp.Status)
+	}
+	return id, nil
+}
+
+func (s *Sink) indicated(ind *znp.GpDataInd) {
+	frame, err := ParseFrame(ind.GPMPDU)
+	if err != nil {
+		return
+	}
+	id := ID{ApplicationID: frame.ApplicationID, SrcID: frame.SrcID}
+	if frame.ApplicationID == ApplicationIEEE {
+		id.IEEEAddr, id.Endpoint = ind.SrcAddress, frame.Endpoint
+	}
+	secured := ind.Status == znp.GpDataIndStatusSecuritySuccess || ind.Status == znp.GpDataIndStatusNoSecurity
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	device, known := s.devices[id]
+	if frame.CommandID == CommandCommissioning {
+		if s.commissioning && (!known || secured) {
+			s.commission(id, frame)
+		}
+		return
+	}
+	if !known || !secured || frame.SecurityLevel < device.SecurityLevel {
+		return
+	}
+	if frame.SecurityLevel >= 2 {
+		if frame.FrameCounter <= device.FrameCounter {
+			return
+		}
+		device.FrameCounter = frame.FrameCounter
+	}
+	if frame.CommandID == CommandDecommissioning {
+		delete(s.devices, id)
+		s.emit(&Decommissioned{ID: id})
+		return
+	}
+	s.emit(&Command{ID: id, CommandID: frame.CommandID, Payload: frame.Payload, FrameCounter: frame.FrameCounter,
+		LinkQuality: ind.LinkQuality})
+}
+
+func (s *Sink) commission(id ID, frame *Frame) {
+	c, err := ParseCommissioning(frame.Payload)
+	if err != nil {
+		return
+	}
+	device := &Device{ID: id, DeviceID: c.DeviceID, SecurityLevel: c.SecurityLevel, KeyType: c.KeyType,
+		FrameCounter: frame.FrameCounter}
+	if c.Key != nil {
+		device.Key = *c.Key
+		if c.KeyEncrypted {
+			if id.ApplicationID != ApplicationSrcID {
+				//the nonce of application ID 2 isn't supported
+				return
+			}
+			if device.Key, err = DecryptKey(s.linkKey, id.SrcID, *c.Key); err != nil {
+				return
+			}
+		}
+	}
+	if c.HasFrameCounter {
+		device.FrameCounter = c.FrameCounter
+	}
+	s.devices[id] = device
+	s.emit(&Commissioned{Device: *device})
+}
+
+func (s *Sink) emit(event interface{}) {
+	select {
+	case s.events <- event:
+	default:
+	}
+}
@@ -0,0 +1,44 @@
+package installcode
+
+import (
+	"crypto/aes"
+	"encoding/binary"
+)
+
+//CRC16 is the CRC-16/X-25 of install codes: polynomial 0x1021 reflected, initial value and final XOR 0xFFFF
+func CRC16(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0x8408
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return ^crc
+}
+
+//MMOHash is the Matyas-Meyer-Oseas hash based on AES-128 defined by the ZigBee specification
+func MMOHash(data []byte) [16]uint8 {
+	const blockSize = aes.BlockSize
+	//the message is padded with a 1 bit and zeros, and ends with its length in bits
+	padded := append(append([]byte{}, data...), 0x80)
+	for len(padded)%blockSize != blockSize-2 {
+		padded = append(padded, 0x00)
+	}
+	padded = binary.BigEndian.AppendUint16(padded, uint16(len(data)*8))
+
+	var hash [16]uint8
+	for i := 0; i < len(padded); i += blockSize {
+		block := padded[i : i+blockSize]
+		cipher, _ := aes.NewCipher(hash[:])
+		cipher.Encrypt(hash[:], block)
+		for j := range hash {
+			hash[j] ^= block[j]
+		}
+	}
+	return hash
+}
@@ -0,0 +1,143 @@
+//Package installcode parses ZigBee install codes and registers them with the trust center of the adapter.
+//
+//	ic, err := installcode.Parse("Z:000B57FFFE1A2B3C$I:83FED3407A939723A5C639B26916D505C3B5")
+//	err = installcode.Register(z, "", ic)
+//	err = installcode.Require(z, true)
+//
+//The link key is derived on the host, so codes of every length are supported, while the adapter only accepts
+//16-byte codes.
+package installcode
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dyrkin/znp-go"
+)
+
+//ErrInvalidCRC is returned when the CRC doesn't match the install code
+var ErrInvalidCRC = errors.New("installcode: invalid CRC")
+
+//InstallCode is an install code, the CRC isn't included
+type InstallCode struct {
+	Code []byte
+	//IEEEAddr is the address of the device, when the printed code contains it
+	IEEEAddr string
+}
+
+//validLengths are the lengths of install codes defined by the Base Device Behavior specification
+var validLengths = []int{6, 8, 12, 16}
+
+//FromBytes checks the CRC of the install code followed by its CRC
+func FromBytes(b []byte) (*InstallCode, error) {
+	n := len(b) - 2
+	valid := false
+	for _, length := range validLengths {
+		valid = valid || n == length
+	}
+	if !valid {
+		return nil, fmt.Errorf("installcode: invalid length %d, expected 6, 8, 12 or 16 bytes and a CRC", len(b))
+	}
+	if CRC16(b[:n]) != uint16(b[n])|uint16(b[n+1])<<8 {
+		return nil, ErrInvalidCRC
+	}
+	return &InstallCode{Code: append([]byte{}, b[:n]...)}, nil
+}
+
+//Parse reads an install code in one of the formats:
+//
+//	83FED3407A939723A5C639B26916D505C3B5           hex, optionally separated with spaces, dashes or colons
+//	Z:000B57FFFE1A2B3C$I:83FED3407A939723A5C639B26916D505C3B5   ZigBee QR code, other fields are ignored
+//	HUE:Z:83FED3407A939723A5C639B26916D505C3B5 M:000B57FFFE1A2B3C   Philips Hue QR code
+func Parse(s string) (*InstallCode, error) {
+	s = strings.TrimSpace(s)
+	var code, address string
+	switch {
+	case strings.HasPrefix(s, "HUE:"):
+		for _, field := range strings.Fields(strings.TrimPrefix(s, "HUE:")) {
+			code, address = value(field, "Z:", code), value(field, "M:", address)
+		}
+	case strings.HasPrefix(s, "Z:"):
+		for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == '$' || r == '%' }) {
+			code, address = value(field, "I:", code), value(field, "Z:", address)
+		}
+	default:
+		code = s
+	}
+	b, err := hex.DecodeString(strings.NewReplacer(" ", "", "-", "", ":", "").Replace(code))
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("installcode: can't parse %q", s)
+	}
+	ic, err := FromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if address != "" {
+		a, err := hex.DecodeString(address)
+		if err != nil || len(a) != 8 {
+			return nil, fmt.Errorf("installcode: invalid IEEE address %q", address)
+		}
+		ic.IEEEAddr = "0x" + hex.EncodeToString(a)
+	}
+	return ic, nil
+}
+
+func value(field string, prefix string, current string) string {
+	if strings.HasPrefix(field, prefix) {
+		return strings.TrimPrefix(field, prefix)
+	}
+	return current
+}
+
+//Bytes returns the install code followed by its CRC
+func (ic *InstallCode) Bytes() []byte {
+	crc := CRC16(ic.Code)
+	return append(append([]byte{}, ic.Code...), byte(crc), byte(crc>>8))
+}
+
+//Key returns the link key derived from the install code
+func (ic *InstallCode) Key() [16]uint8 {
+	return MMOHash(ic.Bytes())
+}
+
+func (ic *InstallCode) String() string {
+	return strings.ToUpper(hex.EncodeToString(ic.Bytes()))
+}
+
+//Register adds the link key derived from the install code to the trust center. The IEEE address of the install
+//code is used when ieeeAddr is empty.
+func Register(z *znp.Znp, ieeeAddr string, ic *InstallCode) error {
+	if ieeeAddr == "" {
+		ieeeAddr = ic.IEEEAddr
+	}
+	if ieeeAddr == "" {
+		return errors.New("installcode: IEEE address of the device is unknown")
+	}
+	key := ic.Key()
+	rsp, err := z.AppCnfBdbAddInstallCode(znp.InstallCodeFormatKeyDerivedFromInstallCode, ieeeAddr, key[:])
+	if err != nil {
+		return err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return fmt.Errorf("installcode: adding the key of %s failed: %s", ieeeAddr, rsp.Status)
+	}
+	return nil
+}
+
+//Require makes the trust center accept only devices joining with a key registered with Register
+func Require(z *znp.Znp, required bool) error {
+	var flag uint8
+	if required {
+		flag = 1
+	}
+	rsp, err := z.AppCnfBdbSetJoinUsesInstallCodeKey(flag)
+	if err != nil {
+		return err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return fmt.Errorf("installcode: setting the join policy failed: %s", rsp.Status)
+	}
+	return nil
+}
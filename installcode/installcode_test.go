@@ -0,0 +1,103 @@
+package installcode
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+//The install code and key are the example of the ZigBee specification
+const code = "83FED3407A939723A5C639B26916D505C3B5"
+
+func (s *MySuite) TestParse(c *C) {
+	for _, printed := range []string{
+		code,
+		"83FE D340 7A93 9723 A5C6 39B2 6916 D505 C3B5",
+		"83-FE-D3-40-7A-93-97-23-A5-C6-39-B2-69-16-D5-05-C3-B5",
+		"Z:000B57FFFE1A2B3C$I:83FED3407A939723A5C639B26916D505C3B5%G$M:123",
+		"HUE:Z:83FED3407A939723A5C639B26916D505C3B5 M:000B57FFFE1A2B3C D:A1B2 A:100B",
+	} {
+		ic, err := Parse(printed)
+		c.Assert(err, IsNil, Commentf(printed))
+		c.Assert(ic.String(), Equals, code)
+	}
+	ic, _ := Parse("Z:000B57FFFE1A2B3C$I:" + code)
+	c.Assert(ic.IEEEAddr, Equals, "0x000b57fffe1a2b3c")
+}
+
+func (s *MySuite) TestInvalidCodes(c *C) {
+	_, err := Parse("83FED3407A939723A5C639B26916D505C3B6")
+	c.Assert(err, Equals, ErrInvalidCRC)
+	_, err = Parse("83FED3407A93")
+	c.Assert(err, ErrorMatches, "installcode: invalid length 6.*")
+	_, err = Parse("not a code")
+	c.Assert(err, ErrorMatches, "installcode: can't parse .*")
+}
+
+func (s *MySuite) TestKey(c *C) {
+	ic, _ := Parse(code)
+	key := ic.Key()
+	c.Assert(hex.EncodeToString(key[:]), Equals, "66b6900981e1ee3ca4206b6b861c02bb")
+
+	short := &InstallCode{Code: []byte{1, 2, 3, 4, 5, 6}}
+	parsed, err := FromBytes(short.Bytes())
+	c.Assert(err, IsNil)
+	c.Assert(parsed.Key(), Equals, short.Key())
+}
+
+//frame returns the next frame sent to the adapter
+func frame(c *C, a *znptest.Adapter) *unp.Frame {
+	for {
+		select {
+		case frame := <-a.Received():
+			if frame.Subsystem != unp.S_SYS {
+				return frame
+			}
+		case <-time.After(time.Second):
+			c.Fatal("no frame")
+		}
+	}
+}
+
+func (s *MySuite) TestRegister(c *C) {
+	a := znptest.New()
+	z := znp.New(a.Unp())
+	z.Start()
+
+	ic, _ := Parse("Z:000B57FFFE1A2B3C$I:" + code)
+	c.Assert(Register(z, "", ic), IsNil)
+	sent := frame(c, a)
+	c.Assert(sent.Subsystem, Equals, unp.S_APP_CNF)
+	c.Assert(sent.Command, Equals, byte(0x04))
+	//the derived key format, the IEEE address and the key
+	c.Assert(hex.EncodeToString(sent.Payload), Equals, "02"+"3c2b1afeff570b00"+"66b6900981e1ee3ca4206b6b861c02bb")
+
+	c.Assert(Register(z, "", &InstallCode{Code: ic.Code}), ErrorMatches, ".*IEEE address of the device is unknown")
+	a.Respond(unp.S_APP_CNF, 0x04, []byte{0x01})
+	c.Assert(Register(z, "0x00124b0001020304", ic), ErrorMatches, "installcode: adding the key of .* failed: .*")
+}
+
+func (s *MySuite) TestRequire(c *C) {
+	a := znptest.New()
+	z := znp.New(a.Unp())
+	z.Start()
+
+	c.Assert(Require(z, true), IsNil)
+	sent := frame(c, a)
+	c.Assert(sent.Subsystem, Equals, unp.S_APP_CNF)
+	c.Assert(sent.Command, Equals, byte(0x06))
+	c.Assert(sent.Payload, DeepEquals, []byte{0x01})
+	c.Assert(Require(z, false), IsNil)
+	c.Assert(frame(c, a).Payload, DeepEquals, []byte{0x00})
+}
@@ -0,0 +1,506 @@
+//Command mtgen generates command.go, model.go and model_test.go of the znp package from the MT spec declared in
+//the spec package. It is run by `go generate` in the root of the module.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dyrkin/znp-go/internal/mtgen/spec"
+)
+
+const header = "// Code generated by mtgen from internal/mtgen/spec. DO NOT EDIT.\n\n"
+
+//lineWidth is the width signatures and request literals are wrapped at. Tabs count as 4 columns
+const lineWidth = 120
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the znp package")
+	flag.Parse()
+
+	files, err := generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(*dir, name), src, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+//generate returns the generated files by name
+func generate() (map[string][]byte, error) {
+	models, err := validate(spec.Common, spec.Subsystems)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{
+		"model.go":      generateModels(spec.Common, spec.Subsystems),
+		"command.go":    generateCommands(spec.Subsystems, models),
+		"model_test.go": generateTests(spec.Common, spec.Subsystems, models),
+	}
+	for name, src := range files {
+		formatted, err := format.Source(src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+		files[name] = compactComments(formatted)
+	}
+	return files, nil
+}
+
+//validate checks the references between commands and models and returns the models by name
+func validate(common []*spec.Model, subsystems []*spec.Subsystem) (map[string]*spec.Model, error) {
+	models := map[string]*spec.Model{}
+	add := func(list []*spec.Model) error {
+		for _, m := range list {
+			if _, ok := models[m.Name]; ok {
+				return fmt.Errorf("model %s is declared twice", m.Name)
+			}
+			models[m.Name] = m
+		}
+		return nil
+	}
+	if err := add(common); err != nil {
+		return nil, err
+	}
+	for _, s := range subsystems {
+		if err := add(s.Models); err != nil {
+			return nil, err
+		}
+	}
+	for _, m := range models {
+		if m.Implements != "" && (models[m.Implements] == nil || !models[m.Implements].Marker) {
+			return nil, fmt.Errorf("model %s implements unknown marker %s", m.Name, m.Implements)
+		}
+		for _, e := range m.Examples {
+			if !strings.HasPrefix(e.Value, "&"+m.Name+"{") {
+				return nil, fmt.Errorf("example of model %s has wrong type: %s", m.Name, e.Value)
+			}
+		}
+	}
+	methods := map[string]bool{}
+	for _, s := range subsystems {
+		async := map[byte]bool{}
+		for _, a := range s.Async {
+			if models[a.Model] == nil {
+				return nil, fmt.Errorf("async command %s 0x%02X uses unknown model %s", s.Name, a.ID, a.Model)
+			}
+			if async[a.ID] {
+				return nil, fmt.Errorf("async command %s 0x%02X is declared twice", s.Name, a.ID)
+			}
+			async[a.ID] = true
+		}
+		for _, c := range s.Commands {
+			if methods[c.Name] {
+				return nil, fmt.Errorf("command %s is declared twice", c.Name)
+			}
+			methods[c.Name] = true
+			if c.Request != "" && models[c.Request] == nil {
+				return nil, fmt.Errorf("command %s uses unknown request %s", c.Name, c.Request)
+			}
+			switch {
+			case c.Type == spec.SREQ && models[c.Response] == nil:
+				return nil, fmt.Errorf("command %s uses unknown response %q", c.Name, c.Response)
+			case c.Type == spec.AREQ && c.Response != "":
+				return nil, fmt.Errorf("command %s is an AREQ and can't have a response", c.Name)
+			}
+			if _, err := params(c, models); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return models, nil
+}
+
+//reserved are the identifiers used in the generated methods which the parameters must not shadow
+var reserved = map[string]bool{"znp": true, "unp": true, "req": true, "rsp": true, "err": true}
+
+//params returns the parameter names of the command
+func params(c *spec.Command, models map[string]*spec.Model) ([]string, error) {
+	var fields []*spec.Field
+	if c.Request != "" {
+		fields = models[c.Request].Fields
+	}
+	names := c.Params
+	if names == nil {
+		for _, f := range fields {
+			names = append(names, lowerCamel(f.Name))
+		}
+	}
+	if len(names) != len(fields) {
+		return nil, fmt.Errorf("command %s has %d params, but its request has %d fields", c.Name, len(names), len(fields))
+	}
+	for _, name := range names {
+		if token.IsKeyword(name) || reserved[name] || types.Universe.Lookup(name) != nil {
+			return nil, fmt.Errorf("param %s of command %s is a reserved identifier, set the names in Params", name, c.Name)
+		}
+	}
+	return names, nil
+}
+
+//lowerCamel lowers the first letter of the name or its leading acronym, e.g. IEEEAddr becomes ieeeAddr
+func lowerCamel(name string) string {
+	r := []rune(name)
+	n := 0
+	for n < len(r) && unicode.IsUpper(r[n]) {
+		n++
+	}
+	if n > 1 && n < len(r) && unicode.IsLower(r[n]) {
+		n--
+	}
+	for i := 0; i < n; i++ {
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+func section(b *bytes.Buffer, s *spec.Subsystem) {
+	fmt.Fprintf(b, "// =======%s=======", s.Name)
+	if s.Note != "" {
+		fmt.Fprintf(b, " %s", s.Note)
+	}
+	b.WriteString("\n\n")
+}
+
+func comment(b *bytes.Buffer, indent string, text string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(b, "%s//%s\n", indent, line)
+	}
+}
+
+func generateModels(common []*spec.Model, subsystems []*spec.Subsystem) []byte {
+	b := &bytes.Buffer{}
+	b.WriteString(header)
+	b.WriteString("package znp\n\n")
+	for _, m := range common {
+		model(b, m)
+	}
+	for _, s := range subsystems {
+		if len(s.Models) == 0 {
+			continue
+		}
+		section(b, s)
+		for _, m := range s.Models {
+			model(b, m)
+		}
+	}
+	return b.Bytes()
+}
+
+func model(b *bytes.Buffer, m *spec.Model) {
+	comment(b, "", m.Doc)
+	switch {
+	case m.Marker:
+		fmt.Fprintf(b, "type %s interface {\n\t%s()\n}\n\n", m.Name, m.Name)
+		return
+	case len(m.Fields) == 0:
+		fmt.Fprintf(b, "type %s struct{}\n\n", m.Name)
+	default:
+		fmt.Fprintf(b, "type %s struct {\n", m.Name)
+		for _, f := range m.Fields {
+			comment(b, "\t", f.Doc)
+			fmt.Fprintf(b, "\t%s %s", f.Name, f.Type)
+			if f.Tag != "" {
+				fmt.Fprintf(b, " `%s`", f.Tag)
+			}
+			if f.Comment != "" {
+				fmt.Fprintf(b, " //%s", f.Comment)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("}\n\n")
+	}
+	if m.Implements != "" {
+		fmt.Fprintf(b, "func (%s *%s) %s() {}\n\n", strings.ToLower(m.Name[:1]), m.Name, m.Implements)
+	}
+}
+
+func generateCommands(subsystems []*spec.Subsystem, models map[string]*spec.Model) []byte {
+	b := &bytes.Buffer{}
+	b.WriteString(header)
+	b.WriteString("package znp\n\nimport unp \"github.com/dyrkin/unp-go\"\n\n")
+	for _, s := range subsystems {
+		section(b, s)
+		for _, c := range s.Commands {
+			command(b, s, c, models)
+		}
+	}
+	b.WriteString("type key struct {\n\tsubsystem unp.Subsystem\n\tcommand   byte\n}\n\n")
+	b.WriteString("var asyncCommandRegistry = make(map[key]interface{})\n\n")
+	b.WriteString("func init() {\n")
+	first := true
+	for _, s := range subsystems {
+		if len(s.Async) == 0 {
+			continue
+		}
+		if !first {
+			b.WriteString("\n")
+		}
+		first = false
+		fmt.Fprintf(b, "\t//%s\n", s.Name)
+		for _, a := range s.Async {
+			fmt.Fprintf(b, "\tasyncCommandRegistry[key{unp.%s, 0x%02X}] = &%s{}\n", s.Const, a.ID, a.Model)
+		}
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+func command(b *bytes.Buffer, s *spec.Subsystem, c *spec.Command, models map[string]*spec.Model) {
+	names, _ := params(c, models)
+	var args, values []string
+	if c.Request != "" {
+		for i, f := range models[c.Request].Fields {
+			args = append(args, names[i]+" "+f.Type)
+			values = append(values, f.Name+": "+names[i])
+		}
+	}
+	result := "error"
+	if c.Type == spec.SREQ {
+		result = fmt.Sprintf("(rsp *%s, err error)", c.Response)
+	}
+	comment(b, "", c.Doc)
+	b.WriteString(wrap(fmt.Sprintf("func (znp *Znp) %s(", c.Name), args, ") "+result+" {", "\t"))
+	if products := products(s, c); len(products) > 0 {
+		gate := fmt.Sprintf("znp.requireProduct(%q, %q, %s)", c.Name, minVersion(s, c), strings.Join(products, ", "))
+		if c.Type == spec.SREQ {
+			fmt.Fprintf(b, "\tif err = %s; err != nil {\n\t\treturn\n\t}\n", gate)
+		} else {
+			fmt.Fprintf(b, "\tif err := %s; err != nil {\n\t\treturn err\n\t}\n", gate)
+		}
+	}
+	req := "nil"
+	if c.Request != "" {
+		b.WriteString(wrap(fmt.Sprintf("\treq := &%s{", c.Request), values, "}", "\t\t"))
+		req = "req"
+	}
+	call := fmt.Sprintf("znp.ProcessRequest(unp.C_%s, unp.%s, 0x%02X, %s", commandType(c.Type), s.Const, c.ID, req)
+	if c.Type == spec.SREQ {
+		fmt.Fprintf(b, "\terr = %s, &rsp)\n\treturn\n}\n\n", call)
+	} else {
+		fmt.Fprintf(b, "\treturn %s, nil)\n}\n\n", call)
+	}
+}
+
+//products returns the firmwares implementing the command
+func products(s *spec.Subsystem, c *spec.Command) []string {
+	if c.Products != nil {
+		return c.Products
+	}
+	return s.Products
+}
+
+//minVersion returns the oldest release implementing the command, empty when any release does
+func minVersion(s *spec.Subsystem, c *spec.Command) string {
+	if c.MinVersion != "" {
+		return c.MinVersion
+	}
+	return s.MinVersion
+}
+
+func commandType(t spec.Type) string {
+	if t == spec.AREQ {
+		return "AREQ"
+	}
+	return "SREQ"
+}
+
+//wrap joins the items with commas and breaks the line before an item which doesn't fit into lineWidth
+func wrap(prefix string, items []string, suffix string, indent string) string {
+	var b strings.Builder
+	line := prefix
+	for i, item := range items {
+		if i < len(items)-1 {
+			item += ","
+		} else {
+			item += suffix
+		}
+		switch {
+		case i == 0:
+			line += item
+		case width(line)+1+width(item) > lineWidth:
+			b.WriteString(line + "\n")
+			line = indent + item
+		default:
+			line += " " + item
+		}
+	}
+	if len(items) == 0 {
+		line += suffix
+	}
+	return b.String() + line + "\n"
+}
+
+func width(s string) int {
+	return len([]rune(s)) + 3*strings.Count(s, "\t")
+}
+
+func generateTests(common []*spec.Model, subsystems []*spec.Subsystem, models map[string]*spec.Model) []byte {
+	b := &bytes.Buffer{}
+	b.WriteString(header)
+	b.WriteString(`package znp
+
+import (
+	"encoding/hex"
+	"reflect"
+
+	"github.com/dyrkin/bin"
+	. "gopkg.in/check.v1"
+)
+
+var modelExamples = []struct {
+	value   interface{}
+	payload string
+}{
+`)
+	examples := func(models []*spec.Model) {
+		for _, m := range models {
+			for _, e := range m.Examples {
+				fmt.Fprintf(b, "\t{%s, %q},\n", e.Value, e.Payload)
+			}
+		}
+	}
+	examples(common)
+	for _, s := range subsystems {
+		examples(s.Models)
+	}
+	b.WriteString(`}
+
+func (s *MySuite) TestModelExamples(c *C) {
+	for _, example := range modelExamples {
+		payload, err := hex.DecodeString(example.payload)
+		c.Assert(err, IsNil)
+		c.Check(hex.EncodeToString(bin.Encode(example.value)), Equals, example.payload,
+			Commentf("encoding %T", example.value))
+		decoded := reflect.New(reflect.TypeOf(example.value).Elem()).Interface()
+		bin.Decode(payload, decoded)
+		c.Check(decoded, DeepEquals, example.value, Commentf("decoding %T", example.value))
+	}
+}
+
+//modelSamples holds a value of every model, with every field set
+var modelSamples = []interface{}{
+`)
+	for _, m := range append(append([]*spec.Model{}, common...), subsystemModels(subsystems)...) {
+		if value, ok := sample(m, models); ok {
+			fmt.Fprintf(b, "	%s,\n", value)
+		}
+	}
+	b.WriteString(`}
+
+func (s *MySuite) TestModelRoundTrips(c *C) {
+	for _, value := range modelSamples {
+		decoded := reflect.New(reflect.TypeOf(value).Elem()).Interface()
+		bin.Decode(bin.Encode(value), decoded)
+		c.Check(decoded, DeepEquals, value, Commentf("%T", value))
+	}
+}
+`)
+	return b.Bytes()
+}
+
+func subsystemModels(subsystems []*spec.Subsystem) []*spec.Model {
+	var models []*spec.Model
+	for _, s := range subsystems {
+		models = append(models, s.Models...)
+	}
+	return models
+}
+
+var (
+	arrayType = regexp.MustCompile(`^\[(\d+)\](\w+)$`)
+	hexTag    = regexp.MustCompile(`hex:"(\d+)"`)
+	condTag   = regexp.MustCompile(`cond:"uint:(\w+)(==|!=)(\d+)"`)
+)
+
+//sample returns a literal of the model with every field set, so that it round trips through bin. Fields present
+//under a condition are set when the condition holds for the sample. Models holding a marker interface can't be
+//decoded on their own and have no sample.
+func sample(m *spec.Model, models map[string]*spec.Model) (string, bool) {
+	if m.Marker {
+		return "", false
+	}
+	values := map[string]uint64{}
+	for _, f := range m.Fields {
+		if cond := condTag.FindStringSubmatch(f.Tag); cond != nil && cond[2] == "==" {
+			var v uint64
+			fmt.Sscan(cond[3], &v)
+			values[cond[1]] = v
+		}
+	}
+	var fields []string
+	for i, f := range m.Fields {
+		if cond := condTag.FindStringSubmatch(f.Tag); cond != nil {
+			var v uint64
+			fmt.Sscan(cond[3], &v)
+			if (values[cond[1]] == v) != (cond[2] == "==") {
+				continue
+			}
+		}
+		value, ok := fieldSample(f, i, models)
+		if !ok {
+			return "", false
+		}
+		if v, ok := values[f.Name]; ok {
+			value = fmt.Sprint(v)
+		}
+		fields = append(fields, f.Name+": "+value)
+	}
+	return fmt.Sprintf("&%s{%s}", m.Name, strings.Join(fields, ", ")), true
+}
+
+//fieldSample returns the value of the i-th field of a sample
+func fieldSample(f *spec.Field, i int, models map[string]*spec.Model) (string, bool) {
+	n := i%0x7f + 1
+	hexLen := 0
+	if tag := hexTag.FindStringSubmatch(f.Tag); tag != nil {
+		fmt.Sscan(tag[1], &hexLen)
+	}
+	hex := "0x" + strings.Repeat(fmt.Sprintf("%02x", n), hexLen)
+	switch t := f.Type; {
+	case strings.Contains(f.Tag, "bits:"):
+		return "1", true
+	case t == "string":
+		return strconv.Quote(hex), true
+	case t == "[]string":
+		return fmt.Sprintf("[]string{%q, %q}", hex, hex), true
+	case t == "[]uint8" || t == "[]uint16":
+		return fmt.Sprintf("%s{%d, %d}", t, n, n+1), true
+	case arrayType.MatchString(t):
+		return fmt.Sprintf("%s{%d, %d}", t, n, n+1), true
+	case strings.HasPrefix(t, "[]*"):
+		value, ok := sample(models[t[3:]], models)
+		return "[]*" + t[3:] + "{" + strings.TrimPrefix(value, "&"+t[3:]) + "}", ok
+	case strings.HasPrefix(t, "*"):
+		return sample(models[t[1:]], models)
+	case models[t] != nil:
+		return sample(models[t], models)
+	default:
+		//integers and the enums of const.go
+		return strconv.Itoa(n), true
+	}
+}
+
+var docComment = regexp.MustCompile(`(?m)^// ([^=])`)
+
+//compactComments reverts the space gofmt inserts into doc comments, the repo writes them as //Comment
+func compactComments(src []byte) []byte {
+	src = docComment.ReplaceAll(src, []byte("//$1"))
+	return bytes.Replace(src, []byte("//Code generated"), []byte("// Code generated"), 1)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) TestLowerCamel(c *C) {
+	c.Assert(lowerCamel("ClusterID"), Equals, "clusterID")
+	c.Assert(lowerCamel("IEEEAddr"), Equals, "ieeeAddr")
+	c.Assert(lowerCamel("GPDASDU"), Equals, "gpdasdu")
+	c.Assert(lowerCamel("ID"), Equals, "id")
+	c.Assert(lowerCamel("TCSignificance"), Equals, "tcSignificance")
+}
+
+func (s *MySuite) TestGeneratedFilesAreUpToDate(c *C) {
+	files, err := generate()
+	c.Assert(err, IsNil)
+	for name, src := range files {
+		current, err := os.ReadFile(filepath.Join("..", "..", name))
+		c.Assert(err, IsNil)
+		c.Check(string(current) == string(src), Equals, true, Commentf("%s is stale, run go generate", name))
+	}
+}
@@ -0,0 +1,273 @@
+package spec
+
+var Af = &Subsystem{
+	Name:  "AF",
+	Const: "S_AF",
+	Models: []*Model{
+		{
+			Name: "AfRegister",
+			Fields: []*Field{
+				{Name: "EndPoint", Type: "uint8"},
+				{Name: "AppProfID", Type: "uint16"},
+				{Name: "AppDeviceID", Type: "uint16"},
+				{Name: "AddDevVer", Type: "uint8"},
+				{Name: "LatencyReq", Type: "Latency"},
+				{Name: "AppInClusterList", Type: "[]uint16", Tag: `size:"1"`},
+				{Name: "AppOutClusterList", Type: "[]uint16", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "AfDataRequestOptions",
+			Fields: []*Field{
+				{Name: "WildcardProfileID", Type: "uint8", Tag: `bits:"0b00000010" bitmask:"start" `},
+				{Name: "APSAck", Type: "uint8", Tag: `bits:"0b00010000"`},
+				{Name: "DiscoverRoute", Type: "uint8", Tag: `bits:"0b00100000"`},
+				{Name: "APSSecurity", Type: "uint8", Tag: `bits:"0b01000000"`},
+				{Name: "SkipRouting", Type: "uint8", Tag: `bits:"0b10000000" bitmask:"end" `},
+			},
+		},
+		{
+			Name: "AfDataRequest",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "DstEndpoint", Type: "uint8"},
+				{Name: "SrcEndpoint", Type: "uint8"},
+				{Name: "ClusterID", Type: "uint16"},
+				{Name: "TransID", Type: "uint8"},
+				{Name: "Options", Type: "*AfDataRequestOptions"},
+				{Name: "Radius", Type: "uint8"},
+				{Name: "Data", Type: "[]uint8", Tag: `size:"1"`},
+			},
+			Examples: []*Example{
+				{Value: `&AfDataRequest{DstAddr: "0x1234", DstEndpoint: 1, SrcEndpoint: 1, ClusterID: 0x0006, TransID: 1, Options: &AfDataRequestOptions{APSAck: 1}, Radius: 15, Data: []uint8{0x01, 0x02}}`, Payload: "34120101060001100f020102"},
+			},
+		},
+		{
+			Name: "AfDataRequestExt",
+			Fields: []*Field{
+				{Name: "DstAddrMode", Type: "AddrMode"},
+				{Name: "DstAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "DstEndpoint", Type: "uint8"},
+				{Name: "DstPanID", Type: "uint16", Comment: "PAN - personal area networks"},
+				{Name: "SrcEndpoint", Type: "uint8"},
+				{Name: "ClusterID", Type: "uint16"},
+				{Name: "TransID", Type: "uint8"},
+				{Name: "Options", Type: "*AfDataRequestOptions"},
+				{Name: "Radius", Type: "uint8"},
+				{Name: "Data", Type: "[]uint8", Tag: `size:"2"`},
+			},
+		},
+		{
+			Name: "AfDataRequestSrcRtgOptions",
+			Fields: []*Field{
+				{Name: "APSAck", Type: "uint8", Tag: `bits:"0b00000001" bitmask:"start"`},
+				{Name: "APSSecurity", Type: "uint8", Tag: `bits:"0b00000100"`},
+				{Name: "SkipRouting", Type: "uint8", Tag: `bits:"0b00001000" bitmask:"end" `},
+			},
+		},
+		{
+			Name: "AfDataRequestSrcRtg",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "DstEndpoint", Type: "uint8"},
+				{Name: "SrcEndpoint", Type: "uint8"},
+				{Name: "ClusterID", Type: "uint16"},
+				{Name: "TransID", Type: "uint8"},
+				{Name: "Options", Type: "*AfDataRequestSrcRtgOptions"},
+				{Name: "Radius", Type: "uint8"},
+				{Name: "RelayList", Type: "[]string", Tag: `size:"1" hex:"2"`},
+				{Name: "Data", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name:   "AfInterPanCtlData",
+			Marker: true,
+		},
+		{
+			Name:       "AfInterPanClrData",
+			Implements: "AfInterPanCtlData",
+		},
+		{
+			Name:       "AfInterPanSetData",
+			Implements: "AfInterPanCtlData",
+			Fields: []*Field{
+				{Name: "Channel", Type: "uint8"},
+			},
+		},
+		{
+			Name:       "AfInterPanRegData",
+			Implements: "AfInterPanCtlData",
+			Fields: []*Field{
+				{Name: "Endpoint", Type: "uint8"},
+			},
+		},
+		{
+			Name:       "AfInterPanChkData",
+			Implements: "AfInterPanCtlData",
+			Fields: []*Field{
+				{Name: "PanID", Type: "uint16"},
+				{Name: "Endpoint", Type: "uint8"},
+			},
+		},
+		{
+			Name: "AfInterPanCtl",
+			Fields: []*Field{
+				{Name: "Command", Type: "InterPanCommand"},
+				{Name: "Data", Type: "AfInterPanCtlData"},
+			},
+		},
+		{
+			Name: "AfDataRetrieve",
+			Fields: []*Field{
+				{Name: "Timestamp", Type: "uint32"},
+				{Name: "Index", Type: "uint16"},
+				{Name: "Length", Type: "uint8"},
+			},
+		},
+		{
+			Name: "AfDataRetrieveResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "Data", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "AfApsfConfigSet",
+			Fields: []*Field{
+				{Name: "Endpoint", Type: "uint8"},
+				{Name: "FrameDelay", Type: "uint8"},
+				{Name: "WindowSize", Type: "uint8"},
+			},
+		},
+		{
+			Name: "AfDataConfirm",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "Endpoint", Type: "uint8"},
+				{Name: "TransID", Type: "uint8"},
+			},
+			Examples: []*Example{
+				{Value: `&AfDataConfirm{Status: StatusSuccess, Endpoint: 1, TransID: 5}`, Payload: "000105"},
+			},
+		},
+		{
+			Name: "AfReflectError",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "Endpoint", Type: "uint8"},
+				{Name: "TransID", Type: "uint8"},
+				{Name: "DstAddrMode", Type: "AddrMode"},
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "AfIncomingMessage",
+			Fields: []*Field{
+				{Name: "GroupID", Type: "uint16"},
+				{Name: "ClusterID", Type: "uint16"},
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "SrcEndpoint", Type: "uint8"},
+				{Name: "DstEndpoint", Type: "uint8"},
+				{Name: "WasBroadcast", Type: "uint8"},
+				{Name: "LinkQuality", Type: "uint8"},
+				{Name: "SecurityUse", Type: "uint8"},
+				{Name: "Timestamp", Type: "uint32"},
+				{Name: "TransSeqNumber", Type: "uint8"},
+				{Name: "Data", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "AfDataStore",
+			Fields: []*Field{
+				{Name: "Index", Type: "uint16"},
+				{Name: "Data", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "AfIncomingMessageExt",
+			Fields: []*Field{
+				{Name: "GroupID", Type: "uint16"},
+				{Name: "ClusterID", Type: "uint16"},
+				{Name: "SrcAddrMode", Type: "AddrMode"},
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "SrcEndpoint", Type: "uint8"},
+				{Name: "SrcPanID", Type: "uint16"},
+				{Name: "DstEndpoint", Type: "uint8"},
+				{Name: "WasBroadcast", Type: "uint8"},
+				{Name: "LinkQuality", Type: "uint8"},
+				{Name: "SecurityUse", Type: "uint8"},
+				{Name: "Timestamp", Type: "uint32"},
+				{Name: "TransSeqNumber", Type: "uint8"},
+				{Name: "Data", Type: "[]uint8", Tag: `size:"2"`},
+			},
+		},
+	},
+	Commands: []*Command{
+		{
+			Name:     "AfRegister",
+			Type:     SREQ,
+			ID:       0x00,
+			Request:  "AfRegister",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AfDataRequest",
+			Type:     SREQ,
+			ID:       0x01,
+			Request:  "AfDataRequest",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AfDataRequestExt",
+			Type:     SREQ,
+			ID:       0x02,
+			Request:  "AfDataRequestExt",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AfDataRequestSrcRtg",
+			Type:     SREQ,
+			ID:       0x03,
+			Request:  "AfDataRequestSrcRtg",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AfInterPanCtl",
+			Type:     SREQ,
+			ID:       0x10,
+			Request:  "AfInterPanCtl",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "AfDataStore",
+			Doc: `AfDataStore copies a chunk of a message longer than one frame into the buffer allocated by AfDataRequestExt.
+An empty chunk sends the message. See the af package.`,
+			Type:     SREQ,
+			ID:       0x11,
+			Request:  "AfDataStore",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "AfDataRetrieve",
+			Doc: `AfDataRetrieve reads a chunk of an incoming message held by the adapter because it didn't fit into
+AfIncomingMessageExt. A zero length frees the message.`,
+			Type:     SREQ,
+			ID:       0x12,
+			Request:  "AfDataRetrieve",
+			Response: "AfDataRetrieveResponse",
+		},
+		{
+			Name:     "AfApsfConfigSet",
+			Type:     SREQ,
+			ID:       0x13,
+			Request:  "AfApsfConfigSet",
+			Response: "StatusResponse",
+		},
+	},
+	Async: []*Async{
+		{ID: 0x80, Model: "AfDataConfirm"},
+		{ID: 0x83, Model: "AfReflectError"},
+		{ID: 0x81, Model: "AfIncomingMessage"},
+		{ID: 0x82, Model: "AfIncomingMessageExt"},
+	},
+}
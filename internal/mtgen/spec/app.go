@@ -0,0 +1,43 @@
+package spec
+
+var App = &Subsystem{
+	Name:  "APP",
+	Const: "S_APP",
+	Models: []*Model{
+		{
+			Name: "AppMsg",
+			Fields: []*Field{
+				{Name: "AppEndpoint", Type: "uint8"},
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "DstEndpoint", Type: "uint8"},
+				{Name: "ClusterID", Type: "uint16"},
+				{Name: "Message", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "AppUserTest",
+			Fields: []*Field{
+				{Name: "SrcEndpoint", Type: "uint8"},
+				{Name: "CommandID", Type: "uint16"},
+				{Name: "Parameter1", Type: "uint16"},
+				{Name: "Parameter2", Type: "uint16"},
+			},
+		},
+	},
+	Commands: []*Command{
+		{
+			Name:     "AppMsg",
+			Type:     SREQ,
+			ID:       0x00,
+			Request:  "AppMsg",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AppUserTest",
+			Type:     SREQ,
+			ID:       0x01,
+			Request:  "AppUserTest",
+			Response: "StatusResponse",
+		},
+	},
+}
@@ -0,0 +1,213 @@
+package spec
+
+var AppCnf = &Subsystem{
+	Name:  "APP_CNF",
+	Const: "S_APP_CNF",
+	//ZNP 1.2 images don't implement MT_APP_CONFIG, the Z-Stack 3.0 releases report 2.7.x
+	Products:   []string{"ProductZStack3x0", "ProductZStack30x"},
+	MinVersion: "2.7.0",
+	Models: []*Model{
+		{
+			Name: "AppCnfSetNwkFrameCounter",
+			Fields: []*Field{
+				{Name: "FrameCounterValue", Type: "uint32"},
+			},
+			Examples: []*Example{
+				{Value: `&AppCnfSetNwkFrameCounter{FrameCounterValue: 0x00010000}`, Payload: "00000100"},
+			},
+		},
+		{
+			Name: "AppCnfSetDefaultEndDeviceTimeout",
+			Fields: []*Field{
+				{Name: "Timeout", Type: "Timeout"},
+			},
+			Examples: []*Example{
+				{Value: `&AppCnfSetDefaultEndDeviceTimeout{Timeout: Timeout256Minutes}`, Payload: "08"},
+			},
+		},
+		{
+			Name: "AppCnfSetEndDeviceTimeout",
+			Fields: []*Field{
+				{Name: "Timeout", Type: "Timeout"},
+			},
+		},
+		{
+			Name: "AppCnfSetAllowRejoinTcPolicy",
+			Fields: []*Field{
+				{Name: "AllowRejoin", Type: "uint8"},
+			},
+			Examples: []*Example{
+				{Value: `&AppCnfSetAllowRejoinTcPolicy{AllowRejoin: 1}`, Payload: "01"},
+			},
+		},
+		{
+			Name: "AppCnfBdbStartCommissioning",
+			Fields: []*Field{
+				{Name: "CommissioningMode", Type: "CommissioningMode"},
+			},
+			Examples: []*Example{
+				{Value: `&AppCnfBdbStartCommissioning{CommissioningMode: CommissioningModeNetworkFormation}`, Payload: "04"},
+			},
+		},
+		{
+			Name: "AppCnfBdbSetChannel",
+			Fields: []*Field{
+				{Name: "IsPrimary", Type: "uint8"},
+				{Name: "Channel", Type: "*Channels"},
+			},
+		},
+		{
+			Name: "AppCnfBdbAddInstallCode",
+			Fields: []*Field{
+				{Name: "InstallCodeFormat", Type: "InstallCodeFormat"},
+				{Name: "IEEEAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "InstallCode", Type: "[]uint8", Comment: "16 bytes of code and 2 of CRC, or the 16 bytes of the derived key"},
+			},
+		},
+		{
+			Name: "AppCnfBdbSetTcRequireKeyExchange",
+			Fields: []*Field{
+				{Name: "BdbTrustCenterRequireKeyExchange", Type: "uint8"},
+			},
+			Examples: []*Example{
+				{Value: `&AppCnfBdbSetTcRequireKeyExchange{BdbTrustCenterRequireKeyExchange: 1}`, Payload: "01"},
+			},
+		},
+		{
+			Name: "AppCnfBdbSetJoinUsesInstallCodeKey",
+			Fields: []*Field{
+				{Name: "BdbJoinUsesInstallCodeKey", Type: "uint8"},
+			},
+			Examples: []*Example{
+				{Value: `&AppCnfBdbSetJoinUsesInstallCodeKey{BdbJoinUsesInstallCodeKey: 1}`, Payload: "01"},
+			},
+		},
+		{
+			Name: "AppCnfBdbSetActiveDefaultCentralizedKey",
+			Fields: []*Field{
+				{Name: "UseGlobal", Type: "uint8"},
+				{Name: "InstallCode", Type: "[18]uint8"},
+			},
+		},
+		{
+			Name: "RemainingCommissioningModes",
+			Fields: []*Field{
+				{Name: "InitiatorTl", Type: "uint8", Tag: `bits:"0x01" bitmask:"start"`},
+				{Name: "NwkSteering", Type: "uint8", Tag: `bits:"0x02"`},
+				{Name: "NwkFormation", Type: "uint8", Tag: `bits:"0x04"`},
+				{Name: "FindingBinding", Type: "uint8", Tag: `bits:"0x08"`},
+				{Name: "Initialization", Type: "uint8", Tag: `bits:"0x10"`},
+				{Name: "ParentLost", Type: "uint8", Tag: `bits:"0x20" bitmask:"end"`},
+			},
+		},
+		{
+			Name: "AppCnfBdbCommissioningNotification",
+			Fields: []*Field{
+				{Name: "CommissioningStatus", Type: "CommissioningStatus"},
+				{Name: "CommissioningMode", Type: "CommissioningMode"},
+				{Name: "RemainingCommissioningModes", Type: "*RemainingCommissioningModes"},
+			},
+			Examples: []*Example{
+				{Value: `&AppCnfBdbCommissioningNotification{CommissioningStatus: CommissioningStatusSuccess, CommissioningMode: CommissioningModeNetworkFormation, RemainingCommissioningModes: &RemainingCommissioningModes{FindingBinding: 1}}`, Payload: "000408"},
+			},
+		},
+	},
+	//Not declared: the touchlink initiator and target controls, BDB_SET_ATTRIBUTES, the BDB_GET_ status queries and
+	//the further BDB_SET_TC_REQUIRE_KEY_EXCHANGE variants. Their command IDs couldn't be confirmed against a
+	//MT_APP_CONFIG header or an adapter, and a wrong ID would run another command of the firmware.
+	Commands: []*Command{
+		{
+			Name: "AppCnfSetNwkFrameCounter",
+			Doc: `AppCnfSetNwkFrameCounter sets the network frame counter to the value specified in the Frame Counter Value.
+For projects with multiple instances of frame counter, the message sets the frame counter of the
+current network.`,
+			Type:     SREQ,
+			ID:       0xFF,
+			Request:  "AppCnfSetNwkFrameCounter",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AppCnfSetDefaultEndDeviceTimeout",
+			Doc:      "AppCnfSetDefaultEndDeviceTimeout sets the default value used by parent device to expire legacy child devices.",
+			Type:     SREQ,
+			ID:       0x01,
+			Request:  "AppCnfSetDefaultEndDeviceTimeout",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AppCnfSetEndDeviceTimeout",
+			Doc:      "AppCnfSetEndDeviceTimeout sets in ZED the timeout value to be send to parent device for child expiring.",
+			Type:     SREQ,
+			ID:       0x02,
+			Request:  "AppCnfSetEndDeviceTimeout",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AppCnfSetAllowRejoinTcPolicy",
+			Doc:      "AppCnfSetAllowRejoinTcPolicy sets the AllowRejoin TC policy.",
+			Type:     SREQ,
+			ID:       0x03,
+			Request:  "AppCnfSetAllowRejoinTcPolicy",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "AppCnfBdbStartCommissioning",
+			Doc: `AppCnfBdbStartCommissioning set the commissioning methods to be executed. Initialization of BDB is executed with this call,
+regardless of its parameters.`,
+			Type:     SREQ,
+			ID:       0x05,
+			Request:  "AppCnfBdbStartCommissioning",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AppCnfBdbSetChannel",
+			Doc:      "AppCnfBdbSetChannel sets  BDB primary or secondary channel masks.",
+			Type:     SREQ,
+			ID:       0x08,
+			Request:  "AppCnfBdbSetChannel",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AppCnfBdbAddInstallCode",
+			Doc:      "AppCnfBdbAddInstallCode add a preconfigured key (plain key or IC) to Trust Center device.",
+			Type:     SREQ,
+			ID:       0x04,
+			Request:  "AppCnfBdbAddInstallCode",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AppCnfBdbSetTcRequireKeyExchange",
+			Doc:      "AppCnfBdbSetTcRequireKeyExchange sets the policy flag on Trust Center device to mandate or not the TCLK exchange procedure.",
+			Type:     SREQ,
+			ID:       0x09,
+			Request:  "AppCnfBdbSetTcRequireKeyExchange",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AppCnfBdbSetJoinUsesInstallCodeKey",
+			Doc:      "AppCnfBdbSetJoinUsesInstallCodeKey sets the policy to mandate or not the usage of an Install Code upon joining.",
+			Type:     SREQ,
+			ID:       0x06,
+			Request:  "AppCnfBdbSetJoinUsesInstallCodeKey",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AppCnfBdbSetActiveDefaultCentralizedKey",
+			Doc:      "AppCnfBdbSetActiveDefaultCentralizedKey on joining devices, set the default key or an install code to attempt to join the network.",
+			Type:     SREQ,
+			ID:       0x07,
+			Request:  "AppCnfBdbSetActiveDefaultCentralizedKey",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "AppCnfBdbZedAttemptRecoverNwk",
+			Doc:      "AppCnfBdbZedAttemptRecoverNwk instruct the ZED to try to rejoin its previews network. Use only in ZED devices.",
+			Type:     SREQ,
+			ID:       0x0A,
+			Response: "StatusResponse",
+		},
+	},
+	Async: []*Async{
+		{ID: 0x80, Model: "AppCnfBdbCommissioningNotification"},
+	},
+}
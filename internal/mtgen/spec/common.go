@@ -0,0 +1,11 @@
+package spec
+
+//Common are the models shared by all subsystems
+var Common = []*Model{
+	{
+		Name: "StatusResponse",
+		Fields: []*Field{
+			{Name: "Status", Type: "Status"},
+		},
+	},
+}
@@ -0,0 +1,40 @@
+package spec
+
+var Debug = &Subsystem{
+	Name:  "DEBUG",
+	Const: "S_DBG",
+	Models: []*Model{
+		{
+			Name: "DebugSetThreshold",
+			Fields: []*Field{
+				{Name: "ComponentID", Type: "uint8"},
+				{Name: "Threshold", Type: "uint8"},
+			},
+		},
+		{
+			Name: "DebugMsg",
+			Fields: []*Field{
+				{Name: "String", Type: "string", Tag: `size:"1"`},
+			},
+		},
+	},
+	Commands: []*Command{
+		{
+			Name:     "DebugSetThreshold",
+			Type:     SREQ,
+			ID:       0x00,
+			Request:  "DebugSetThreshold",
+			Response: "StatusResponse",
+		},
+		{
+			Name:    "DebugMsg",
+			Type:    AREQ,
+			ID:      0x00,
+			Request: "DebugMsg",
+			Params:  []string{"str"},
+		},
+	},
+	Async: []*Async{
+		{ID: 0x00, Model: "DebugMsg"},
+	},
+}
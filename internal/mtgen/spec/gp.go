@@ -0,0 +1,110 @@
+package spec
+
+var Gp = &Subsystem{
+	Name:  "GP",
+	Const: "S_GP",
+	Models: []*Model{
+		{
+			Name: "TxOptions",
+			Fields: []*Field{
+				{Name: "UseGpTxQueue", Type: "uint8", Tag: `bits:"0b00000001" bitmask:"start"`},
+				{Name: "UseCSMAorCA", Type: "uint8", Tag: `bits:"0b00000010"`},
+				{Name: "UseMacAck", Type: "uint8", Tag: `bits:"0b00000100"`},
+				{Name: "GPDFFrameTypeForTx", Type: "uint8", Tag: `bits:"0b00011000"`},
+				{Name: "TxOnMatchingEndpoint", Type: "uint8", Tag: `bits:"0b00100000" bitmask:"end"`},
+			},
+		},
+		{
+			Name: "GpDataReq",
+			Fields: []*Field{
+				{Name: "Action", Type: "GpAction"},
+				{Name: "TxOptions", Type: "*TxOptions"},
+				{Name: "ApplicationID", Type: "uint8"},
+				{Name: "SrcID", Type: "uint32"},
+				{Name: "GPDIEEEAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "Endpoint", Type: "uint8"},
+				{Name: "GPDCommandID", Type: "uint8"},
+				{Name: "GPDASDU", Type: "[]uint8", Tag: `size:"1"`},
+				{Name: "GPEPHandle", Type: "uint8"},
+				{Name: "GPTxQueueEntryLifetime", Type: "uint32", Tag: `bound:"3"`},
+			},
+		},
+		{
+			Name: "GpSecRsp",
+			Fields: []*Field{
+				{Name: "Status", Type: "GpStatus"},
+				{Name: "DGPStubHandle", Type: "uint8"},
+				{Name: "ApplicationID", Type: "uint8"},
+				{Name: "SrcID", Type: "uint32"},
+				{Name: "GPDIEEEAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "Endpoint", Type: "uint8"},
+				{Name: "GPDFSecurityLevel", Type: "uint8"},
+				{Name: "GPDFKeyType", Type: "uint8"},
+				{Name: "GPDKey", Type: "[16]uint8"},
+				{Name: "GPDSecurityFrameCounter", Type: "uint32"},
+			},
+		},
+		{
+			Name: "GpDataCnf",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "GPMPDUHandle", Type: "uint8"},
+			},
+		},
+		{
+			Name: "GpSecReq",
+			Fields: []*Field{
+				{Name: "ApplicationID", Type: "uint8"},
+				{Name: "SrcID", Type: "uint32"},
+				{Name: "GPDIEEEAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "Endpoint", Type: "uint8"},
+				{Name: "GPDFSecurityLevel", Type: "uint8"},
+				{Name: "GPDFKeyType", Type: "uint8"},
+				{Name: "GPDSecurityFrameCounter", Type: "uint32"},
+				{Name: "DGPStubHandle", Type: "uint8"},
+			},
+		},
+		{
+			Name: "GpDataInd",
+			Fields: []*Field{
+				{Name: "Status", Type: "GpDataIndStatus"},
+				{Name: "RSSI", Type: "uint8"},
+				{Name: "LinkQuality", Type: "uint8"},
+				{Name: "SeqNumber", Type: "uint8"},
+				{Name: "SrcAddrMode", Type: "AddrMode"},
+				{Name: "SrcPANId", Type: "uint16"},
+				{Name: "SrcAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "DstAddrMode", Type: "AddrMode"},
+				{Name: "DstPANId", Type: "uint16"},
+				{Name: "DstAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "GPMPDU", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+	},
+	Commands: []*Command{
+		{
+			Name:     "GpDataReq",
+			Doc:      "GpDataReq callback to receive notifications from BDB process.",
+			Type:     SREQ,
+			ID:       0x01,
+			Request:  "GpDataReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "GpSecRsp",
+			Doc: `GpSecRsp provides a mechanism for the Green Power EndPoint to provide security data into
+the dGP stub.`,
+			Type:     SREQ,
+			ID:       0x02,
+			Request:  "GpSecRsp",
+			Response: "StatusResponse",
+		},
+	},
+	Async: []*Async{
+		{ID: 0x01, Model: "GpDataReq"},
+		{ID: 0x02, Model: "GpSecRsp"},
+		{ID: 0x05, Model: "GpDataCnf"},
+		{ID: 0x03, Model: "GpSecReq"},
+		{ID: 0x04, Model: "GpDataInd"},
+	},
+}
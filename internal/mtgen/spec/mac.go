@@ -0,0 +1,7 @@
+package spec
+
+var Mac = &Subsystem{
+	Name:  "MAC",
+	Note:  "is not supported on my device",
+	Const: "S_MAC",
+}
@@ -0,0 +1,242 @@
+package spec
+
+var Nwk = &Subsystem{
+	Name:  "NWK",
+	Const: "S_NWK",
+	Models: []*Model{
+		{
+			Name: "NwkNldeDataReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Nsdu", Type: "[]uint8", Tag: `size:"1"`},
+				{Name: "NsduHandle", Type: "uint8"},
+				{Name: "NsduHandleOptions", Type: "uint16"},
+				{Name: "SecurityEnable", Type: "uint8"},
+				{Name: "DiscoverRoute", Type: "uint8"},
+				{Name: "RadiusCounter", Type: "uint8"},
+			},
+			Examples: []*Example{
+				{Value: `&NwkNldeDataReq{DstAddr: "0x1234", Nsdu: []uint8{0xaa, 0xbb}, NsduHandle: 7, NsduHandleOptions: 0, SecurityEnable: 1, DiscoverRoute: 1, RadiusCounter: 30}`, Payload: "341202aabb07000001011e"},
+			},
+		},
+		{
+			Name: "NwkNlmeNetworkFormationReq",
+			Fields: []*Field{
+				{Name: "PanID", Type: "uint16"},
+				{Name: "ChannelList", Type: "*Channels"},
+				{Name: "ScanDuration", Type: "uint8"},
+				{Name: "BeaconOrder", Type: "uint8"},
+				{Name: "SuperframeOrder", Type: "uint8"},
+				{Name: "BatteryLifeExtension", Type: "uint8"},
+			},
+		},
+		{
+			Name: "NwkNlmePermitJoiningReq",
+			Fields: []*Field{
+				{Name: "PermitDuration", Type: "uint8", Comment: "Seconds, 0x00 disables and 0xFF enables joining permanently"},
+			},
+		},
+		{
+			Name: "NwkNlmeJoinReq",
+			Fields: []*Field{
+				{Name: "ExtendedPanID", Type: "string", Tag: `hex:"8"`},
+				{Name: "PanID", Type: "uint16"},
+				{Name: "Channel", Type: "uint8"},
+				{Name: "CapabilityInformation", Type: "*CapInfo"},
+			},
+		},
+		{
+			Name: "NwkNlmeLeaveReq",
+			Fields: []*Field{
+				{Name: "ExtendedAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "RemoveChildren", Type: "uint8"},
+				{Name: "Rejoin", Type: "uint8"},
+			},
+		},
+		{
+			Name: "NwkNlmeRouteDiscoveryReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Options", Type: "uint8"},
+				{Name: "Radius", Type: "uint8"},
+			},
+		},
+		{
+			Name: "NwkNlmeNetworkDiscoveryReq",
+			Fields: []*Field{
+				{Name: "ScanChannels", Type: "*Channels"},
+				{Name: "ScanDuration", Type: "uint8"},
+			},
+		},
+		{
+			Name: "NwkNldeDataCnf",
+			Fields: []*Field{
+				{Name: "NsduHandle", Type: "uint8"},
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "NwkNldeDataInd",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Nsdu", Type: "[]uint8", Tag: `size:"1"`},
+				{Name: "LinkQuality", Type: "uint8"},
+			},
+			Examples: []*Example{
+				{Value: `&NwkNldeDataInd{SrcAddr: "0x1234", Nsdu: []uint8{0xaa, 0xbb}, LinkQuality: 200}`, Payload: "341202aabbc8"},
+			},
+		},
+		{
+			Name: "NwkNlmeNetworkFormationCnf",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "NwkNlmeJoinCnf",
+			Fields: []*Field{
+				{Name: "PanID", Type: "uint16"},
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "NwkNlmeJoinInd",
+			Fields: []*Field{
+				{Name: "ShortAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "ExtendedAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "CapabilityInformation", Type: "*CapInfo"},
+			},
+		},
+		{
+			Name: "NwkNlmeLeaveCnf",
+			Fields: []*Field{
+				{Name: "ExtendedAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "RemoveChildren", Type: "uint8"},
+				{Name: "Rejoin", Type: "uint8"},
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "NwkNlmeLeaveInd",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "ExtendedAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "Request", Type: "uint8"},
+				{Name: "RemoveChildren", Type: "uint8"},
+				{Name: "Rejoin", Type: "uint8"},
+			},
+		},
+		{
+			Name: "NwkNlmePollCnf",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "NwkNlmeSyncInd",
+		},
+		{
+			Name: "NetworkDescriptor",
+			Fields: []*Field{
+				{Name: "PanID", Type: "uint16"},
+				{Name: "LogicalChannel", Type: "uint8"},
+				{Name: "BeaconOrder", Type: "uint8"},
+				{Name: "SuperframeOrder", Type: "uint8"},
+				{Name: "RouterCapacity", Type: "uint8"},
+				{Name: "DeviceCapacity", Type: "uint8"},
+				{Name: "ProtocolVersion", Type: "uint8"},
+				{Name: "StackProfile", Type: "uint8"},
+				{Name: "ExtendedPanID", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+		{
+			Name: "NwkNlmeNetworkDiscoveryCnf",
+			Fields: []*Field{
+				{Name: "NetworkList", Type: "[]*NetworkDescriptor", Tag: `size:"1"`},
+			},
+			Examples: []*Example{
+				{Value: `&NwkNlmeNetworkDiscoveryCnf{NetworkList: []*NetworkDescriptor{{PanID: 0x1a62, LogicalChannel: 11, BeaconOrder: 15, SuperframeOrder: 15, RouterCapacity: 1, DeviceCapacity: 1, ProtocolVersion: 2, StackProfile: 2, ExtendedPanID: "0x00124b0001020304"}}}`, Payload: "01621a0b0f0f0101020204030201004b1200"},
+			},
+		},
+	},
+	Commands: []*Command{
+		{
+			Name:     "NwkInit",
+			Doc:      "NwkInit initializes the network layer. Requires a firmware built with MT_NWK_FUNC.",
+			Type:     SREQ,
+			ID:       0x00,
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "NwkNldeDataReq",
+			Doc:      "NwkNldeDataReq sends a network layer data frame. The result is reported with NwkNldeDataCnf.",
+			Type:     SREQ,
+			ID:       0x01,
+			Request:  "NwkNldeDataReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "NwkNlmeNetworkFormationReq",
+			Doc: `NwkNlmeNetworkFormationReq forms a new network as a coordinator. The result is reported with
+NwkNlmeNetworkFormationCnf.`,
+			Type:     SREQ,
+			ID:       0x02,
+			Request:  "NwkNlmeNetworkFormationReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "NwkNlmePermitJoiningReq",
+			Doc:      "NwkNlmePermitJoiningReq allows devices to join through this device for the given duration.",
+			Type:     SREQ,
+			ID:       0x03,
+			Request:  "NwkNlmePermitJoiningReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "NwkNlmeJoinReq",
+			Doc: `NwkNlmeJoinReq joins the network previously found with NwkNlmeNetworkDiscoveryReq. The result is
+reported with NwkNlmeJoinCnf.`,
+			Type:     SREQ,
+			ID:       0x04,
+			Request:  "NwkNlmeJoinReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "NwkNlmeLeaveReq",
+			Doc: `NwkNlmeLeaveReq requests the device with the extended address to leave the network. Use the
+address of this device to leave itself. The result is reported with NwkNlmeLeaveCnf.`,
+			Type:     SREQ,
+			ID:       0x05,
+			Request:  "NwkNlmeLeaveReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "NwkNlmeNetworkDiscoveryReq",
+			Doc:      "NwkNlmeNetworkDiscoveryReq scans the channels for networks. They are reported with NwkNlmeNetworkDiscoveryCnf.",
+			Type:     SREQ,
+			ID:       0x09,
+			Request:  "NwkNlmeNetworkDiscoveryReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "NwkNlmeRouteDiscoveryReq",
+			Doc:      "NwkNlmeRouteDiscoveryReq initiates the discovery of a route to the destination.",
+			Type:     SREQ,
+			ID:       0x0A,
+			Request:  "NwkNlmeRouteDiscoveryReq",
+			Response: "StatusResponse",
+		},
+	},
+	Async: []*Async{
+		{ID: 0x80, Model: "NwkNldeDataCnf"},
+		{ID: 0x81, Model: "NwkNldeDataInd"},
+		{ID: 0x82, Model: "NwkNlmeNetworkFormationCnf"},
+		{ID: 0x83, Model: "NwkNlmeJoinCnf"},
+		{ID: 0x84, Model: "NwkNlmeJoinInd"},
+		{ID: 0x85, Model: "NwkNlmeLeaveCnf"},
+		{ID: 0x86, Model: "NwkNlmeLeaveInd"},
+		{ID: 0x87, Model: "NwkNlmePollCnf"},
+		{ID: 0x88, Model: "NwkNlmeSyncInd"},
+		{ID: 0x89, Model: "NwkNlmeNetworkDiscoveryCnf"},
+	},
+}
@@ -0,0 +1,202 @@
+package spec
+
+var Sapi = &Subsystem{
+	Name:  "SAPI",
+	Const: "S_SAPI",
+	Models: []*Model{
+		{
+			Name: "EmptyResponse",
+		},
+		{
+			Name: "SapiZbPermitJoiningRequest",
+			Fields: []*Field{
+				{Name: "Destination", Type: "string", Tag: `hex:"2"`},
+				{Name: "Timeout", Type: "uint8"},
+			},
+		},
+		{
+			Name: "SapiZbBindDevice",
+			Fields: []*Field{
+				{Name: "Create", Type: "uint8"},
+				{Name: "CommandID", Type: "uint16"},
+				{Name: "Destination", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+		{
+			Name: "SapiZbAllowBind",
+			Fields: []*Field{
+				{Name: "Timeout", Type: "uint8"},
+			},
+		},
+		{
+			Name: "SapiZbSendDataRequest",
+			Fields: []*Field{
+				{Name: "Destination", Type: "string", Tag: `hex:"2"`},
+				{Name: "CommandID", Type: "uint16"},
+				{Name: "Handle", Type: "uint8"},
+				{Name: "Ack", Type: "uint8"},
+				{Name: "Radius", Type: "uint8"},
+				{Name: "Data", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "SapiZbReadConfiguration",
+			Fields: []*Field{
+				{Name: "ConfigID", Type: "uint8"},
+			},
+		},
+		{
+			Name: "SapiZbReadConfigurationResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "ConfigID", Type: "uint8"},
+				{Name: "Value", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "SapiZbWriteConfiguration",
+			Fields: []*Field{
+				{Name: "ConfigID", Type: "uint8"},
+				{Name: "Value", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "SapiZbGetDeviceInfo",
+			Fields: []*Field{
+				{Name: "Param", Type: "uint8"},
+			},
+		},
+		{
+			Name: "SapiZbGetDeviceInfoResponse",
+			Fields: []*Field{
+				{Name: "Param", Type: "uint8"},
+				{Name: "Value", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SapiZbFindDeviceRequest",
+			Fields: []*Field{
+				{Name: "SearchKey", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+		{
+			Name: "SapiZbStartConfirm",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "SapiZbBindConfirm",
+			Fields: []*Field{
+				{Name: "CommandID", Type: "uint16"},
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "SapiZbAllowBindConfirm",
+			Fields: []*Field{
+				{Name: "Source", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "SapiZbSendDataConfirm",
+			Fields: []*Field{
+				{Name: "Handle", Type: "uint8"},
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "SapiZbReceiveDataIndication",
+			Fields: []*Field{
+				{Name: "Source", Type: "string", Tag: `hex:"2"`},
+				{Name: "CommandID", Type: "uint16"},
+				{Name: "Data", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "SapiZbFindDeviceConfirm",
+			Fields: []*Field{
+				{Name: "SearchType", Type: "uint8"},
+				{Name: "Result", Type: "string", Tag: `hex:"2"`},
+				{Name: "SearchKey", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+	},
+	Commands: []*Command{
+		{
+			Name: "SapiZbSystemReset",
+			Type: AREQ,
+			ID:   0x09,
+		},
+		{
+			Name:     "SapiZbStartRequest",
+			Type:     SREQ,
+			ID:       0x00,
+			Response: "EmptyResponse",
+		},
+		{
+			Name:     "SapiZbPermitJoiningRequest",
+			Type:     SREQ,
+			ID:       0x08,
+			Request:  "SapiZbPermitJoiningRequest",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "SapiZbBindDevice",
+			Type:     SREQ,
+			ID:       0x01,
+			Request:  "SapiZbBindDevice",
+			Response: "EmptyResponse",
+		},
+		{
+			Name:     "SapiZbAllowBind",
+			Type:     SREQ,
+			ID:       0x02,
+			Request:  "SapiZbAllowBind",
+			Response: "EmptyResponse",
+		},
+		{
+			Name:     "SapiZbSendDataRequest",
+			Type:     SREQ,
+			ID:       0x03,
+			Request:  "SapiZbSendDataRequest",
+			Response: "EmptyResponse",
+		},
+		{
+			Name:     "SapiZbReadConfiguration",
+			Type:     SREQ,
+			ID:       0x04,
+			Request:  "SapiZbReadConfiguration",
+			Response: "SapiZbReadConfigurationResponse",
+		},
+		{
+			Name:     "SapiZbWriteConfiguration",
+			Type:     SREQ,
+			ID:       0x05,
+			Request:  "SapiZbWriteConfiguration",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "SapiZbGetDeviceInfo",
+			Type:     SREQ,
+			ID:       0x06,
+			Request:  "SapiZbGetDeviceInfo",
+			Response: "SapiZbGetDeviceInfoResponse",
+		},
+		{
+			Name:     "SapiZbFindDeviceRequest",
+			Type:     SREQ,
+			ID:       0x07,
+			Request:  "SapiZbFindDeviceRequest",
+			Response: "EmptyResponse",
+		},
+	},
+	Async: []*Async{
+		{ID: 0x80, Model: "SapiZbStartConfirm"},
+		{ID: 0x81, Model: "SapiZbBindConfirm"},
+		{ID: 0x82, Model: "SapiZbAllowBindConfirm"},
+		{ID: 0x83, Model: "SapiZbSendDataConfirm"},
+		{ID: 0x87, Model: "SapiZbReceiveDataIndication"},
+		{ID: 0x85, Model: "SapiZbFindDeviceConfirm"},
+	},
+}
@@ -0,0 +1,90 @@
+//Package spec declares the MT commands supported by znp. It is the source for the generated command.go, model.go
+//and model_test.go of the root package, run `go generate` after changing it.
+package spec
+
+//Type of the command sent to the adapter
+type Type int
+
+const (
+	//SREQ is a synchronous request answered by the adapter with an SRSP
+	SREQ Type = iota
+	//AREQ is an asynchronous request which has no response
+	AREQ
+)
+
+//Subsystem groups the models, commands and async commands of an MT subsystem. Everything is emitted in the
+//order it is declared.
+type Subsystem struct {
+	//Name is used in the section markers of the generated files, e.g. AF
+	Name string
+	//Note is appended to the section marker
+	Note string
+	//Const is the unp subsystem constant, e.g. S_AF
+	Const string
+	//Products lists the firmwares implementing the subsystem. Commands are sent to any firmware when it is empty
+	Products []string
+	//MinVersion is the oldest release of the products implementing the subsystem, e.g. 2.7.0
+	MinVersion string
+	Models     []*Model
+	Commands   []*Command
+	Async      []*Async
+}
+
+//Command is a request sent to the adapter. The generated method takes the fields of the request as parameters.
+type Command struct {
+	Name string
+	Doc  string
+	Type Type
+	ID   byte
+	//Request is the name of the model sent as the payload. Commands without payload leave it empty
+	Request string
+	//Response is the name of the model the SRSP is decoded into. Only used with SREQ
+	Response string
+	//Params overrides the parameter names derived from the request fields
+	Params []string
+	//Products overrides the Products of the subsystem
+	Products []string
+	//MinVersion overrides the MinVersion of the subsystem
+	MinVersion string
+}
+
+//Async is a command sent by the adapter on its own, e.g. an indication or a confirmation
+type Async struct {
+	ID    byte
+	Model string
+}
+
+//Model is a struct encoded and decoded with github.com/dyrkin/bin
+type Model struct {
+	Name   string
+	Doc    string
+	Fields []*Field
+	//Marker makes the model an interface with a single marker method of the same name
+	Marker bool
+	//Implements is the name of a marker model implemented by this model
+	Implements string
+	//Examples are checked by the generated golden tests
+	Examples []*Example
+}
+
+type Field struct {
+	Name string
+	//Type is the Go type of the field
+	Type string
+	//Tag is the raw struct tag without the backquotes
+	Tag     string
+	Doc     string
+	Comment string
+}
+
+//Example is a model value and its MT payload. The value must encode into the payload and the payload must
+//decode into the value.
+type Example struct {
+	//Value is a Go expression of the model type, e.g. &SysOsalNvRead{ID: 0x0083}
+	Value string
+	//Payload is the hex encoded payload
+	Payload string
+}
+
+//Subsystems are all supported subsystems
+var Subsystems = []*Subsystem{Af, App, Debug, Mac, Nwk, Sapi, Sys, Util, Zdo, AppCnf, Gp}
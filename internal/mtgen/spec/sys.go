@@ -0,0 +1,656 @@
+package spec
+
+var Sys = &Subsystem{
+	Name:  "SYS",
+	Const: "S_SYS",
+	Models: []*Model{
+		{
+			Name: "SysResetReq",
+			Fields: []*Field{
+				{Name: "ResetType", Type: "byte", Doc: `This command will reset the device by using a hardware reset (i.e.
+watchdog reset) if ‘Type’ is zero. Otherwise a soft reset (i.e. a jump to the
+reset vector) is done. This is especially useful in the CC2531, for
+instance, so that the USB host does not have to contend with the USB
+H/W resetting (and thus causing the USB host to re-enumerate the device
+which can cause an open virtual serial port to hang.)`},
+			},
+		},
+		{
+			Name: "Capabilities",
+			Doc:  "Capabilities represents the interfaces that this device can handle (compiled into the device)",
+			Fields: []*Field{
+				{Name: "Sys", Type: "uint16", Tag: `bitmask:"start" bits:"0x0001"`},
+				{Name: "Mac", Type: "uint16", Tag: `bits:"0x0002"`},
+				{Name: "Nwk", Type: "uint16", Tag: `bits:"0x0004"`},
+				{Name: "Af", Type: "uint16", Tag: `bits:"0x0008"`},
+				{Name: "Zdo", Type: "uint16", Tag: `bits:"0x0010"`},
+				{Name: "Sapi", Type: "uint16", Tag: `bits:"0x0020"`},
+				{Name: "Util", Type: "uint16", Tag: `bits:"0x0040"`},
+				{Name: "Debug", Type: "uint16", Tag: `bits:"0x0080"`},
+				{Name: "App", Type: "uint16", Tag: `bits:"0x0100"`},
+				{Name: "Zoad", Type: "uint16", Tag: `bitmask:"end" bits:"0x1000"`},
+			},
+		},
+		{
+			Name: "SysPingResponse",
+			Fields: []*Field{
+				{Name: "Capabilities", Type: "*Capabilities"},
+			},
+		},
+		{
+			Name: "SysVersionResponse",
+			Fields: []*Field{
+				{Name: "TransportRev", Type: "uint8", Comment: "Transport protocol revision"},
+				{Name: "Product", Type: "Product", Comment: "Product Id"},
+				{Name: "MajorRel", Type: "uint8", Comment: "Software major release number"},
+				{Name: "MinorRel", Type: "uint8", Comment: "Software minor release number"},
+				{Name: "MaintRel", Type: "uint8", Comment: "Software maintenance release number"},
+				{Name: "Revision", Type: "uint32", Comment: "Build date, only reported by Z-Stack 3.x"},
+			},
+			Examples: []*Example{
+				{Value: `&SysVersionResponse{TransportRev: 2, Product: ProductZStack3x0, MajorRel: 2, MinorRel: 7, MaintRel: 1, Revision: 20210708}`, Payload: "020102070114643401"},
+			},
+		},
+		{
+			Name: "SysSetExtAddr",
+			Fields: []*Field{
+				{Name: "ExtAddress", Type: "string", Tag: `hex:"8"`, Comment: "The device’s extended address."},
+			},
+		},
+		{
+			Name: "SysGetExtAddrResponse",
+			Fields: []*Field{
+				{Name: "ExtAddress", Type: "string", Tag: `hex:"8"`, Comment: "The device’s extended address."},
+			},
+		},
+		{
+			Name: "SysRamRead",
+			Fields: []*Field{
+				{Name: "Address", Type: "uint16", Comment: "Address of the memory that will be read."},
+				{Name: "Len", Type: "uint8", Comment: "The number of bytes that will be read from the target RAM."},
+			},
+		},
+		{
+			Name: "SysRamReadResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "uint8", Comment: "Status is either Success (0) or Failure (1)."},
+				{Name: "Value", Type: "[]uint8", Tag: `size:"1"`, Comment: "The value read from the target RAM."},
+			},
+		},
+		{
+			Name: "SysRamWrite",
+			Fields: []*Field{
+				{Name: "Address", Type: "uint16", Comment: "Address of the memory that will be written."},
+				{Name: "Value", Type: "[]uint8", Tag: `size:"1"`, Comment: "The value written to the target RAM."},
+			},
+		},
+		{
+			Name: "SysOsalNvRead",
+			Fields: []*Field{
+				{Name: "ID", Type: "uint16"},
+				{Name: "Offset", Type: "uint8"},
+			},
+			Examples: []*Example{
+				{Value: `&SysOsalNvRead{ID: 0x0083, Offset: 0}`, Payload: "830000"},
+			},
+		},
+		{
+			Name: "SysOsalNvReadResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "Value", Type: "[]uint8", Tag: `size:"1" log:"redact"`},
+			},
+			Examples: []*Example{
+				{Value: `&SysOsalNvReadResponse{Status: StatusSuccess, Value: []uint8{0x62, 0x1a}}`, Payload: "0002621a"},
+			},
+		},
+		{
+			Name: "SysOsalNvWrite",
+			Fields: []*Field{
+				{Name: "ID", Type: "uint16"},
+				{Name: "Offset", Type: "uint8"},
+				{Name: "Value", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "SysOsalNvItemInit",
+			Fields: []*Field{
+				{Name: "ID", Type: "uint16"},
+				{Name: "ItemLen", Type: "uint16"},
+				{Name: "InitData", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "SysOsalNvDelete",
+			Fields: []*Field{
+				{Name: "ID", Type: "uint16"},
+				{Name: "ItemLen", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SysOsalNvLength",
+			Fields: []*Field{
+				{Name: "ID", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SysOsalNvLengthResponse",
+			Fields: []*Field{
+				{Name: "Length", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SysOsalStartTimer",
+			Fields: []*Field{
+				{Name: "ID", Type: "uint8"},
+				{Name: "Timeout", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SysOsalStopTimer",
+			Fields: []*Field{
+				{Name: "ID", Type: "uint8"},
+			},
+		},
+		{
+			Name: "SysRandomResponse",
+			Fields: []*Field{
+				{Name: "Value", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SysAdcRead",
+			Fields: []*Field{
+				{Name: "Channel", Type: "Channel"},
+				{Name: "Resolution", Type: "Resolution"},
+			},
+		},
+		{
+			Name: "SysAdcReadResponse",
+			Fields: []*Field{
+				{Name: "Value", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SysGpio",
+			Fields: []*Field{
+				{Name: "Operation", Type: "Operation"},
+				{Name: "Value", Type: "uint8"},
+			},
+		},
+		{
+			Name: "SysGpioResponse",
+			Fields: []*Field{
+				{Name: "Value", Type: "uint8"},
+			},
+		},
+		{
+			Name: "SysTime",
+			Fields: []*Field{
+				{Name: "UTCTime", Type: "uint32"},
+				{Name: "Hour", Type: "uint8"},
+				{Name: "Minute", Type: "uint8"},
+				{Name: "Second", Type: "uint8"},
+				{Name: "Month", Type: "uint8"},
+				{Name: "Day", Type: "uint8"},
+				{Name: "Year", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SysSetTxPower",
+			Fields: []*Field{
+				{Name: "TXPower", Type: "uint8"},
+			},
+		},
+		{
+			Name: "SysSetTxPowerResponse",
+			Fields: []*Field{
+				{Name: "TXPower", Type: "uint8"},
+			},
+		},
+		{
+			Name: "SysZDiagsClearStats",
+			Fields: []*Field{
+				{Name: "ClearNV", Type: "uint8"},
+			},
+		},
+		{
+			Name: "SysZDiagsClearStatsResponse",
+			Fields: []*Field{
+				{Name: "SysClock", Type: "uint32"},
+			},
+		},
+		{
+			Name: "SysZDiagsGetStats",
+			Fields: []*Field{
+				{Name: "AttributeID", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SysZDiagsGetStatsResponse",
+			Fields: []*Field{
+				{Name: "AttributeValue", Type: "uint32"},
+			},
+		},
+		{
+			Name: "SysZDiagsSaveStatsToNvResponse",
+			Fields: []*Field{
+				{Name: "SysClock", Type: "uint32"},
+			},
+		},
+		{
+			Name: "SysNvCreate",
+			Fields: []*Field{
+				{Name: "SysID", Type: "uint8"},
+				{Name: "ItemID", Type: "uint16"},
+				{Name: "SubID", Type: "uint16"},
+				{Name: "Length", Type: "uint32"},
+			},
+		},
+		{
+			Name: "SysNvDelete",
+			Fields: []*Field{
+				{Name: "SysID", Type: "uint8"},
+				{Name: "ItemID", Type: "uint16"},
+				{Name: "SubID", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SysNvLength",
+			Fields: []*Field{
+				{Name: "SysID", Type: "uint8"},
+				{Name: "ItemID", Type: "uint16"},
+				{Name: "SubID", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SysNvLengthResponse",
+			Fields: []*Field{
+				{Name: "Length", Type: "uint32"},
+			},
+		},
+		{
+			Name: "SysNvRead",
+			Fields: []*Field{
+				{Name: "SysID", Type: "uint8"},
+				{Name: "ItemID", Type: "uint16"},
+				{Name: "SubID", Type: "uint16"},
+				{Name: "Offset", Type: "uint16"},
+				{Name: "Length", Type: "uint8"},
+			},
+		},
+		{
+			Name: "SysNvReadResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "Value", Type: "[]uint8", Tag: `size:"1" log:"redact"`},
+			},
+		},
+		{
+			Name: "SysNvWrite",
+			Fields: []*Field{
+				{Name: "SysID", Type: "uint8"},
+				{Name: "ItemID", Type: "uint16"},
+				{Name: "SubID", Type: "uint16"},
+				{Name: "Offset", Type: "uint16"},
+				{Name: "Value", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "SysNvUpdate",
+			Fields: []*Field{
+				{Name: "SysID", Type: "uint8"},
+				{Name: "ItemID", Type: "uint16"},
+				{Name: "SubID", Type: "uint16"},
+				{Name: "Value", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "SysNvCompact",
+			Fields: []*Field{
+				{Name: "Threshold", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SysNvReadExt",
+			Fields: []*Field{
+				{Name: "ID", Type: "uint16"},
+				{Name: "Offset", Type: "uint16"},
+			},
+		},
+		{
+			Name: "SysNvWriteExt",
+			Fields: []*Field{
+				{Name: "ID", Type: "uint16"},
+				{Name: "Offset", Type: "uint16"},
+				{Name: "Value", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "SysResetInd",
+			Fields: []*Field{
+				{Name: "Reason", Type: "Reason"},
+				{Name: "TransportRev", Type: "uint8"},
+				{Name: "Product", Type: "uint8"},
+				{Name: "MinorRel", Type: "uint8"},
+				{Name: "HwRev", Type: "uint8"},
+			},
+		},
+		{
+			Name: "SysOsalTimerExpired",
+			Fields: []*Field{
+				{Name: "ID", Type: "uint8"},
+			},
+		},
+	},
+	Commands: []*Command{
+		{
+			Name:    "SysResetReq",
+			Doc:     "SysReset is sent by the tester to reset the target device",
+			Type:    AREQ,
+			ID:      0x00,
+			Request: "SysResetReq",
+		},
+		{
+			Name:     "SysPing",
+			Doc:      "SysPing issues PING requests to verify if a device is active and check the capability of the device.",
+			Type:     SREQ,
+			ID:       0x01,
+			Response: "SysPingResponse",
+		},
+		{
+			Name:     "SysVersion",
+			Type:     SREQ,
+			ID:       0x02,
+			Response: "SysVersionResponse",
+		},
+		{
+			Name:     "SysSetExtAddr",
+			Doc:      "SysSetExtAddr is used to set the extended address of the device",
+			Type:     SREQ,
+			ID:       0x03,
+			Request:  "SysSetExtAddr",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "SysGetExtAddr",
+			Doc:      "SysGetExtAddr is used to get the extended address of the device",
+			Type:     SREQ,
+			ID:       0x04,
+			Response: "SysGetExtAddrResponse",
+		},
+		{
+			Name: "SysRamRead",
+			Doc: `SysRamRead is used by the tester to read a single memory location in the target RAM. The
+command accepts an address value and returns the memory value present in the target RAM at that address.`,
+			Type:     SREQ,
+			ID:       0x05,
+			Request:  "SysRamRead",
+			Response: "SysRamReadResponse",
+			Params:   []string{"address", "length"},
+		},
+		{
+			Name: "SysRamWrite",
+			Doc: `SysRamWrite is used by the tester to write to a particular location in the target RAM. The
+command accepts an address location and a memory value. The memory value is written to the
+address location in the target RAM.`,
+			Type:     SREQ,
+			ID:       0x06,
+			Request:  "SysRamWrite",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "SysOsalNvRead",
+			Doc: `SysOsalNvRead is used by the tester to read a single memory item from the target non-volatile
+memory. The command accepts an attribute Id value and data offset and returns the memory value
+present in the target for the specified attribute Id.`,
+			Type:     SREQ,
+			ID:       0x08,
+			Request:  "SysOsalNvRead",
+			Response: "SysOsalNvReadResponse",
+		},
+		{
+			Name: "SysOsalNvWrite",
+			Doc: `SysOsalNvWrite is used by the tester to write to a particular item in non-volatile memory. The
+command accepts an attribute Id, data offset, data length, and attribute value. The attribute value is
+written to the location specified for the attribute Id in the target.`,
+			Type:     SREQ,
+			ID:       0x09,
+			Request:  "SysOsalNvWrite",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "SysOsalNvItemInit",
+			Doc: `SysOsalNvItemInit is used by the tester to create and initialize an item in non-volatile memory. The
+NV item will be created if it does not already exist. The data for the new NV item will be left
+uninitialized if the InitLen parameter is zero. When InitLen is non-zero, the data for the NV item
+will be initialized (starting at offset of zero) with the values from InitData. Note that it is not
+necessary to initialize the entire NV item (InitLen < ItemLen). It is also possible to create an NV
+item that is larger than the maximum length InitData – use the SYS_OSAL_NV_WRITE
+command to finish the initialization.`,
+			Type:     SREQ,
+			ID:       0x07,
+			Request:  "SysOsalNvItemInit",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "SysOsalNvDelete",
+			Doc: `SysOsalNvDelete is used by the tester to delete an item from the non-volatile memory. The ItemLen
+parameter must match the length of the NV item or the command will fail. Use this command with
+caution – deleted items cannot be recovered.`,
+			Type:     SREQ,
+			ID:       0x12,
+			Request:  "SysOsalNvDelete",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "SysOsalNvLength",
+			Doc: `SysOsalNvLength is used by the tester to get the length of an item in non-volatile memory. A
+returned length of zero indicates that the NV item does not exist.`,
+			Type:     SREQ,
+			ID:       0x13,
+			Request:  "SysOsalNvLength",
+			Response: "SysOsalNvLengthResponse",
+		},
+		{
+			Name: "SysOsalStartTimer",
+			Doc: `SysOsalStartTimer is used by the tester to start a timer event. The event will expired after the indicated
+amount of time and a notification will be sent back to the tester.`,
+			Type:     SREQ,
+			ID:       0x0A,
+			Request:  "SysOsalStartTimer",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "SysOsalStopTimer",
+			Doc:      "SysOsalStopTimer is used by the tester to stop a timer event.",
+			Type:     SREQ,
+			ID:       0x0B,
+			Request:  "SysOsalStopTimer",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "SysRandom",
+			Doc:      "SysRandom is used by the tester to get a random 16-bit number.",
+			Type:     SREQ,
+			ID:       0x0C,
+			Response: "SysRandomResponse",
+		},
+		{
+			Name:     "SysAdcRead",
+			Doc:      "SysAdcRead reads a value from the ADC based on specified channel and resolution.",
+			Type:     SREQ,
+			ID:       0x0D,
+			Request:  "SysAdcRead",
+			Response: "SysAdcReadResponse",
+		},
+		{
+			Name:     "SysGpio",
+			Doc:      "SysGpio is used by the tester to control the 4 GPIO pins on the CC2530-ZNP build.",
+			Type:     SREQ,
+			ID:       0x0E,
+			Request:  "SysGpio",
+			Response: "SysGpioResponse",
+		},
+		{
+			Name: "SysSetTime",
+			Doc: `SysSetTime is used by the tester to set the target system date and time. The time can be
+specified in “seconds since 00:00:00 on January 1, 2000” or in parsed date/time components`,
+			Type:     SREQ,
+			ID:       0x10,
+			Request:  "SysTime",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "SysGetTime",
+			Doc: `SysGetTime is used by the tester to get the target system date and time. The time is returned in
+seconds since 00:00:00 on January 1, 2000” and parsed date/time components.`,
+			Type:     SREQ,
+			ID:       0x11,
+			Response: "SysTime",
+		},
+		{
+			Name: "SysSetTxPower",
+			Doc: `SysSetTxPower is used by the tester to set the target system radio transmit power. The returned TX
+power is the actual setting applied to the radio – nearest characterized value for the specific radio`,
+			Type:     SREQ,
+			ID:       0x14,
+			Request:  "SysSetTxPower",
+			Response: "SysSetTxPowerResponse",
+		},
+		{
+			Name:     "SysZDiagsInitStats",
+			Doc:      "SysZDiagsInitStats is used to initialize the statistics table in NV memory.",
+			Type:     SREQ,
+			ID:       0x17,
+			Response: "StatusResponse",
+		},
+		{
+			Name: "SysZDiagsClearStats",
+			Doc: `SysZDiagsClearStats is used to clear the statistics table. To clear data in NV (including the Boot
+Counter) the clearNV flag shall be set to TRUE.`,
+			Type:     SREQ,
+			ID:       0x18,
+			Request:  "SysZDiagsClearStats",
+			Response: "SysZDiagsClearStatsResponse",
+		},
+		{
+			Name:     "SysZDiagsGetStats",
+			Doc:      "SysZDiagsGetStats is used to read a specific system (attribute) ID statistics and/or metrics value.",
+			Type:     SREQ,
+			ID:       0x19,
+			Request:  "SysZDiagsGetStats",
+			Response: "SysZDiagsGetStatsResponse",
+		},
+		{
+			Name:     "SysZDiagsRestoreStatsNv",
+			Doc:      "SysZDiagsRestoreStatsNv is used to restore the statistics table from NV into the RAM table.",
+			Type:     SREQ,
+			ID:       0x1A,
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "SysZDiagsSaveStatsToNv",
+			Doc:      "SysZDiagsSaveStatsToNv is used to save the statistics table from RAM to NV.",
+			Type:     SREQ,
+			ID:       0x1B,
+			Response: "SysZDiagsSaveStatsToNvResponse",
+		},
+		{
+			Name:       "SysNvCreate",
+			Doc:        "SysNvCreate is used to attempt to create an item in non-volatile memory.",
+			Type:       SREQ,
+			ID:         0x30,
+			Request:    "SysNvCreate",
+			Response:   "StatusResponse",
+			Products:   []string{"ProductZStack3x0", "ProductZStack30x"},
+			MinVersion: "2.7.0",
+		},
+		{
+			Name:       "SysNvDelete",
+			Doc:        "SysNvDelete is used to attempt to delete an item in non-volatile memory.",
+			Type:       SREQ,
+			ID:         0x31,
+			Request:    "SysNvDelete",
+			Response:   "StatusResponse",
+			Products:   []string{"ProductZStack3x0", "ProductZStack30x"},
+			MinVersion: "2.7.0",
+		},
+		{
+			Name:       "SysNvLength",
+			Doc:        "SysNvLength is used to get the length of an item in non-volatile memory.",
+			Type:       SREQ,
+			ID:         0x32,
+			Request:    "SysNvLength",
+			Response:   "SysNvLengthResponse",
+			Products:   []string{"ProductZStack3x0", "ProductZStack30x"},
+			MinVersion: "2.7.0",
+		},
+		{
+			Name:       "SysNvRead",
+			Doc:        "SysNvRead is used to read an item in non-volatile memory",
+			Type:       SREQ,
+			ID:         0x33,
+			Request:    "SysNvRead",
+			Response:   "SysNvReadResponse",
+			Products:   []string{"ProductZStack3x0", "ProductZStack30x"},
+			MinVersion: "2.7.0",
+		},
+		{
+			Name:       "SysNvWrite",
+			Doc:        "SysNvWrite is used to write an item in non-volatile memory",
+			Type:       SREQ,
+			ID:         0x34,
+			Request:    "SysNvWrite",
+			Response:   "StatusResponse",
+			Products:   []string{"ProductZStack3x0", "ProductZStack30x"},
+			MinVersion: "2.7.0",
+		},
+		{
+			Name:       "SysNvUpdate",
+			Doc:        "SysNvUpdate is used to update an item in non-volatile memory",
+			Type:       SREQ,
+			ID:         0x35,
+			Request:    "SysNvUpdate",
+			Response:   "StatusResponse",
+			Products:   []string{"ProductZStack3x0", "ProductZStack30x"},
+			MinVersion: "2.7.0",
+		},
+		{
+			Name:       "SysNvCompact",
+			Doc:        "SysNvCompact is used to compact the active page in non-volatile memory",
+			Type:       SREQ,
+			ID:         0x36,
+			Request:    "SysNvCompact",
+			Response:   "StatusResponse",
+			Products:   []string{"ProductZStack3x0", "ProductZStack30x"},
+			MinVersion: "2.7.0",
+		},
+		{
+			Name: "SysNvReadExt",
+			Doc: `SysNvReadExt is used by the tester to read a single memory item from the target non-volatile
+memory. The command accepts an attribute Id value and data offset and returns the memory value
+present in the target for the specified attribute Id. Unlike SysOsalNvRead it takes a 16-bit offset.`,
+			Type:       SREQ,
+			ID:         0x1C,
+			Request:    "SysNvReadExt",
+			Response:   "SysNvReadResponse",
+			Products:   []string{"ProductZStack3x0", "ProductZStack30x"},
+			MinVersion: "2.7.0",
+		},
+		{
+			Name:       "SysNvWriteExt",
+			Doc:        "SysNvWriteExt is used to write an item in non-volatile memory at a 16-bit offset",
+			Type:       SREQ,
+			ID:         0x1D,
+			Request:    "SysNvWriteExt",
+			Response:   "StatusResponse",
+			Products:   []string{"ProductZStack3x0", "ProductZStack30x"},
+			MinVersion: "2.7.0",
+		},
+	},
+	Async: []*Async{
+		{ID: 0x80, Model: "SysResetInd"},
+		{ID: 0x81, Model: "SysOsalTimerExpired"},
+	},
+}
@@ -0,0 +1,617 @@
+package spec
+
+var Util = &Subsystem{
+	Name:  "UTIL",
+	Const: "S_UTIL",
+	Models: []*Model{
+		{
+			Name: "DeviceType",
+			Fields: []*Field{
+				{Name: "Coordinator", Type: "uint8", Tag: `bits:"0x01" bitmask:"start"`},
+				{Name: "Router", Type: "uint8", Tag: `bits:"0x02"`},
+				{Name: "EndDevice", Type: "uint8", Tag: `bits:"0x04" bitmask:"end"`},
+			},
+		},
+		{
+			Name: "UtilGetDeviceInfoResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "IEEEAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "ShortAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "DeviceType", Type: "*DeviceType"},
+				{Name: "DeviceState", Type: "DeviceState"},
+				{Name: "AssocDevicesList", Type: "[]string", Tag: `size:"1" hex:"2"`},
+			},
+		},
+		{
+			Name: "NvInfoStatus",
+			Fields: []*Field{
+				{Name: "IEEEAddress", Type: "Status", Tag: `bits:"0b00000001" bitmask:"start"`},
+				{Name: "ScanChannels", Type: "Status", Tag: `bits:"0b00000010"`},
+				{Name: "PanID", Type: "Status", Tag: `bits:"0b00000100"`},
+				{Name: "SecurityLevel", Type: "Status", Tag: `bits:"0b00001000"`},
+				{Name: "PreConfigKey", Type: "Status", Tag: `bits:"0b00010000" bitmask:"end"`},
+			},
+		},
+		{
+			Name: "UtilGetNvInfoResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "*NvInfoStatus"},
+				{Name: "IEEEAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "ScanChannels", Type: "uint32"},
+				{Name: "PanID", Type: "uint16"},
+				{Name: "SecurityLevel", Type: "uint8"},
+				{Name: "PreConfigKey", Type: "[16]uint8"},
+			},
+		},
+		{
+			Name: "UtilSetPanId",
+			Fields: []*Field{
+				{Name: "PanID", Type: "uint16"},
+			},
+		},
+		{
+			Name: "UtilSetChannels",
+			Fields: []*Field{
+				{Name: "Channels", Type: "*Channels"},
+			},
+		},
+		{
+			Name: "UtilSetSecLevel",
+			Fields: []*Field{
+				{Name: "SecLevel", Type: "uint8"},
+			},
+		},
+		{
+			Name: "UtilSetPreCfgKey",
+			Fields: []*Field{
+				{Name: "PreCfgKey", Type: "[16]uint8"},
+			},
+		},
+		{
+			Name: "UtilCallbackSubCmd",
+			Fields: []*Field{
+				{Name: "SubsystemID", Type: "SubsystemId"},
+				{Name: "Action", Type: "Action"},
+			},
+		},
+		{
+			Name: "Keys",
+			Fields: []*Field{
+				{Name: "Key1", Type: "uint8", Tag: `bits:"0x01" bitmask:"start"`},
+				{Name: "Key2", Type: "uint8", Tag: `bits:"0x02"`},
+				{Name: "Key3", Type: "uint8", Tag: `bits:"0x04"`},
+				{Name: "Key4", Type: "uint8", Tag: `bits:"0x08"`},
+				{Name: "Key5", Type: "uint8", Tag: `bits:"0x10"`},
+				{Name: "Key6", Type: "uint8", Tag: `bits:"0x20"`},
+				{Name: "Key7", Type: "uint8", Tag: `bits:"0x40"`},
+				{Name: "Key8", Type: "uint8", Tag: `bits:"0x80" bitmask:"end"`},
+			},
+		},
+		{
+			Name: "UtilKeyEvent",
+			Fields: []*Field{
+				{Name: "Keys", Type: "*Keys"},
+				{Name: "Shift", Type: "Shift"},
+			},
+		},
+		{
+			Name: "UtilTimeAliveResponse",
+			Fields: []*Field{
+				{Name: "Seconds", Type: "uint32"},
+			},
+		},
+		{
+			Name: "UtilLedControl",
+			Fields: []*Field{
+				{Name: "LedID", Type: "uint8"},
+				{Name: "Mode", Type: "Mode"},
+			},
+		},
+		{
+			Name: "UtilLoopback",
+			Fields: []*Field{
+				{Name: "Data", Type: "[]uint8"},
+			},
+		},
+		{
+			Name: "UtilDataReq",
+			Fields: []*Field{
+				{Name: "SecurityUse", Type: "uint8"},
+			},
+		},
+		{
+			Name: "UtilSrcMatchAddEntry",
+			Fields: []*Field{
+				{Name: "AddrMode", Type: "AddrMode"},
+				{Name: "Address", Type: "string", Tag: `hex:"8"`},
+				{Name: "PanID", Type: "uint16"},
+			},
+		},
+		{
+			Name: "UtilSrcMatchDelEntry",
+			Fields: []*Field{
+				{Name: "AddrMode", Type: "AddrMode"},
+				{Name: "Address", Type: "string", Tag: `hex:"8"`},
+				{Name: "PanID", Type: "uint16"},
+			},
+		},
+		{
+			Name: "UtilSrcMatchCheckSrcAddr",
+			Fields: []*Field{
+				{Name: "AddrMode", Type: "AddrMode"},
+				{Name: "Address", Type: "string", Tag: `hex:"8"`},
+				{Name: "PanID", Type: "uint16"},
+			},
+		},
+		{
+			Name: "UtilSrcMatchAckAllPending",
+			Fields: []*Field{
+				{Name: "Option", Type: "Action"},
+			},
+		},
+		{
+			Name: "UtilSrcMatchCheckAllPendingResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "Value", Type: "uint8"},
+			},
+		},
+		{
+			Name: "UtilAddrMgrExtAddrLookup",
+			Fields: []*Field{
+				{Name: "ExtAddr", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+		{
+			Name: "UtilAddrMgrExtAddrLookupResponse",
+			Fields: []*Field{
+				{Name: "NwkAddr", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "UtilAddrMgrAddrLookup",
+			Fields: []*Field{
+				{Name: "NwkAddr", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "UtilAddrMgrAddrLookupResponse",
+			Fields: []*Field{
+				{Name: "ExtAddr", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+		{
+			Name: "UtilApsmeLinkKeyDataGet",
+			Fields: []*Field{
+				{Name: "ExtAddr", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+		{
+			Name: "UtilApsmeLinkKeyDataGetResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "SecKey", Type: "[16]uint8", Tag: `log:"redact"`},
+				{Name: "TxFrmCntr", Type: "uint32"},
+				{Name: "RxFrmCntr", Type: "uint32"},
+			},
+		},
+		{
+			Name: "UtilApsmeLinkKeyNvIdGet",
+			Fields: []*Field{
+				{Name: "ExtAddr", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+		{
+			Name: "UtilApsmeLinkKeyNvIdGetResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "LinkKeyNvId", Type: "uint16"},
+			},
+		},
+		{
+			Name: "UtilApsmeRequestKeyCmd",
+			Fields: []*Field{
+				{Name: "PartnerAddr", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+		{
+			Name: "UtilAssocCount",
+			Fields: []*Field{
+				{Name: "StartRelation", Type: "Relation"},
+				{Name: "EndRelation", Type: "Relation"},
+			},
+		},
+		{
+			Name: "UtilAssocCountResponse",
+			Fields: []*Field{
+				{Name: "Count", Type: "uint16"},
+			},
+		},
+		{
+			Name: "LinkInfo",
+			Fields: []*Field{
+				{Name: "TxCounter", Type: "uint8", Comment: "Counter of transmission success/failures"},
+				{Name: "TxCost", Type: "uint8", Comment: "Average of sending rssi values if link staus is enabled"},
+				{Name: "RxLqi", Type: "uint8", Doc: "i.e. NWK_LINK_STATUS_PERIOD is defined as non zero", Comment: "average of received rssi values"},
+				{Name: "InKeySeqNum", Type: "uint8", Doc: "needs to be converted to link cost (1-7) before used", Comment: "security key sequence number"},
+				{Name: "InFrmCntr", Type: "uint32", Comment: "security frame counter.."},
+				{Name: "TxFailure", Type: "uint16", Comment: "higher values indicate more failures"},
+			},
+		},
+		{
+			Name: "AgingEndDevice",
+			Fields: []*Field{
+				{Name: "EndDevCfg", Type: "uint8"},
+				{Name: "DeviceTimeout", Type: "uint32"},
+			},
+		},
+		{
+			Name: "Device",
+			Fields: []*Field{
+				{Name: "ShortAddr", Type: "string", Tag: `hex:"2"`, Comment: "Short address of associated device, or invalid 0xfffe"},
+				{Name: "AddrIdx", Type: "uint16", Comment: "Index from the address manager"},
+				{Name: "NodeRelation", Type: "uint8"},
+				{Name: "DevStatus", Type: "uint8", Comment: "bitmap of various status values"},
+				{Name: "AssocCnt", Type: "uint8"},
+				{Name: "Age", Type: "uint8"},
+				{Name: "LinkInfo", Type: "*LinkInfo"},
+				{Name: "EndDev", Type: "*AgingEndDevice"},
+				{Name: "TimeoutCounter", Type: "uint32"},
+				{Name: "KeepaliveRcv", Type: "uint8"},
+			},
+		},
+		{
+			Name: "UtilAssocFindDevice",
+			Fields: []*Field{
+				{Name: "Number", Type: "uint8"},
+			},
+		},
+		{
+			Name: "UtilAssocFindDeviceResponse",
+			Fields: []*Field{
+				{Name: "Device", Type: "*Device"},
+			},
+		},
+		{
+			Name: "UtilAssocGetWithAddr",
+			Fields: []*Field{
+				{Name: "ExtAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "NwkAddr", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "UtilAssocGetWithAddrResponse",
+			Fields: []*Field{
+				{Name: "Device", Type: "*Device"},
+			},
+		},
+		{
+			Name: "UtilBindAddEntry",
+			Fields: []*Field{
+				{Name: "AddrMode", Type: "AddrMode"},
+				{Name: "DstAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "DstEndpoint", Type: "uint8"},
+				{Name: "ClusterIDs", Type: "[]uint16", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "BindEntry",
+			Fields: []*Field{
+				{Name: "SrcEP", Type: "uint8"},
+				{Name: "DstGroupMode", Type: "uint8"},
+				{Name: "DstIdx", Type: "uint16"},
+				{Name: "DstEP", Type: "uint8"},
+				{Name: "ClusterIDList", Type: "[]uint16", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "UtilBindAddEntryResponse",
+			Fields: []*Field{
+				{Name: "BindEntry", Type: "*BindEntry"},
+			},
+		},
+		{
+			Name: "UtilZclKeyEstInitEst",
+			Fields: []*Field{
+				{Name: "TaskID", Type: "uint8"},
+				{Name: "SeqNum", Type: "uint8"},
+				{Name: "EndPoint", Type: "uint8"},
+				{Name: "AddrMode", Type: "AddrMode"},
+				{Name: "Addr", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+		{
+			Name: "UtilZclKeyEstSign",
+			Fields: []*Field{
+				{Name: "Input", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "UtilZclKeyEstSignResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "Key", Type: "[42]uint8"},
+			},
+		},
+		{
+			Name: "UtilSrngGenResponse",
+			Fields: []*Field{
+				{Name: "SecureRandomNumbers", Type: "[100]uint8"},
+			},
+		},
+		{
+			Name: "UtilSyncReq",
+		},
+		{
+			Name: "UtilZclKeyEstablishInd",
+			Fields: []*Field{
+				{Name: "TaskId", Type: "uint8"},
+				{Name: "Event", Type: "uint8"},
+				{Name: "Status", Type: "uint8"},
+				{Name: "WaitTime", Type: "uint8"},
+				{Name: "Suite", Type: "uint16"},
+			},
+		},
+	},
+	Commands: []*Command{
+		{
+			Name:     "UtilGetDeviceInfo",
+			Doc:      "UtilGetDeviceInfo is sent by the tester to retrieve the device info.",
+			Type:     SREQ,
+			ID:       0x00,
+			Response: "UtilGetDeviceInfoResponse",
+		},
+		{
+			Name: "UtilGetNvInfo",
+			Doc: `UtilGetNvInfo is used by the tester to read a block of parameters from non-volatile storage of the
+target device.`,
+			Type:     SREQ,
+			ID:       0x01,
+			Response: "UtilGetNvInfoResponse",
+		},
+		{
+			Name:     "UtilSetPanId",
+			Doc:      "UtilSetPanId stores a PanId value into non-volatile memory to be used the next time the target device resets.",
+			Type:     SREQ,
+			ID:       0x02,
+			Request:  "UtilSetPanId",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "UtilSetChannels",
+			Doc: `UtilSetChannels is used to store a channel select bit-mask into non-volatile memory to be used the
+next time the target device resets.`,
+			Type:     SREQ,
+			ID:       0x03,
+			Request:  "UtilSetChannels",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "UtilSetSecLevel",
+			Doc: `UtilSetSecLevel is used to store a security level value into non-volatile memory to be used the next time the target device
+resets.`,
+			Type:     SREQ,
+			ID:       0x04,
+			Request:  "UtilSetSecLevel",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "UtilSetPreCfgKey",
+			Doc: `UtilSetPreCfgKey is used to store a pre-configured key array into non-volatile memory to be used the
+next time the target device resets.`,
+			Type:     SREQ,
+			ID:       0x05,
+			Request:  "UtilSetPreCfgKey",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "UtilCallbackSubCmd",
+			Doc: `UtilCallbackSubCmd subscribes/unsubscribes to layer callbacks. For particular subsystem callbacks to
+work, the software must be compiled with a special flag that is unique to that subsystem to enable
+the callback mechanism. For example to enable ZDO callbacks, MT_ZDO_CB_FUNC flag must
+be compiled when the software is built. For complete list of callback compile flags, check section
+1.2 or “Z-Stack Compile Options” document.`,
+			Type:     SREQ,
+			ID:       0x06,
+			Request:  "UtilCallbackSubCmd",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "UtilKeyEvent",
+			Doc: `UtilKeyEvent sends key and shift codes to the application that registered for key events. The keys parameter is a
+bit mask, allowing for multiple keys in a single command. The return status indicates success if
+the command is processed by a registered key handler, not whether the key code was used. Not all
+applications support all key or shift codes but there is no indication when a key code is dropped.`,
+			Type:     SREQ,
+			ID:       0x07,
+			Request:  "UtilKeyEvent",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "UtilTimeAlive",
+			Doc:      "UtilTimeAlive is used by the tester to get the board’s time alive",
+			Type:     SREQ,
+			ID:       0x09,
+			Response: "UtilTimeAliveResponse",
+		},
+		{
+			Name:     "UtilLedControl",
+			Doc:      "UtilLedControl is used by the tester to control the LEDs on the board.",
+			Type:     SREQ,
+			ID:       0x0A,
+			Request:  "UtilLedControl",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "UtilLoopback",
+			Doc:      "UtilLoopback is used by the tester to test data buffer loopback.",
+			Type:     SREQ,
+			ID:       0x10,
+			Request:  "UtilLoopback",
+			Response: "UtilLoopback",
+		},
+		{
+			Name:     "UtilDataReq",
+			Doc:      "UtilDataReq is used by the tester to effect a MAC MLME Poll Request",
+			Type:     SREQ,
+			ID:       0x11,
+			Request:  "UtilDataReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "UtilSrcMatchEnable",
+			Doc:      "UtilSrcMatchEnable is used to enable AUTOPEND and source address matching.",
+			Type:     SREQ,
+			ID:       0x20,
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "UtilSrcMatchAddEntry",
+			Doc:      "UtilSrcMatchAddEntry is used to add a short or extended address to the source address table",
+			Type:     SREQ,
+			ID:       0x21,
+			Request:  "UtilSrcMatchAddEntry",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "UtilSrcMatchDelEntry",
+			Doc:      "UtilSrcMatchDelEntry is used to delete a short or extended address from the source address table.",
+			Type:     SREQ,
+			ID:       0x22,
+			Request:  "UtilSrcMatchDelEntry",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "UtilSrcMatchCheckSrcAddr",
+			Doc:      "UtilSrcMatchCheckSrcAddr is used to delete a short or extended address from the source address table.",
+			Type:     SREQ,
+			ID:       0x23,
+			Request:  "UtilSrcMatchCheckSrcAddr",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "UtilSrcMatchAckAllPending",
+			Doc:      "UtilSrcMatchAckAllPending is used to enable/disable acknowledging all packets with pending bit set.",
+			Type:     SREQ,
+			ID:       0x24,
+			Request:  "UtilSrcMatchAckAllPending",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "UtilSrcMatchCheckAllPending",
+			Doc:      "UtilSrcMatchCheckAllPending is used to check if acknowledging all packets with pending bit set is enabled.",
+			Type:     SREQ,
+			ID:       0x25,
+			Response: "UtilSrcMatchCheckAllPendingResponse",
+		},
+		{
+			Name:     "UtilAddrMgrExtAddrLookup",
+			Doc:      "UtilAddrMgrExtAddrLookup is a proxy call to the AddrMgrEntryLookupExt() function.",
+			Type:     SREQ,
+			ID:       0x40,
+			Request:  "UtilAddrMgrExtAddrLookup",
+			Response: "UtilAddrMgrExtAddrLookupResponse",
+		},
+		{
+			Name:     "UtilAddrMgrAddrLookup",
+			Doc:      "UtilAddrMgrAddrLookup is a proxy call to the AddrMgrEntryLookupNwk() function.",
+			Type:     SREQ,
+			ID:       0x41,
+			Request:  "UtilAddrMgrAddrLookup",
+			Response: "UtilAddrMgrAddrLookupResponse",
+		},
+		{
+			Name:     "UtilApsmeLinkKeyDataGet",
+			Doc:      "UtilApsmeLinkKeyDataGet retrieves APS link key data, Tx and Rx frame counters",
+			Type:     SREQ,
+			ID:       0x44,
+			Request:  "UtilApsmeLinkKeyDataGet",
+			Response: "UtilApsmeLinkKeyDataGetResponse",
+		},
+		{
+			Name:     "UtilApsmeLinkKeyNvIdGet",
+			Doc:      "UtilApsmeLinkKeyNvIdGet is a proxy call to the APSME_LinkKeyNvIdGet() function.",
+			Type:     SREQ,
+			ID:       0x45,
+			Request:  "UtilApsmeLinkKeyNvIdGet",
+			Response: "UtilApsmeLinkKeyNvIdGetResponse",
+		},
+		{
+			Name: "UtilApsmeRequestKeyCmd",
+			Doc: `UtilApsmeRequestKeyCmd is used to send a request key to the Trust Center from an originator device who
+wants to exchange messages with a partner device.`,
+			Type:     SREQ,
+			ID:       0x4B,
+			Request:  "UtilApsmeRequestKeyCmd",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "UtilAssocCount",
+			Doc:      "UtilAssocCount is a proxy call to the AssocCount() function",
+			Type:     SREQ,
+			ID:       0x48,
+			Request:  "UtilAssocCount",
+			Response: "UtilAssocCountResponse",
+		},
+		{
+			Name:     "UtilAssocFindDevice",
+			Doc:      "UtilAssocFindDevice is a proxy call to the AssocFindDevice() function.",
+			Type:     SREQ,
+			ID:       0x49,
+			Request:  "UtilAssocFindDevice",
+			Response: "UtilAssocFindDeviceResponse",
+		},
+		{
+			Name:     "UtilAssocGetWithAddr",
+			Doc:      "UtilAssocGetWithAddr is a proxy call to the AssocGetWithAddress() function.",
+			Type:     SREQ,
+			ID:       0x4A,
+			Request:  "UtilAssocGetWithAddr",
+			Response: "UtilAssocGetWithAddrResponse",
+		},
+		{
+			Name:     "UtilBindAddEntry",
+			Doc:      "UtilBindAddEntry is a proxy call to the bindAddEntry() function",
+			Type:     SREQ,
+			ID:       0x4D,
+			Request:  "UtilBindAddEntry",
+			Response: "UtilBindAddEntryResponse",
+		},
+		{
+			Name:     "UtilZclKeyEstInitEst",
+			Doc:      "UtilZclKeyEstInitEst is a proxy call to zclGeneral_KeyEstablish_InitiateKeyEstablishment().",
+			Type:     SREQ,
+			ID:       0x80,
+			Request:  "UtilZclKeyEstInitEst",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "UtilZclKeyEstSign",
+			Doc:      "UtilZclKeyEstSign is a proxy call to zclGeneral_KeyEstablishment_ECDSASign().",
+			Type:     SREQ,
+			ID:       0x81,
+			Request:  "UtilZclKeyEstSign",
+			Response: "UtilZclKeyEstSignResponse",
+		},
+		{
+			Name: "UtilSrngGen",
+			Doc: `UtilSrngGen is used to generate Secure Random Number. It generates 1,000,000 bits in sets of
+100 bytes. As in 100 bytes of secure random numbers are generated until 1,000,000 bits are
+generated. 100 bytes are generate`,
+			Type:     SREQ,
+			ID:       0x4C,
+			Response: "UtilSrngGenResponse",
+		},
+		{
+			Name: "UtilSyncReq",
+			Doc:  "UtilSyncReq is an asynchronous request/response handshake.",
+			Type: AREQ,
+			ID:   0xE0,
+		},
+	},
+	Async: []*Async{
+		{ID: 0xE0, Model: "UtilSyncReq"},
+		{ID: 0xE1, Model: "UtilZclKeyEstablishInd"},
+	},
+}
@@ -0,0 +1,1324 @@
+package spec
+
+var Zdo = &Subsystem{
+	Name:  "ZDO",
+	Const: "S_ZDO",
+	Models: []*Model{
+		{
+			Name: "ZdoNwkAddrReq",
+			Fields: []*Field{
+				{Name: "IEEEAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "ReqType", Type: "ReqType"},
+				{Name: "StartIndex", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoIeeeAddrReq",
+			Fields: []*Field{
+				{Name: "ShortAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "ReqType", Type: "ReqType"},
+				{Name: "StartIndex", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoNodeDescReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "NWKAddrOfInterest", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "ZdoPowerDescReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "NWKAddrOfInterest", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "ZdoUserDescReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "NWKAddrOfInterest", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "ZdoComplexDescReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "NWKAddrOfInterest", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "ZdoMatchDescReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "NWKAddrOfInterest", Type: "string", Tag: `hex:"2"`},
+				{Name: "ProfileID", Type: "uint16"},
+				{Name: "InClusterList", Type: "[]uint16", Tag: `size:"1"`},
+				{Name: "OutClusterList", Type: "[]uint16", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoSimpleDescReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "NWKAddrOfInterest", Type: "string", Tag: `hex:"2"`},
+				{Name: "Endpoint", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoActiveEpReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "NWKAddrOfInterest", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "CapInfo",
+			Fields: []*Field{
+				{Name: "AlternatePANCoordinator", Type: "uint8", Tag: `bits:"0b00000001" bitmask:"start"`},
+				{Name: "Router", Type: "uint8", Tag: `bits:"0b00000010"`},
+				{Name: "MainPowered", Type: "uint8", Tag: `bits:"0b00000100"`},
+				{Name: "ReceiverOnWhenIdle", Type: "uint8", Tag: `bits:"0b00001000"`},
+				{Name: "Reserved1", Type: "uint8", Tag: `bits:"0b00010000"`},
+				{Name: "Reserved2", Type: "uint8", Tag: `bits:"0b00100000"`},
+				{Name: "Security", Type: "uint8", Tag: `bits:"0b01000000"`},
+				{Name: "AllocAddr", Type: "uint8", Tag: `bits:"0b10000000" bitmask:"end"`},
+			},
+		},
+		{
+			Name: "ZdoEndDeviceAnnce",
+			Fields: []*Field{
+				{Name: "NwkAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "IEEEAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "Capabilities", Type: "*CapInfo"},
+			},
+		},
+		{
+			Name: "ZdoUserDescSet",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "NWKAddrOfInterest", Type: "string", Tag: `hex:"2"`},
+				{Name: "UserDescriptor", Type: "string", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ServerMask",
+			Fields: []*Field{
+				{Name: "PrimTrustCenter", Type: "uint16", Tag: `bits:"0x01" bitmask:"start"`},
+				{Name: "BkupTrustCenter", Type: "uint16", Tag: `bits:"0x02"`},
+				{Name: "PrimBindTable", Type: "uint16", Tag: `bits:"0x04"`},
+				{Name: "BkupBindTable", Type: "uint16", Tag: `bits:"0x08"`},
+				{Name: "PrimDiscTable", Type: "uint16", Tag: `bits:"0x10"`},
+				{Name: "BkupDiscTable", Type: "uint16", Tag: `bits:"0x20"`},
+				{Name: "NetworkManager", Type: "uint16", Tag: `bits:"0x40" bitmask:"end"`},
+			},
+		},
+		{
+			Name: "ZdoServerDiscReq",
+			Fields: []*Field{
+				{Name: "ServerMask", Type: "*ServerMask"},
+			},
+		},
+		{
+			Name: "ZdoEndDeviceBindReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "LocalCoordinatorAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "IEEEAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "Endpoint", Type: "uint8"},
+				{Name: "ProfileID", Type: "uint16"},
+				{Name: "InClusterList", Type: "[]uint16", Tag: `size:"1"`},
+				{Name: "OutClusterList", Type: "[]uint16", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoBindUnbindReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "SrcAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "SrcEndpoint", Type: "uint8"},
+				{Name: "ClusterID", Type: "uint16"},
+				{Name: "DstAddrMode", Type: "AddrMode"},
+				{Name: "DstAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "DstEndpoint", Type: "uint8"},
+			},
+		},
+		{
+			Name: "Channels",
+			Fields: []*Field{
+				{Name: "Channel11", Type: "uint32", Tag: `bits:"0x00000800" bitmask:"start"`},
+				{Name: "Channel12", Type: "uint32", Tag: `bits:"0x00001000"`},
+				{Name: "Channel13", Type: "uint32", Tag: `bits:"0x00002000"`},
+				{Name: "Channel14", Type: "uint32", Tag: `bits:"0x00004000"`},
+				{Name: "Channel15", Type: "uint32", Tag: `bits:"0x00008000"`},
+				{Name: "Channel16", Type: "uint32", Tag: `bits:"0x00010000"`},
+				{Name: "Channel17", Type: "uint32", Tag: `bits:"0x00020000"`},
+				{Name: "Channel18", Type: "uint32", Tag: `bits:"0x00040000"`},
+				{Name: "Channel19", Type: "uint32", Tag: `bits:"0x00080000"`},
+				{Name: "Channel20", Type: "uint32", Tag: `bits:"0x00100000"`},
+				{Name: "Channel21", Type: "uint32", Tag: `bits:"0x00200000"`},
+				{Name: "Channel22", Type: "uint32", Tag: `bits:"0x00400000"`},
+				{Name: "Channel23", Type: "uint32", Tag: `bits:"0x00800000"`},
+				{Name: "Channel24", Type: "uint32", Tag: `bits:"0x01000000"`},
+				{Name: "Channel25", Type: "uint32", Tag: `bits:"0x02000000"`},
+				{Name: "Channel26", Type: "uint32", Tag: `bits:"0x04000000" bitmask:"end"`},
+			},
+		},
+		{
+			Name: "ZdoMgmtNwkDiskReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "ScanChannels", Type: "*Channels"},
+				{Name: "ScanDuration", Type: "uint8"},
+				{Name: "StartIndex", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoMgmtLqiReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "StartIndex", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoMgmtRtgReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "StartIndex", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoMgmtBindReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "StartIndex", Type: "uint8"},
+			},
+		},
+		{
+			Name: "RemoveChildrenRejoin",
+			Fields: []*Field{
+				{Name: "Rejoin", Type: "uint8", Tag: `bits:"0b00000001" bitmask:"start"`},
+				{Name: "RemoveChildren", Type: "uint8", Tag: `bits:"0b00000010" bitmask:"end"`},
+			},
+		},
+		{
+			Name: "ZdoMgmtLeaveReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "DeviceAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "RemoveChildrenRejoin", Type: "*RemoveChildrenRejoin"},
+			},
+		},
+		{
+			Name: "ZdoMgmtDirectJoinReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "DeviceAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "CapInfo", Type: "*CapInfo"},
+			},
+		},
+		{
+			Name: "ZdoMgmtPermitJoinReq",
+			Fields: []*Field{
+				{Name: "AddrMode", Type: "AddrMode"},
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Duration", Type: "uint8"},
+				{Name: "TCSignificance", Type: "uint8"},
+			},
+			Examples: []*Example{
+				{Value: `&ZdoMgmtPermitJoinReq{AddrMode: AddrModeAddr16Bit, DstAddr: "0xfffc", Duration: 60, TCSignificance: 0}`, Payload: "02fcff3c00"},
+			},
+		},
+		{
+			Name: "ZdoMgmtNwkUpdateReq",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "DstAddrMode", Type: "AddrMode"},
+				{Name: "ChannelMask", Type: "*Channels"},
+				{Name: "ScanDuration", Type: "uint8", Comment: "0x00-0x05 scans, 0xFE changes the channel, 0xFF updates the channel mask"},
+				{Name: "ScanCount", Type: "uint8"},
+				{Name: "NwkManagerAddr", Type: "string", Tag: `hex:"2"`},
+			},
+			Examples: []*Example{
+				{Value: `&ZdoMgmtNwkUpdateReq{DstAddr: "0x1234", DstAddrMode: AddrModeAddr16Bit, ChannelMask: &Channels{Channel15: 1, Channel26: 1}, ScanDuration: 3, ScanCount: 1, NwkManagerAddr: "0x0000"}`, Payload: "3412020080000403010000"},
+			},
+		},
+		{
+			Name: "ZdoMsgCbRegister",
+			Fields: []*Field{
+				{Name: "ClusterID", Type: "uint16"},
+			},
+		},
+		{
+			Name: "ZdoMsgCbRemove",
+			Fields: []*Field{
+				{Name: "ClusterID", Type: "uint16"},
+			},
+		},
+		{
+			Name: "ZdoStartupFromApp",
+			Fields: []*Field{
+				{Name: "StartDelay", Type: "uint16"},
+			},
+		},
+		{
+			Name: "ZdoStartupFromAppResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "StartupFromAppStatus"},
+			},
+		},
+		{
+			Name: "ZdoSetLinkKey",
+			Fields: []*Field{
+				{Name: "ShortAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "IEEEAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "LinkKeyData", Type: "[16]uint8", Tag: `log:"redact"`},
+			},
+		},
+		{
+			Name: "ZdoRemoveLinkKey",
+			Fields: []*Field{
+				{Name: "IEEEAddr", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+		{
+			Name: "ZdoGetLinkKey",
+			Fields: []*Field{
+				{Name: "IEEEAddr", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+		{
+			Name: "ZdoGetLinkKeyResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "IEEEAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "LinkKeyData", Type: "[16]uint8", Tag: `log:"redact"`},
+			},
+		},
+		{
+			Name: "ZdoNwkDiscoveryReq",
+			Fields: []*Field{
+				{Name: "ScanChannels", Type: "*Channels"},
+				{Name: "ScanDuration", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoJoinReq",
+			Fields: []*Field{
+				{Name: "LogicalChannel", Type: "uint8"},
+				{Name: "PanID", Type: "uint16"},
+				{Name: "ExtendedPanID", Type: "uint64", Comment: "64-bit extended PAN ID (ver. 1.1 only). If not v1.1 or don't care, use all 0xFF"},
+				{Name: "ChosenParent", Type: "string", Tag: `hex:"2"`},
+				{Name: "ParentDepth", Type: "uint8"},
+				{Name: "StackProfile", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoSetRejoinParameters",
+			Fields: []*Field{
+				{Name: "BackoffDuration", Type: "uint32"},
+				{Name: "ScanDuration", Type: "uint32"},
+			},
+		},
+		{
+			Name: "ZdoSecAddLinkKey",
+			Fields: []*Field{
+				{Name: "ShortAddress", Type: "string", Tag: `hex:"2"`},
+				{Name: "ExtendedAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "Key", Type: "[16]uint8", Tag: `log:"redact"`},
+			},
+		},
+		{
+			Name: "ZdoSecEntryLookupExt",
+			Fields: []*Field{
+				{Name: "ExtendedAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "Entry", Type: "[5]uint8"},
+			},
+		},
+		{
+			Name: "ZdoSecEntryLookupExtResponse",
+			Fields: []*Field{
+				{Name: "AMI", Type: "uint16"},
+				{Name: "KeyNVID", Type: "uint16"},
+				{Name: "AuthenticationOption", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoSecDeviceRemove",
+			Fields: []*Field{
+				{Name: "ExtendedAddress", Type: "string", Tag: `hex:"8"`},
+			},
+		},
+		{
+			Name: "ZdoExtRouteDisc",
+			Fields: []*Field{
+				{Name: "DestinationAddress", Type: "string", Tag: `hex:"2"`},
+				{Name: "Options", Type: "uint8"},
+				{Name: "Radius", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoExtRouteCheck",
+			Fields: []*Field{
+				{Name: "DestinationAddress", Type: "string", Tag: `hex:"2"`},
+				{Name: "RTStatus", Type: "uint8"},
+				{Name: "Options", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoExtRemoveGroup",
+			Fields: []*Field{
+				{Name: "Endpoint", Type: "uint8"},
+				{Name: "GroupID", Type: "uint16"},
+			},
+		},
+		{
+			Name: "ZdoExtRemoveAllGroup",
+			Fields: []*Field{
+				{Name: "Endpoint", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoExtFindAllGroupsEndpoint",
+			Fields: []*Field{
+				{Name: "Endpoint", Type: "uint8"},
+				{Name: "GroupList", Type: "[]uint16", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoExtFindAllGroupsEndpointResponse",
+			Fields: []*Field{
+				{Name: "Groups", Type: "[]uint16", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoExtFindGroup",
+			Fields: []*Field{
+				{Name: "Endpoint", Type: "uint8"},
+				{Name: "GroupID", Type: "uint16"},
+			},
+		},
+		{
+			Name: "ZdoExtFindGroupResponse",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "GroupID", Type: "uint16"},
+				{Name: "Name", Type: "string", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoExtAddGroup",
+			Fields: []*Field{
+				{Name: "Endpoint", Type: "uint8"},
+				{Name: "GroupID", Type: "uint16"},
+				{Name: "GroupName", Type: "string", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoExtCountAllGroupsResponse",
+			Fields: []*Field{
+				{Name: "Count", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoExtRxIdle",
+			Fields: []*Field{
+				{Name: "SetFlag", Type: "uint8"},
+				{Name: "SetValue", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoExtUpdateNwkKey",
+			Fields: []*Field{
+				{Name: "DestinationAddress", Type: "string", Tag: `hex:"2"`},
+				{Name: "KeySeqNum", Type: "uint8"},
+				{Name: "Key", Type: "[16]uint8"},
+			},
+		},
+		{
+			Name: "ZdoExtSwitchNwkKey",
+			Fields: []*Field{
+				{Name: "DestinationAddress", Type: "string", Tag: `hex:"2"`},
+				{Name: "KeySeqNum", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoExtNwkInfoResponse",
+			Fields: []*Field{
+				{Name: "ShortAddress", Type: "string", Tag: `hex:"2"`},
+				{Name: "PanID", Type: "uint16"},
+				{Name: "ParentAddress", Type: "string", Tag: `hex:"2"`},
+				{Name: "ExtendedPanID", Type: "uint64"},
+				{Name: "ExtendedParentAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "Channel", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoExtSeqApsRemoveReq",
+			Fields: []*Field{
+				{Name: "NwkAddress", Type: "string", Tag: `hex:"2"`},
+				{Name: "ExtendedAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "ParentAddress", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "ZdoExtSetParams",
+			Fields: []*Field{
+				{Name: "UseMulticast", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoNwkAddrOfInterestReq",
+			Fields: []*Field{
+				{Name: "DestAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "NwkAddrOfInterest", Type: "string", Tag: `hex:"2"`},
+				{Name: "Cmd", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoNwkAddrRsp",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "IEEEAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "NwkAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "StartIndex", Type: "uint8"},
+				{Name: "AssocDevList", Type: "[]string", Tag: `size:"1" hex:"2"`},
+			},
+		},
+		{
+			Name: "ZdoIEEEAddrRsp",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "IEEEAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "NwkAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "StartIndex", Type: "uint8"},
+				{Name: "AssocDevList", Type: "[]string", Tag: `size:"1" hex:"2"`},
+			},
+		},
+		{
+			Name: "ZdoNodeDescRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "NWKAddrOfInterest", Type: "string", Tag: `hex:"2"`},
+				{Name: "LogicalType", Type: "LogicalType", Tag: `bits:"0b00000011" bitmask:"start"`},
+				{Name: "ComplexDescriptorAvailable", Type: "uint8", Tag: `bits:"0b00001000"`},
+				{Name: "UserDescriptorAvailable", Type: "uint8", Tag: `bits:"0b00010000"  bitmask:"end"`},
+				{Name: "APSFlags", Type: "uint8", Tag: `bits:"0b00011111" bitmask:"start"`},
+				{Name: "FrequencyBand", Type: "uint8", Tag: `bits:"0b11100000" bitmask:"end"`},
+				{Name: "MacCapabilitiesFlags", Type: "*CapInfo"},
+				{Name: "ManufacturerCode", Type: "uint16"},
+				{Name: "MaxBufferSize", Type: "uint8"},
+				{Name: "MaxInTransferSize", Type: "uint16"},
+				{Name: "ServerMask", Type: "*ServerMask"},
+				{Name: "MaxOutTransferSize", Type: "uint16"},
+				{Name: "DescriptorCapabilities", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoPowerDescRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "NWKAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "CurrentPowerMode", Type: "uint8", Tag: `bits:"0b00001111" bitmask:"start"`},
+				{Name: "AvailablePowerSources", Type: "uint8", Tag: `bits:"0b11110000"  bitmask:"end"`},
+				{Name: "CurrentPowerSource", Type: "uint8", Tag: `bits:"0b00001111" bitmask:"start"`},
+				{Name: "CurrentPowerSourceLevel", Type: "uint8", Tag: `bits:"0b11110000"  bitmask:"end"`},
+			},
+		},
+		{
+			Name: "ZdoSimpleDescRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "NWKAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Len", Type: "uint8"},
+				{Name: "Endpoint", Type: "uint8"},
+				{Name: "ProfileID", Type: "uint16"},
+				{Name: "DeviceID", Type: "uint16"},
+				{Name: "DeviceVersion", Type: "uint8"},
+				{Name: "InClusterList", Type: "[]uint16", Tag: `size:"1"`},
+				{Name: "OutClusterList", Type: "[]uint16", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoActiveEpRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "NWKAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "ActiveEPList", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoMatchDescRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "NWKAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "MatchList", Type: "[]uint8", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoComplexDescRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "NWKAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "ComplexDescriptor", Type: "string", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoUserDescRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "NWKAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "UserDescriptor", Type: "string", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoUserDescConf",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "NWKAddr", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "ZdoServerDiscRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "ServerMask", Type: "*ServerMask"},
+			},
+		},
+		{
+			Name: "ZdoEndDeviceBindRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "ZdoBindRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "ZdoUnbindRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "Network",
+			Fields: []*Field{
+				{Name: "PanID", Type: "uint16", Tag: `bound:"8"`},
+				{Name: "LogicalChannel", Type: "uint8"},
+				{Name: "StackProfile", Type: "uint8", Tag: `bits:"0b00001111" bitmask:"start"`},
+				{Name: "ZigbeeVersion", Type: "uint8", Tag: `bits:"0b11110000" bitmask:"end"`},
+				{Name: "BeaconOrder", Type: "uint8", Tag: `bits:"0b00001111" bitmask:"start"`},
+				{Name: "SuperFrameOrder", Type: "uint8", Tag: `bits:"0b11110000" bitmask:"end"`},
+				{Name: "PermitJoin", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoMgmtNwkDiscRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "NetworkCount", Type: "uint8"},
+				{Name: "StartIndex", Type: "uint8"},
+				{Name: "NetworkList", Type: "[]*Network", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "NeighborLqi",
+			Fields: []*Field{
+				{Name: "ExtendedPanID", Type: "uint64"},
+				{Name: "ExtendedAddress", Type: "string", Tag: `hex:"8"`},
+				{Name: "NetworkAddress", Type: "string", Tag: `hex:"2"`},
+				{Name: "DeviceType", Type: "LqiDeviceType", Tag: `bits:"0b00000011" bitmask:"start"`},
+				{Name: "RxOnWhenIdle", Type: "uint8", Tag: `bits:"0b00001100"`},
+				{Name: "Relationship", Type: "uint8", Tag: `bits:"0b00110000" bitmask:"end"`},
+				{Name: "PermitJoining", Type: "uint8"},
+				{Name: "Depth", Type: "uint8"},
+				{Name: "LQI", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoMgmtLqiRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "NeighborTableEntries", Type: "uint8"},
+				{Name: "StartIndex", Type: "uint8"},
+				{Name: "NeighborLqiList", Type: "[]*NeighborLqi", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "Route",
+			Fields: []*Field{
+				{Name: "DestinationAddress", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "RouteStatus"},
+				{Name: "NextHop", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "ZdoMgmtRtgRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "RoutingTableEntries", Type: "uint8"},
+				{Name: "StartIndex", Type: "uint8"},
+				{Name: "RoutingTable", Type: "[]*Route", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "Addr",
+			Fields: []*Field{
+				{Name: "AddrMode", Type: "AddrMode"},
+				{Name: "ShortAddr", Type: "string", Tag: `hex:"2" cond:"uint:AddrMode!=3"`},
+				{Name: "ExtendedAddr", Type: "string", Tag: `hex:"8" cond:"uint:AddrMode==3"`},
+				{Name: "DstEndpoint", Type: "uint8", Tag: `cond:"uint:AddrMode==3"`},
+			},
+		},
+		{
+			Name: "Binding",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "SrcEndpoint", Type: "uint8"},
+				{Name: "ClusterID", Type: "uint16"},
+				{Name: "DstAddr", Type: "*Addr"},
+			},
+		},
+		{
+			Name: "ZdoMgmtBindRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "BindTableEntries", Type: "uint8"},
+				{Name: "StartIndex", Type: "uint8"},
+				{Name: "BindTable", Type: "[]*Binding", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoMgmtLeaveRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "ZdoMgmtDirectJoinRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "ZdoMgmtPermitJoinRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "ZdoMgmtNwkUpdateNotify",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+				{Name: "ScannedChannels", Type: "*Channels"},
+				{Name: "TotalTransmissions", Type: "uint16"},
+				{Name: "TransmissionFailures", Type: "uint16"},
+				{Name: "EnergyValues", Type: "[]uint8", Tag: `size:"1"`, Comment: "One value per scanned channel in ascending order"},
+			},
+			Examples: []*Example{
+				{Value: `&ZdoMgmtNwkUpdateNotify{SrcAddr: "0x1234", Status: StatusSuccess, ScannedChannels: &Channels{Channel15: 1, Channel26: 1}, TotalTransmissions: 100, TransmissionFailures: 2, EnergyValues: []uint8{0x20, 0xc0}}`, Payload: "34120000800004640002000220c0"},
+			},
+		},
+		{
+			Name: "ZdoStateChangeInd",
+			Fields: []*Field{
+				{Name: "State", Type: "DeviceState"},
+			},
+			Examples: []*Example{
+				{Value: `&ZdoStateChangeInd{State: DeviceStateStartedAsZigBeeCoordinator}`, Payload: "09"},
+			},
+		},
+		{
+			Name: "ZdoEndDeviceAnnceInd",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "NwkAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "IEEEAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "Capabilities", Type: "*CapInfo"},
+			},
+			Examples: []*Example{
+				{Value: `&ZdoEndDeviceAnnceInd{SrcAddr: "0x1234", NwkAddr: "0x1234", IEEEAddr: "0x00124b0001020304", Capabilities: &CapInfo{MainPowered: 1, ReceiverOnWhenIdle: 1, AllocAddr: 1}}`, Payload: "3412341204030201004b12008c"},
+			},
+		},
+		{
+			Name: "ZdoMatchDescRpsSent",
+			Fields: []*Field{
+				{Name: "NwkAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "InClusterList", Type: "[]uint16", Tag: `size:"1"`},
+				{Name: "OutClusterList", Type: "[]uint16", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoStatusErrorRsp",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "ZdoSrcRtgInd",
+			Fields: []*Field{
+				{Name: "DstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "RelayList", Type: "[]string", Tag: `size:"1" hex:"2"`},
+			},
+		},
+		{
+			Name: "Beacon",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "PanID", Type: "uint16"},
+				{Name: "LogicalChannel", Type: "uint8"},
+				{Name: "PermitJoining", Type: "uint8"},
+				{Name: "RouterCapacity", Type: "uint8"},
+				{Name: "DeviceCapacity", Type: "uint8"},
+				{Name: "ProtocolVersion", Type: "uint8"},
+				{Name: "StackProfile", Type: "uint8"},
+				{Name: "LQI", Type: "uint8"},
+				{Name: "Depth", Type: "uint8"},
+				{Name: "UpdateID", Type: "uint8"},
+				{Name: "ExtendedPanID", Type: "uint64"},
+			},
+		},
+		{
+			Name: "ZdoBeaconNotifyInd",
+			Fields: []*Field{
+				{Name: "BeaconList", Type: "[]*Beacon", Tag: `size:"1"`},
+			},
+		},
+		{
+			Name: "ZdoJoinCnf",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+				{Name: "DeviceAddress", Type: "string", Tag: `hex:"2"`},
+				{Name: "ParentAddress", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "ZdoNwkDiscoveryCnf",
+			Fields: []*Field{
+				{Name: "Status", Type: "Status"},
+			},
+		},
+		{
+			Name: "ZdoLeaveInd",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "ExtAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "Request", Type: "uint8"},
+				{Name: "Remove", Type: "uint8"},
+				{Name: "Rejoin", Type: "uint8"},
+			},
+		},
+		{
+			Name: "ZdoMsgCbIncoming",
+			Fields: []*Field{
+				{Name: "SrcAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "WasBroadcast", Type: "uint8"},
+				{Name: "ClusterID", Type: "uint16"},
+				{Name: "SecurityUse", Type: "uint8"},
+				{Name: "SeqNum", Type: "uint8"},
+				{Name: "MacDstAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "Data", Type: "[]uint8"},
+			},
+		},
+		{
+			Name: "ZdoTcDevInd",
+			Fields: []*Field{
+				{Name: "SrcNwkAddr", Type: "string", Tag: `hex:"2"`},
+				{Name: "SrcIEEEAddr", Type: "string", Tag: `hex:"8"`},
+				{Name: "ParentNwkAddr", Type: "string", Tag: `hex:"2"`},
+			},
+		},
+		{
+			Name: "ZdoPermitJoinInd",
+			Fields: []*Field{
+				{Name: "PermitJoinDuration", Type: "uint8"},
+			},
+		},
+	},
+	Commands: []*Command{
+		{
+			Name: "ZdoNwkAddrReq",
+			Doc: `ZdoNwkAddrReq will request the device to send a “Network Address Request”. This message sends a
+broadcast message looking for a 16 bit address with a known 64 bit IEEE address. You must
+subscribe to “ZDO Network Address Response” to receive the response to this message. Check
+section 3.0.1.7 for more details on callback subscription. The response message listed below only
+indicates whether or not the message was received properly.`,
+			Type:     SREQ,
+			ID:       0x00,
+			Request:  "ZdoNwkAddrReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "ZdoIeeeAddrReq",
+			Doc: `ZdoIeeeAddrReq will request a device’s IEEE 64-bit address. You must subscribe to “ZDO IEEE
+Address Response” to receive the data response to this message. The response message listed
+below only indicates whether or not the message was received properly.`,
+			Type:     SREQ,
+			ID:       0x01,
+			Request:  "ZdoIeeeAddrReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "ZdoNodeDescReq",
+			Doc: `ZdoNodeDescReq is generated to inquire about the Node Descriptor information of the destination
+device.`,
+			Type:     SREQ,
+			ID:       0x02,
+			Request:  "ZdoNodeDescReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "ZdoPowerDescReq",
+			Doc: `ZdoPowerDescReq is generated to inquire about the Power Descriptor information of the destination
+device.`,
+			Type:     SREQ,
+			ID:       0x03,
+			Request:  "ZdoPowerDescReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "ZdoSimpleDescReq",
+			Doc: `ZdoSimpleDescReq is generated to inquire as to the Simple Descriptor of the destination device’s
+Endpoint.`,
+			Type:     SREQ,
+			ID:       0x04,
+			Request:  "ZdoSimpleDescReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoActiveEpReq",
+			Doc:      "ZdoActiveEpReq is generated to request a list of active endpoint from the destination device",
+			Type:     SREQ,
+			ID:       0x05,
+			Request:  "ZdoActiveEpReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoMatchDescReq",
+			Doc:      "ZdoMatchDescReq is generated to request the device match descriptor",
+			Type:     SREQ,
+			ID:       0x06,
+			Request:  "ZdoMatchDescReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoComplexDescReq",
+			Doc:      "ZdoComplexDescReq is generated to request for the destination device’s complex descriptor.",
+			Type:     SREQ,
+			ID:       0x07,
+			Request:  "ZdoComplexDescReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoUserDescReq",
+			Doc:      "ZdoUserDescReq is generated to request for the destination device’s user descriptor",
+			Type:     SREQ,
+			ID:       0x08,
+			Request:  "ZdoUserDescReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "ZdoEndDeviceAnnce",
+			Doc: `ZdoEndDeviceAnnce will cause the device to issue an “End device announce” broadcast packet to the
+network. This is typically used by an end-device to announce itself to the network.`,
+			Type:     SREQ,
+			ID:       0x0A,
+			Request:  "ZdoEndDeviceAnnce",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoUserDescSet",
+			Doc:      "ZdoUserDescSet is generated to write a User Descriptor value to the targeted device.",
+			Type:     SREQ,
+			ID:       0x0B,
+			Request:  "ZdoUserDescSet",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "ZdoServerDiscReq",
+			Doc: `ZdoServerDiscReq is used for local device to discover the location of a particular system server or
+servers as indicated by the ServerMask parameter. The destination addressing on this request is
+‘broadcast to all RxOnWhenIdle devices’.`,
+			Type:     SREQ,
+			ID:       0x0C,
+			Request:  "ZdoServerDiscReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoEndDeviceBindReq",
+			Doc:      "ZdoEndDeviceBindReq is generated to request an End Device Bind with the destination device.",
+			Type:     SREQ,
+			ID:       0x20,
+			Request:  "ZdoEndDeviceBindReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoBindReq",
+			Doc:      "ZdoBindReq is generated to request an End Device Bind with the destination device.",
+			Type:     SREQ,
+			ID:       0x21,
+			Request:  "ZdoBindUnbindReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoUnbindReq",
+			Doc:      "ZdoUnbindReq is generated to request a un-bind.",
+			Type:     SREQ,
+			ID:       0x22,
+			Request:  "ZdoBindUnbindReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoMgmtNwkDiskReq",
+			Doc:      "ZdoMgmtNwkDiskReq is generated to request the destination device to perform a network discovery",
+			Type:     SREQ,
+			ID:       0x30,
+			Request:  "ZdoMgmtNwkDiskReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "ZdoMgmtLqiReq",
+			Doc: `ZdoMgmtLqiReq is generated to request the destination device to perform a LQI query of other
+devices in the network.`,
+			Type:     SREQ,
+			ID:       0x31,
+			Request:  "ZdoMgmtLqiReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoMgmtRtgReq",
+			Doc:      "ZdoMgmtRtgReq is generated to request the Routing Table of the destination device",
+			Type:     SREQ,
+			ID:       0x32,
+			Request:  "ZdoMgmtRtgReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoMgmtBindReq",
+			Doc:      "ZdoMgmtBindReq is generated to request the Binding Table of the destination device.",
+			Type:     SREQ,
+			ID:       0x33,
+			Request:  "ZdoMgmtBindReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoMgmtLeaveReq",
+			Doc:      "ZdoMgmtLeaveReq is generated to request a Management Leave Request for the target device",
+			Type:     SREQ,
+			ID:       0x34,
+			Request:  "ZdoMgmtLeaveReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "ZdoMgmtDirectJoinReq",
+			Doc: `ZdoMgmtDirectJoinReq is generated to request the Management Direct Join Request of a designated
+device.`,
+			Type:     SREQ,
+			ID:       0x35,
+			Request:  "ZdoMgmtDirectJoinReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoMgmtPermitJoinReq",
+			Doc:      "ZdoMgmtPermitJoinReq is generated to set the Permit Join for the destination device.",
+			Type:     SREQ,
+			ID:       0x36,
+			Request:  "ZdoMgmtPermitJoinReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "ZdoMgmtNwkUpdateReq",
+			Doc: `ZdoMgmtNwkUpdateReq is provided to allow updating of network configuration parameters or to request
+information from devices on network conditions in the local operating environment.`,
+			Type:     SREQ,
+			ID:       0x37,
+			Request:  "ZdoMgmtNwkUpdateReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "ZdoMsgCbRegister",
+			Doc: `ZdoMsgCbRegister registers for a ZDO callback (see reference [3], “6. ZDO Message Requests” for
+example usage).`,
+			Type:     SREQ,
+			ID:       0x3E,
+			Request:  "ZdoMsgCbRegister",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "ZdoMsgCbRemove",
+			Doc: `ZdoMsgCbRemove removes a registration for a ZDO callback (see reference [3], “6. ZDO Message
+Requests” for example usage).`,
+			Type:     SREQ,
+			ID:       0x3F,
+			Request:  "ZdoMsgCbRemove",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoStartupFromApp",
+			Doc:      "ZdoStartupFromApp starts the device in the network.",
+			Type:     SREQ,
+			ID:       0x40,
+			Request:  "ZdoStartupFromApp",
+			Response: "ZdoStartupFromAppResponse",
+		},
+		{
+			Name:     "ZdoSetLinkKey",
+			Doc:      "ZdoSetLinkKey sets the application link key of a given device.",
+			Type:     SREQ,
+			ID:       0x23,
+			Request:  "ZdoSetLinkKey",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoRemoveLinkKey",
+			Doc:      "ZdoRemoveLinkKey removes the application link key of a given device.",
+			Type:     SREQ,
+			ID:       0x24,
+			Request:  "ZdoRemoveLinkKey",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoGetLinkKey",
+			Doc:      "ZdoGetLinkKey retrieves the application link key of a given device.",
+			Type:     SREQ,
+			ID:       0x25,
+			Request:  "ZdoGetLinkKey",
+			Response: "ZdoGetLinkKeyResponse",
+		},
+		{
+			Name: "ZdoNwkDiscoveryReq",
+			Doc: `ZdoNwkDiscoveryReq is used to initiate a network discovery (active scan).
+Strange response SecOldFrmCount(0xa1)`,
+			Type:     SREQ,
+			ID:       0x26,
+			Request:  "ZdoNwkDiscoveryReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoJoinReq",
+			Doc:      "ZdoJoinReq is used to request the device to join itself to a parent device on a network.",
+			Type:     SREQ,
+			ID:       0x27,
+			Request:  "ZdoJoinReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoSetRejoinParameters",
+			Doc:      "ZdoSetRejoinParameters is used to set rejoin backoff duration and rejoin scan duration for an end device",
+			Type:     SREQ,
+			ID:       0xCC,
+			Request:  "ZdoSetRejoinParameters",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoSecAddLinkKey",
+			Doc:      "ZdoSecAddLinkKey handles the ZDO security add link key extension message.",
+			Type:     SREQ,
+			ID:       0x42,
+			Request:  "ZdoSecAddLinkKey",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoSecEntryLookupExt",
+			Doc:      "ZdoSecEntryLookupExt handles the ZDO security entry lookup extended extension message",
+			Type:     SREQ,
+			ID:       0x43,
+			Request:  "ZdoSecEntryLookupExt",
+			Response: "ZdoSecEntryLookupExtResponse",
+		},
+		{
+			Name:     "ZdoSecDeviceRemove",
+			Doc:      "ZdoSecDeviceRemove handles the ZDO security remove device extended extension message.",
+			Type:     SREQ,
+			ID:       0x44,
+			Request:  "ZdoSecDeviceRemove",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoExtRouteDisc",
+			Doc:      "ZdoExtRouteDisc handles the ZDO route discovery extension message.",
+			Type:     SREQ,
+			ID:       0x45,
+			Request:  "ZdoExtRouteDisc",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoExtRouteCheck",
+			Doc:      "ZdoExtRouteCheck handles the ZDO route check extension message.",
+			Type:     SREQ,
+			ID:       0x46,
+			Request:  "ZdoExtRouteCheck",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoExtRemoveGroup",
+			Doc:      "ZdoExtRemoveGroup handles the ZDO extended remove group extension message.",
+			Type:     SREQ,
+			ID:       0x47,
+			Request:  "ZdoExtRemoveGroup",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoExtRemoveAllGroup",
+			Doc:      "ZdoExtRemoveAllGroup handles the ZDO extended remove all group extension message.",
+			Type:     SREQ,
+			ID:       0x48,
+			Request:  "ZdoExtRemoveAllGroup",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoExtFindAllGroupsEndpoint",
+			Doc:      "ZdoExtFindAllGroupsEndpoint handles the ZDO extension find all groups for endpoint message",
+			Type:     SREQ,
+			ID:       0x49,
+			Request:  "ZdoExtFindAllGroupsEndpoint",
+			Response: "ZdoExtFindAllGroupsEndpointResponse",
+		},
+		{
+			Name:     "ZdoExtFindGroup",
+			Doc:      "ZdoExtFindGroup handles the ZDO extension find all groups for endpoint message",
+			Type:     SREQ,
+			ID:       0x4A,
+			Request:  "ZdoExtFindGroup",
+			Response: "ZdoExtFindGroupResponse",
+		},
+		{
+			Name:     "ZdoExtAddGroup",
+			Doc:      "ZdoExtAddGroup handles the ZDO extension add group message.",
+			Type:     SREQ,
+			ID:       0x4B,
+			Request:  "ZdoExtAddGroup",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoExtCountAllGroups",
+			Doc:      "ZdoExtCountAllGroups handles the ZDO extension count all groups message.",
+			Type:     SREQ,
+			ID:       0x4C,
+			Response: "ZdoExtCountAllGroupsResponse",
+		},
+		{
+			Name:     "ZdoExtRxIdle",
+			Doc:      "ZdoExtRxIdle handles the ZDO extension Get/Set RxOnIdle to ZMac message",
+			Type:     SREQ,
+			ID:       0x4D,
+			Request:  "ZdoExtRxIdle",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoExtUpdateNwkKey",
+			Doc:      "ZdoExtUpdateNwkKey handles the ZDO security update network key extension message.",
+			Type:     SREQ,
+			ID:       0x4E,
+			Request:  "ZdoExtUpdateNwkKey",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoExtSwitchNwkKey",
+			Doc:      "ZdoExtSwitchNwkKey handles the ZDO security switch network key extension message.",
+			Type:     SREQ,
+			ID:       0x4F,
+			Request:  "ZdoExtSwitchNwkKey",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoExtNwkInfo",
+			Doc:      "ZdoExtNwkInfo handles the ZDO extension network message.",
+			Type:     SREQ,
+			ID:       0x50,
+			Response: "ZdoExtNwkInfoResponse",
+		},
+		{
+			Name:     "ZdoExtSeqApsRemoveReq",
+			Doc:      "ZdoExtSeqApsRemoveReq handles the ZDO extension Security Manager APS Remove Request message.",
+			Type:     SREQ,
+			ID:       0x51,
+			Request:  "ZdoExtSeqApsRemoveReq",
+			Response: "StatusResponse",
+		},
+		{
+			Name: "ZdoForceConcentratorChange",
+			Doc: `ZdoForceConcentratorChange forces a network concentrator change by resetting zgConcentratorEnable and
+zgConcentratorDiscoveryTime from NV and set nwk event.`,
+			Type: AREQ,
+			ID:   0x52,
+		},
+		{
+			Name:     "ZdoExtSetParams",
+			Doc:      "ZdoExtSeqApsRemoveReq set parameters not settable through NV.",
+			Type:     SREQ,
+			ID:       0x53,
+			Request:  "ZdoExtSetParams",
+			Response: "StatusResponse",
+		},
+		{
+			Name:     "ZdoNwkAddrOfInterestReq",
+			Doc:      "ZdoNwkAddrOfInterestReq handles ZDO network address of interest request.",
+			Type:     SREQ,
+			ID:       0x29,
+			Request:  "ZdoNwkAddrOfInterestReq",
+			Response: "StatusResponse",
+		},
+	},
+	Async: []*Async{
+		{ID: 0x80, Model: "ZdoNwkAddrRsp"},
+		{ID: 0x81, Model: "ZdoIEEEAddrRsp"},
+		{ID: 0x82, Model: "ZdoNodeDescRsp"},
+		{ID: 0x83, Model: "ZdoPowerDescRsp"},
+		{ID: 0x84, Model: "ZdoSimpleDescRsp"},
+		{ID: 0x85, Model: "ZdoActiveEpRsp"},
+		{ID: 0x86, Model: "ZdoMatchDescRsp"},
+		{ID: 0x87, Model: "ZdoComplexDescRsp"},
+		{ID: 0x88, Model: "ZdoUserDescRsp"},
+		{ID: 0x89, Model: "ZdoUserDescConf"},
+		{ID: 0x8A, Model: "ZdoServerDiscRsp"},
+		{ID: 0xA0, Model: "ZdoEndDeviceBindRsp"},
+		{ID: 0xA1, Model: "ZdoBindRsp"},
+		{ID: 0xA2, Model: "ZdoUnbindRsp"},
+		{ID: 0xB0, Model: "ZdoMgmtNwkDiscRsp"},
+		{ID: 0xB1, Model: "ZdoMgmtLqiRsp"},
+		{ID: 0xB2, Model: "ZdoMgmtRtgRsp"},
+		{ID: 0xB3, Model: "ZdoMgmtBindRsp"},
+		{ID: 0xB4, Model: "ZdoMgmtLeaveRsp"},
+		{ID: 0xB5, Model: "ZdoMgmtDirectJoinRsp"},
+		{ID: 0xB6, Model: "ZdoMgmtPermitJoinRsp"},
+		{ID: 0xB8, Model: "ZdoMgmtNwkUpdateNotify"},
+		{ID: 0xC0, Model: "ZdoStateChangeInd"},
+		{ID: 0xC1, Model: "ZdoEndDeviceAnnceInd"},
+		{ID: 0xC2, Model: "ZdoMatchDescRpsSent"},
+		{ID: 0xC3, Model: "ZdoStatusErrorRsp"},
+		{ID: 0xC4, Model: "ZdoSrcRtgInd"},
+		{ID: 0xC5, Model: "ZdoBeaconNotifyInd"},
+		{ID: 0xC6, Model: "ZdoJoinCnf"},
+		{ID: 0xC7, Model: "ZdoNwkDiscoveryCnf"},
+		{ID: 0xC9, Model: "ZdoLeaveInd"},
+		{ID: 0xFF, Model: "ZdoMsgCbIncoming"},
+		{ID: 0xCA, Model: "ZdoTcDevInd"},
+		{ID: 0xCB, Model: "ZdoPermitJoinInd"},
+	},
+}
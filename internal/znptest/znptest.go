@@ -0,0 +1,149 @@
+//Package znptest is a fake adapter for the tests. It answers SysPing and SysVersion like a Z-Stack 3.x.0 adapter,
+//the tests register handlers for the other commands they need.
+//
+//	a := znptest.New()
+//	a.Handle(unp.S_ZDO, 0x36, func(r *znptest.Request) []byte {
+//		r.Decode(req)
+//		return []byte{0x00}
+//	})
+//	z := znp.New(a.Unp())
+//	z.Start()
+//
+//It doesn't import znp, so that the tests of the root package can use it too.
+package znptest
+
+import (
+	"net"
+	"sync"
+
+	"github.com/dyrkin/bin"
+	"github.com/dyrkin/unp-go"
+)
+
+//Version is the SysVersion payload of a Z-Stack 3.x.0 2.7.1 adapter built on 20210708
+var Version = []byte{0x02, 0x01, 0x02, 0x07, 0x01, 0x14, 0x64, 0x34, 0x01}
+
+//Capabilities is the SysPing payload of an adapter with the SYS, AF, ZDO, SAPI, UTIL and APP subsystems
+var Capabilities = []byte{0x79, 0x01}
+
+//Handler answers a request. The returned payload is sent as SRSP, it defaults to a successful status when nil.
+//Handlers are called for AREQs too, their result is ignored.
+type Handler func(r *Request) []byte
+
+//Request is a frame received from the host
+type Request struct {
+	*unp.Frame
+	after []*unp.Frame
+}
+
+//Decode decodes the payload into v
+func (r *Request) Decode(v interface{}) {
+	bin.Decode(r.Payload, v)
+}
+
+//Reply queues an async command which is sent after the SRSP of the request. v is a payload or a model.
+func (r *Request) Reply(subsystem unp.Subsystem, command byte, v interface{}) {
+	r.after = append(r.after, async(subsystem, command, v))
+}
+
+type key struct {
+	subsystem unp.Subsystem
+	command   byte
+}
+
+//Adapter is the fake adapter. Handlers are called one after the other from the goroutine reading the frames.
+type Adapter struct {
+	mu       sync.Mutex
+	handlers map[key]Handler
+	received chan *unp.Frame
+	u        *unp.Unp
+	writeMu  sync.Mutex
+}
+
+//New returns an adapter answering SysPing and SysVersion
+func New() *Adapter {
+	a := &Adapter{handlers: map[key]Handler{}, received: make(chan *unp.Frame, 100)}
+	a.Respond(unp.S_SYS, 0x01, Capabilities)
+	a.Respond(unp.S_SYS, 0x02, Version)
+	return a
+}
+
+//Handle registers the handler of the command, replacing the previous one
+func (a *Adapter) Handle(subsystem unp.Subsystem, command byte, handler Handler) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.handlers[key{subsystem, command}] = handler
+}
+
+//Respond answers the command with the payload
+func (a *Adapter) Respond(subsystem unp.Subsystem, command byte, payload []byte) {
+	a.Handle(subsystem, command, func(*Request) []byte { return payload })
+}
+
+//Received returns the channel of the frames received from the host. Frames are dropped when it is full.
+func (a *Adapter) Received() chan *unp.Frame {
+	return a.received
+}
+
+//Conn starts serving and returns the connection of the host
+func (a *Adapter) Conn() net.Conn {
+	hostSide, adapterSide := net.Pipe()
+	a.u = unp.New(1, adapterSide)
+	go a.serve()
+	return hostSide
+}
+
+//Unp starts serving and returns the unp of the host, to be passed to znp.New
+func (a *Adapter) Unp() *unp.Unp {
+	return unp.New(1, a.Conn())
+}
+
+//Send writes an async command to the host. v is a payload or a model.
+func (a *Adapter) Send(subsystem unp.Subsystem, command byte, v interface{}) {
+	a.write(async(subsystem, command, v))
+}
+
+func (a *Adapter) serve() {
+	for {
+		frame, err := a.u.ReadFrame()
+		if err != nil {
+			return
+		}
+		select {
+		case a.received <- frame:
+		default:
+		}
+		a.mu.Lock()
+		handler := a.handlers[key{frame.Subsystem, frame.Command}]
+		a.mu.Unlock()
+		r := &Request{Frame: frame}
+		var payload []byte
+		if handler != nil {
+			payload = handler(r)
+		}
+		if payload == nil {
+			payload = []byte{0x00}
+		}
+		if frame.CommandType == unp.C_SREQ {
+			a.write(&unp.Frame{CommandType: unp.C_SRSP, Subsystem: frame.Subsystem, Command: frame.Command,
+				Payload: payload})
+		}
+		for _, after := range r.after {
+			a.write(after)
+		}
+	}
+}
+
+func (a *Adapter) write(frame *unp.Frame) {
+	a.writeMu.Lock()
+	defer a.writeMu.Unlock()
+	a.u.WriteFrame(frame)
+}
+
+func async(subsystem unp.Subsystem, command byte, v interface{}) *unp.Frame {
+	payload, ok := v.([]byte)
+	if !ok {
+		payload = bin.Encode(v)
+	}
+	return &unp.Frame{CommandType: unp.C_AREQ, Subsystem: subsystem, Command: command, Payload: payload}
+}
@@ -0,0 +1,164 @@
+//Package joinpolicy removes the devices a policy doesn't allow in the network. It runs when the trust center
+//reports a joining device and when a device announces itself. Every report of the trust center is checked, the
+//announcement following it is part of the same join and isn't checked again.
+//
+//The removal happens after the fact: when ZdoTcDevInd arrives, the trust center has already handed the network key
+//to the joining device. A denied device is asked to leave, but it knows the key until the key is rotated. Use
+//installcode.Require to keep devices without an install code out of the network.
+//
+//	e := joinpolicy.New(z, joinpolicy.All(joinpolicy.DenyList("0x00124b0001020304"), joinpolicy.Func(check)))
+//	for event := range e.Events() {
+//		log.Println(event)
+//	}
+package joinpolicy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dyrkin/znp-go"
+)
+
+//Event is the audit record of a decision
+type Event struct {
+	Time    time.Time
+	Device  Device
+	Source  string //ZdoTcDevInd or ZdoEndDeviceAnnceInd
+	Allowed bool
+	Reason  string
+	//Err is set when a denied device couldn't be removed
+	Err error
+}
+
+func (e Event) String() string {
+	decision := "allowed"
+	if !e.Allowed {
+		decision = "denied"
+	}
+	s := fmt.Sprintf("%s %s (%s) %s by %s: %s", e.Time.Format(time.RFC3339), e.Device.IEEEAddr, e.Device.NwkAddr,
+		decision, e.Source, e.Reason)
+	if e.Err != nil {
+		s += fmt.Sprintf(", removal failed: %s", e.Err)
+	}
+	return s
+}
+
+//Enforcer applies the policy to the joining devices
+type Enforcer struct {
+	z            *znp.Znp
+	policy       Policy
+	subscription *znp.Subscription
+	events       chan Event
+
+	window time.Duration     //period the announcement of a join is expected in
+	joined map[string]joined //joins reported by the trust center by IEEE address
+}
+
+//joined is a join reported by ZdoTcDevInd, whose announcement is still expected
+type joined struct {
+	nwkAddr string
+	time    time.Time
+}
+
+//New returns an enforcer watching the adapter. Call Stop when it is no longer needed.
+func New(z *znp.Znp, policy Policy) *Enforcer {
+	e := &Enforcer{
+		z:            z,
+		policy:       policy,
+		subscription: z.Subscribe(),
+		events:       make(chan Event, 100),
+		window:       time.Minute,
+		joined:       map[string]joined{},
+	}
+	go e.watch()
+	return e
+}
+
+//Events returns the channel the audit events are delivered to. Events are dropped when nobody reads the channel.
+//The channel is closed after Stop.
+func (e *Enforcer) Events() chan Event {
+	return e.events
+}
+
+//Stop stops watching the adapter
+func (e *Enforcer) Stop() {
+	e.subscription.Unsubscribe()
+}
+
+func (e *Enforcer) watch() {
+	defer close(e.events)
+	for async := range e.subscription.Events() {
+		switch async := async.(type) {
+		case *znp.ZdoTcDevInd:
+			e.enforce("ZdoTcDevInd", Device{IEEEAddr: async.SrcIEEEAddr, NwkAddr: async.SrcNwkAddr,
+				ParentAddr: async.ParentNwkAddr})
+		case *znp.ZdoEndDeviceAnnceInd:
+			e.enforce("ZdoEndDeviceAnnceInd", Device{IEEEAddr: async.IEEEAddr, NwkAddr: async.NwkAddr})
+		}
+	}
+}
+
+//enforce applies the policy to the device. A joining device is reported by ZdoTcDevInd and announces itself right
+//after, it is removed and audited only once per join. A device rejoining after it was removed is reported again by
+//the trust center and is checked again.
+func (e *Enforcer) enforce(source string, device Device) {
+	now := time.Now()
+	if e.announced(source, device, now) {
+		return
+	}
+	event := Event{Time: now, Device: device, Source: source}
+	event.Allowed, event.Reason = e.policy.Authorize(device)
+	if !event.Allowed {
+		event.Err = e.remove(device)
+	}
+	select {
+	case e.events <- event:
+	default:
+	}
+}
+
+//announced reports whether the indication is the announcement of a join the trust center reported within the
+//window. The reports of the trust center are recorded, each of them covers a single announcement.
+func (e *Enforcer) announced(source string, device Device, now time.Time) bool {
+	for addr, join := range e.joined {
+		if now.Sub(join.time) >= e.window {
+			delete(e.joined, addr)
+		}
+	}
+	ieeeAddr := strings.ToLower(device.IEEEAddr)
+	if source == "ZdoTcDevInd" {
+		e.joined[ieeeAddr] = joined{nwkAddr: strings.ToLower(device.NwkAddr), time: now}
+		return false
+	}
+	join, ok := e.joined[ieeeAddr]
+	if !ok || join.nwkAddr != strings.ToLower(device.NwkAddr) {
+		return false
+	}
+	delete(e.joined, ieeeAddr)
+	return true
+}
+
+//remove asks the parent of the device, or the device itself when the parent is unknown, to make it leave without
+//rejoining and removes it from the tables of the trust center
+func (e *Enforcer) remove(device Device) error {
+	dstAddr := device.ParentAddr
+	if dstAddr == "" {
+		dstAddr = device.NwkAddr
+	}
+	rsp, err := e.z.ZdoMgmtLeaveReq(dstAddr, device.IEEEAddr, &znp.RemoveChildrenRejoin{})
+	if err != nil {
+		return err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return fmt.Errorf("joinpolicy: leave request failed: %s", rsp.Status)
+	}
+	rsp, err = e.z.ZdoSecDeviceRemove(device.IEEEAddr)
+	if err != nil {
+		return err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return fmt.Errorf("joinpolicy: removal from the trust center failed: %s", rsp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package joinpolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+//fakeAdapter records the leave and removal requests
+type fakeAdapter struct {
+	*znptest.Adapter
+	leaves   []*znp.ZdoMgmtLeaveReq
+	removals []string
+}
+
+func newFakeAdapter() *fakeAdapter {
+	a := &fakeAdapter{Adapter: znptest.New()}
+	a.Handle(unp.S_ZDO, 0x34, func(r *znptest.Request) []byte {
+		req := &znp.ZdoMgmtLeaveReq{}
+		r.Decode(req)
+		a.leaves = append(a.leaves, req)
+		return nil
+	})
+	a.Handle(unp.S_ZDO, 0x44, func(r *znptest.Request) []byte {
+		req := &znp.ZdoSecDeviceRemove{}
+		r.Decode(req)
+		a.removals = append(a.removals, req.ExtendedAddress)
+		return nil
+	})
+	return a
+}
+
+func (s *MySuite) TestPolicies(c *C) {
+	device := Device{IEEEAddr: "0x00124B0001020304"}
+	allowed, _ := AllowList("0x00124b0001020304").Authorize(device)
+	c.Assert(allowed, Equals, true)
+	allowed, reason := All(AllowList("0x00124b0001020304"), DenyList("0x00124b0001020304")).Authorize(device)
+	c.Assert(allowed, Equals, false)
+	c.Assert(reason, Equals, "in the deny list")
+	allowed, _ = (&InstallCodeOnly{}).Authorize(device)
+	c.Assert(allowed, Equals, false)
+}
+
+func (s *MySuite) TestEnforce(c *C) {
+	a := newFakeAdapter()
+	z := znp.New(a.Unp())
+	z.Start()
+	e := New(z, DenyList("0x00124b00deadbeef"))
+	defer e.Stop()
+
+	for _, ind := range []*znp.ZdoTcDevInd{
+		{SrcNwkAddr: "0x1a2b", SrcIEEEAddr: "0x00124b0001020304", ParentNwkAddr: "0x0000"},
+		{SrcNwkAddr: "0x3c4d", SrcIEEEAddr: "0x00124b00deadbeef", ParentNwkAddr: "0x5e6f"},
+	} {
+		a.Send(unp.S_ZDO, 0xCA, ind)
+		//the device announces itself after joining
+		a.Send(unp.S_ZDO, 0xC1, &znp.ZdoEndDeviceAnnceInd{SrcAddr: ind.SrcNwkAddr, NwkAddr: ind.SrcNwkAddr,
+			IEEEAddr: ind.SrcIEEEAddr, Capabilities: &znp.CapInfo{}})
+	}
+	//the enforcer handles the indications in order, the last device is reported right after the first two
+	a.Send(unp.S_ZDO, 0xCA, &znp.ZdoTcDevInd{SrcNwkAddr: "0x7a8b", SrcIEEEAddr: "0x00124b0005060708",
+		ParentNwkAddr: "0x0000"})
+	var events []Event
+	for len(events) < 3 {
+		select {
+		case event := <-e.Events():
+			events = append(events, event)
+		case <-time.After(time.Second):
+			c.Fatal("no event")
+		}
+	}
+	c.Assert(events[2].Device.IEEEAddr, Equals, "0x00124b0005060708")
+	c.Assert(events[0].Allowed, Equals, true)
+	c.Assert(events[1].Allowed, Equals, false)
+	c.Assert(events[1].Err, IsNil)
+	c.Assert(events[1].Device.ParentAddr, Equals, "0x5e6f")
+	c.Assert(a.leaves, DeepEquals, []*znp.ZdoMgmtLeaveReq{{DstAddr: "0x5e6f", DeviceAddr: "0x00124b00deadbeef",
+		RemoveChildrenRejoin: &znp.RemoveChildrenRejoin{}}})
+	c.Assert(a.removals, DeepEquals, []string{"0x00124b00deadbeef"})
+}
+
+func (s *MySuite) TestDeniedDeviceRejoins(c *C) {
+	a := newFakeAdapter()
+	z := znp.New(a.Unp())
+	z.Start()
+	e := New(z, DenyList("0x00124b00deadbeef"))
+	defer e.Stop()
+
+	//the device rejoins right after it was removed
+	for _, nwkAddr := range []string{"0x3c4d", "0x3c4d"} {
+		a.Send(unp.S_ZDO, 0xCA, &znp.ZdoTcDevInd{SrcNwkAddr: nwkAddr, SrcIEEEAddr: "0x00124b00deadbeef",
+			ParentNwkAddr: "0x5e6f"})
+		a.Send(unp.S_ZDO, 0xC1, &znp.ZdoEndDeviceAnnceInd{SrcAddr: nwkAddr, NwkAddr: nwkAddr,
+			IEEEAddr: "0x00124b00deadbeef", Capabilities: &znp.CapInfo{}})
+	}
+	a.Send(unp.S_ZDO, 0xCA, &znp.ZdoTcDevInd{SrcNwkAddr: "0x7a8b", SrcIEEEAddr: "0x00124b0005060708",
+		ParentNwkAddr: "0x0000"})
+	var events []Event
+	for len(events) < 3 {
+		select {
+		case event := <-e.Events():
+			events = append(events, event)
+		case <-time.After(time.Second):
+			c.Fatal("no event")
+		}
+	}
+	c.Assert(events[0].Allowed, Equals, false)
+	c.Assert(events[1].Allowed, Equals, false)
+	c.Assert(events[1].Source, Equals, "ZdoTcDevInd")
+	c.Assert(events[2].Device.IEEEAddr, Equals, "0x00124b0005060708")
+	c.Assert(a.leaves, HasLen, 2)
+	c.Assert(a.removals, DeepEquals, []string{"0x00124b00deadbeef", "0x00124b00deadbeef"})
+}
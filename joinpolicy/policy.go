@@ -0,0 +1,108 @@
+package joinpolicy
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/installcode"
+)
+
+//Device joining the network
+type Device struct {
+	IEEEAddr string
+	NwkAddr  string
+	//ParentAddr is the network address of the parent, it is only known from ZdoTcDevInd
+	ParentAddr string
+}
+
+//Policy decides whether a device may stay in the network. The reason is recorded in the audit events.
+type Policy interface {
+	Authorize(device Device) (allowed bool, reason string)
+}
+
+//Func is a policy implemented by a function
+type Func func(device Device) (allowed bool, reason string)
+
+//Authorize calls the function
+func (f Func) Authorize(device Device) (bool, string) {
+	return f(device)
+}
+
+//AllowList allows the devices with the IEEE addresses only
+func AllowList(ieeeAddrs ...string) Policy {
+	allowed := addrSet(ieeeAddrs)
+	return Func(func(device Device) (bool, string) {
+		if allowed[strings.ToLower(device.IEEEAddr)] {
+			return true, "allow list"
+		}
+		return false, "not in the allow list"
+	})
+}
+
+//DenyList allows all devices but those with the IEEE addresses
+func DenyList(ieeeAddrs ...string) Policy {
+	denied := addrSet(ieeeAddrs)
+	return Func(func(device Device) (bool, string) {
+		if denied[strings.ToLower(device.IEEEAddr)] {
+			return false, "in the deny list"
+		}
+		return true, "not in the deny list"
+	})
+}
+
+//All allows the devices allowed by every policy. The reason is the one of the first policy denying the device.
+func All(policies ...Policy) Policy {
+	return Func(func(device Device) (bool, string) {
+		reason := "no policy"
+		for _, policy := range policies {
+			var allowed bool
+			if allowed, reason = policy.Authorize(device); !allowed {
+				return false, reason
+			}
+		}
+		return true, reason
+	})
+}
+
+//InstallCodeOnly allows the devices an install code was registered for with Register. The zero value allows
+//nothing. Enable installcode.Require too, so that the adapter doesn't hand the network key to other devices.
+type InstallCodeOnly struct {
+	mu         sync.Mutex
+	registered map[string]bool
+}
+
+//Register registers the install code of the device with the adapter and allows the device
+func (p *InstallCodeOnly) Register(z *znp.Znp, ieeeAddr string, ic *installcode.InstallCode) error {
+	if err := installcode.Register(z, ieeeAddr, ic); err != nil {
+		return err
+	}
+	if ieeeAddr == "" {
+		ieeeAddr = ic.IEEEAddr
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.registered == nil {
+		p.registered = map[string]bool{}
+	}
+	p.registered[strings.ToLower(ieeeAddr)] = true
+	return nil
+}
+
+//Authorize allows the devices with a registered install code
+func (p *InstallCodeOnly) Authorize(device Device) (bool, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.registered[strings.ToLower(device.IEEEAddr)] {
+		return true, "install code registered"
+	}
+	return false, "no install code registered"
+}
+
+func addrSet(addrs []string) map[string]bool {
+	set := map[string]bool{}
+	for _, addr := range addrs {
+		set[strings.ToLower(addr)] = true
+	}
+	return set
+}
@@ -0,0 +1,51 @@
+package linkkey
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+const redacted = "[redacted]"
+
+//Key is an APS link key. It is redacted when formatted, logged or marshalled, use Hex to get its value.
+type Key [16]uint8
+
+//ParseKey parses the hex value of a key
+func ParseKey(s string) (Key, error) {
+	var key Key
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return key, fmt.Errorf("linkkey: invalid key: %w", err)
+	}
+	if len(b) != len(key) {
+		return key, fmt.Errorf("linkkey: key is %d bytes long, expected %d", len(b), len(key))
+	}
+	copy(key[:], b)
+	return key, nil
+}
+
+//Hex returns the value of the key
+func (k Key) Hex() string {
+	return hex.EncodeToString(k[:])
+}
+
+func (k Key) String() string {
+	return redacted
+}
+
+//Format redacts the key with every verb, e.g. %x
+func (k Key) Format(f fmt.State, verb rune) {
+	io.WriteString(f, redacted)
+}
+
+//LogValue redacts the key in slog records
+func (k Key) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+//MarshalText redacts the key in JSON, e.g. in the responses of an API. Export writes the keys in the clear.
+func (k Key) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
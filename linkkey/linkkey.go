@@ -0,0 +1,296 @@
+//Package linkkey manages the APS link keys the trust center shares with the devices. Keys are listed with their
+//frame counters, exported to a file and imported onto another adapter, e.g. when the adapter is replaced.
+//
+//	s := linkkey.New(z)
+//	err := s.Export(file)
+//	...
+//	err = linkkey.New(replacement).Import(file)
+package linkkey
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dyrkin/bin"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/nv"
+)
+
+var (
+	//ErrNotFound is returned when the adapter has no key for the device
+	ErrNotFound = errors.New("linkkey: no key for the device")
+	//ErrNotRotated is returned when the key didn't change after the key request
+	ErrNotRotated = errors.New("linkkey: key wasn't rotated")
+)
+
+//legacyTclkTableEnd is the last item of the legacy trust center link key table
+const legacyTclkTableEnd = 0x01FF
+
+//Entry is the key of a device with its frame counters
+type Entry struct {
+	IEEEAddr string
+	//NwkAddr is the network address known by the adapter, it is empty when the address is unknown
+	NwkAddr        string
+	Key            Key
+	TxFrameCounter uint32
+	RxFrameCounter uint32
+}
+
+//Store reads and writes the keys of the adapter
+type Store struct {
+	z        *znp.Znp
+	timeout  time.Duration //time a rotation has to complete
+	interval time.Duration //interval the key is checked at during a rotation
+}
+
+//New returns the key store of the adapter
+func New(z *znp.Znp) *Store {
+	return &Store{z: z, timeout: 10 * time.Second, interval: 250 * time.Millisecond}
+}
+
+//Get returns the key of the device with UtilApsmeLinkKeyDataGet
+func (s *Store) Get(ieeeAddr string) (*Entry, error) {
+	ieeeAddr = strings.ToLower(ieeeAddr)
+	rsp, err := s.z.UtilApsmeLinkKeyDataGet(ieeeAddr)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return nil, fmt.Errorf("%w: %s (%s)", ErrNotFound, ieeeAddr, rsp.Status)
+	}
+	entry := &Entry{IEEEAddr: ieeeAddr, Key: rsp.SecKey, TxFrameCounter: rsp.TxFrmCntr,
+		RxFrameCounter: rsp.RxFrmCntr}
+	if lookup, err := s.z.UtilAddrMgrExtAddrLookup(ieeeAddr); err == nil && lookup.NwkAddr != "0xfffe" &&
+		lookup.NwkAddr != "0xffff" {
+		entry.NwkAddr = lookup.NwkAddr
+	}
+	return entry, nil
+}
+
+//List returns the keys of the devices in the trust center link key table. The table is walked in NV, the
+//ExTclkTable of Z-Stack 3.x or the legacy table of older firmwares, and the keys are read from APSME. Older
+//firmwares are only told apart once the adapter is probed.
+func (s *Store) List() ([]*Entry, error) {
+	addrs, err := s.addresses()
+	if err != nil {
+		return nil, err
+	}
+	var entries []*Entry
+	for _, addr := range addrs {
+		entry, err := s.Get(addr)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+//Set stores the key of the device with ZdoSetLinkKey and restores its frame counters in NV
+func (s *Store) Set(entry *Entry) error {
+	ieeeAddr := strings.ToLower(entry.IEEEAddr)
+	nwkAddr := entry.NwkAddr
+	if nwkAddr == "" {
+		nwkAddr = "0xfffe"
+	}
+	rsp, err := s.z.ZdoSetLinkKey(nwkAddr, ieeeAddr, entry.Key)
+	if err := check("ZdoSetLinkKey", rsp, err); err != nil {
+		return err
+	}
+	return s.setFrameCounters(ieeeAddr, entry.TxFrameCounter, entry.RxFrameCounter)
+}
+
+//Remove removes the key of the device with ZdoRemoveLinkKey
+func (s *Store) Remove(ieeeAddr string) error {
+	rsp, err := s.z.ZdoRemoveLinkKey(strings.ToLower(ieeeAddr))
+	return check("ZdoRemoveLinkKey", rsp, err)
+}
+
+//Rotate asks the trust center for a new key for the device with UtilApsmeRequestKeyCmd and waits until the key
+//changed
+func (s *Store) Rotate(ctx context.Context, ieeeAddr string) (*Entry, error) {
+	old, err := s.Get(ieeeAddr)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := s.z.UtilApsmeRequestKeyCmd(old.IEEEAddr)
+	if err := check("UtilApsmeRequestKeyCmd", rsp, err); err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	deadline := time.NewTimer(s.timeout)
+	defer deadline.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			entry, err := s.Get(old.IEEEAddr)
+			if err != nil {
+				return nil, err
+			}
+			if entry.Key != old.Key {
+				return entry, nil
+			}
+		case <-deadline.C:
+			return nil, ErrNotRotated
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+//file is the format of the exported keys. Unlike Entry it holds the keys in the clear.
+type file struct {
+	Keys []*fileEntry `json:"keys"`
+}
+
+type fileEntry struct {
+	IEEEAddr       string `json:"ieeeAddr"`
+	NwkAddr        string `json:"nwkAddr,omitempty"`
+	Key            string `json:"key"`
+	TxFrameCounter uint32 `json:"txFrameCounter"`
+	RxFrameCounter uint32 `json:"rxFrameCounter"`
+}
+
+//Export writes the keys returned by List as JSON. The file contains the keys in the clear, protect it accordingly.
+func (s *Store) Export(w io.Writer) error {
+	entries, err := s.List()
+	if err != nil {
+		return err
+	}
+	f := &file{Keys: []*fileEntry{}}
+	for _, e := range entries {
+		f.Keys = append(f.Keys, &fileEntry{IEEEAddr: e.IEEEAddr, NwkAddr: e.NwkAddr, Key: e.Key.Hex(),
+			TxFrameCounter: e.TxFrameCounter, RxFrameCounter: e.RxFrameCounter})
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(f)
+}
+
+//Import stores the keys written by Export with Set. Devices drop frames with counters they already saw, so export
+//the keys right before the import and don't use the old adapter afterwards.
+func (s *Store) Import(r io.Reader) error {
+	f := &file{}
+	if err := json.NewDecoder(r).Decode(f); err != nil {
+		return fmt.Errorf("linkkey: invalid file: %w", err)
+	}
+	for _, e := range f.Keys {
+		key, err := ParseKey(e.Key)
+		if err != nil {
+			return fmt.Errorf("linkkey: key of %s: %w", e.IEEEAddr, err)
+		}
+		err = s.Set(&Entry{IEEEAddr: e.IEEEAddr, NwkAddr: e.NwkAddr, Key: key, TxFrameCounter: e.TxFrameCounter,
+			RxFrameCounter: e.RxFrameCounter})
+		if err != nil {
+			return fmt.Errorf("linkkey: importing the key of %s failed: %w", e.IEEEAddr, err)
+		}
+	}
+	return nil
+}
+
+//addresses returns the devices of the trust center link key table
+func (s *Store) addresses() ([]string, error) {
+	var addrs []string
+	for subID := uint16(0); subID < 0xFFFF; subID++ {
+		value, err := nv.ExTclkTable.Read(s.z, subID)
+		if errors.Is(err, znp.ErrUnsupported) {
+			return s.legacyAddresses()
+		}
+		if errors.Is(err, nv.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entry := &nv.TCLinkKeyEntry{}
+		if decode(value, entry) && used(entry.ExtAddr) {
+			addrs = append(addrs, entry.ExtAddr)
+		}
+	}
+	return addrs, nil
+}
+
+//legacyAddresses returns the devices of the legacy table, the entries are stored in consecutive items
+func (s *Store) legacyAddresses() ([]string, error) {
+	var addrs []string
+	for id := nv.TclkTableStart.ID; id <= legacyTclkTableEnd; id++ {
+		value, err := nv.Read(s.z, id)
+		if errors.Is(err, nv.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entry := &nv.LegacyTCLinkKey{}
+		if decode(value, entry) && used(entry.ExtAddr) {
+			addrs = append(addrs, entry.ExtAddr)
+		}
+	}
+	return addrs, nil
+}
+
+//setFrameCounters updates the entry of the device found with UtilApsmeLinkKeyNvIdGet. The ID is the sub ID of
+//ExTclkTable on Z-Stack 3.x and the legacy item ID on older firmwares.
+func (s *Store) setFrameCounters(ieeeAddr string, tx uint32, rx uint32) error {
+	rsp, err := s.z.UtilApsmeLinkKeyNvIdGet(ieeeAddr)
+	if err != nil {
+		return err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return fmt.Errorf("%w: %s (%s)", ErrNotFound, ieeeAddr, rsp.Status)
+	}
+	value, err := nv.ExTclkTable.Read(s.z, rsp.LinkKeyNvId)
+	if errors.Is(err, znp.ErrUnsupported) {
+		legacy := &nv.LegacyTCLinkKey{}
+		item := nv.Item{ID: rsp.LinkKeyNvId, Name: "ZCD_NV_TCLK_TABLE"}
+		if err := item.Decode(s.z, legacy); err != nil {
+			return err
+		}
+		if legacy.ExtAddr != ieeeAddr {
+			return fmt.Errorf("linkkey: %s holds the key of %s", item, legacy.ExtAddr)
+		}
+		legacy.TxFrameCounter, legacy.RxFrameCounter = tx, rx
+		return item.Encode(s.z, legacy)
+	}
+	if err != nil {
+		return err
+	}
+	entry := &nv.TCLinkKeyEntry{}
+	if !decode(value, entry) || entry.ExtAddr != ieeeAddr {
+		return fmt.Errorf("linkkey: %s[%d] doesn't hold the key of %s", nv.ExTclkTable.Name, rsp.LinkKeyNvId,
+			ieeeAddr)
+	}
+	entry.TxFrameCounter, entry.RxFrameCounter = tx, rx
+	return nv.ExTclkTable.Write(s.z, rsp.LinkKeyNvId, bin.Encode(entry))
+}
+
+//decode decodes the value into the layout and reports whether it has the length of the layout
+func decode(value []byte, v interface{}) bool {
+	bin.Decode(value, v)
+	return len(bin.Encode(v)) == len(value)
+}
+
+//used reports whether the address of a table entry belongs to a device, unused entries are zeroed and the
+//default key has the wildcard address
+func used(ieeeAddr string) bool {
+	return ieeeAddr != "0x0000000000000000" && ieeeAddr != "0xffffffffffffffff"
+}
+
+func check(command string, rsp *znp.StatusResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return fmt.Errorf("linkkey: %s failed: %s", command, rsp.Status)
+	}
+	return nil
+}
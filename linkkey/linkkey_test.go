@@ -0,0 +1,199 @@
+package linkkey
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/dyrkin/bin"
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	"github.com/dyrkin/znp-go/nv"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+const device = "0x00124b0001020304"
+
+//fakeAdapter is a Z-Stack 3.x trust center keeping its link key table in memory. The keys are held by APSME, the
+//table holds the addresses and the frame counters.
+type fakeAdapter struct {
+	*znptest.Adapter
+	table [][]byte
+	keys  map[string]Key
+}
+
+func newFakeAdapter(size int) *fakeAdapter {
+	a := &fakeAdapter{Adapter: znptest.New(), keys: map[string]Key{}}
+	for i := 0; i < size; i++ {
+		a.table = append(a.table, bin.Encode(&nv.TCLinkKeyEntry{ExtAddr: "0x0000000000000000"}))
+	}
+	a.Handle(unp.S_SYS, 0x32, func(r *znptest.Request) []byte {
+		req := &znp.SysNvLength{}
+		r.Decode(req)
+		return binary.LittleEndian.AppendUint32(nil, uint32(len(a.entry(req.ItemID, req.SubID))))
+	})
+	a.Handle(unp.S_SYS, 0x33, func(r *znptest.Request) []byte {
+		req := &znp.SysNvRead{}
+		r.Decode(req)
+		value := a.entry(req.ItemID, req.SubID)[req.Offset:]
+		return append([]byte{0x00, uint8(len(value))}, value...)
+	})
+	a.Handle(unp.S_SYS, 0x34, func(r *znptest.Request) []byte {
+		req := &znp.SysNvWrite{}
+		r.Decode(req)
+		copy(a.entry(req.ItemID, req.SubID)[req.Offset:], req.Value)
+		return nil
+	})
+	a.Handle(unp.S_UTIL, 0x40, func(r *znptest.Request) []byte {
+		if a.lookup(r) < 0 {
+			return []byte{0xfe, 0xff}
+		}
+		return []byte{0x2b, 0x1a}
+	})
+	a.Handle(unp.S_UTIL, 0x44, func(r *znptest.Request) []byte {
+		index := a.lookup(r)
+		if index < 0 {
+			return []byte{0xc8}
+		}
+		req := &znp.UtilApsmeLinkKeyDataGet{}
+		r.Decode(req)
+		entry := &nv.TCLinkKeyEntry{}
+		bin.Decode(a.table[index], entry)
+		return bin.Encode(&znp.UtilApsmeLinkKeyDataGetResponse{SecKey: a.keys[req.ExtAddr],
+			TxFrmCntr: entry.TxFrameCounter, RxFrmCntr: entry.RxFrameCounter})
+	})
+	a.Handle(unp.S_UTIL, 0x45, func(r *znptest.Request) []byte {
+		index := a.lookup(r)
+		if index < 0 {
+			return []byte{0xc8, 0x00, 0x00}
+		}
+		return []byte{0x00, uint8(index), 0x00}
+	})
+	a.Handle(unp.S_UTIL, 0x4B, func(r *znptest.Request) []byte {
+		req := &znp.UtilApsmeRequestKeyCmd{}
+		r.Decode(req)
+		key := a.keys[req.PartnerAddr]
+		key[0]++
+		a.keys[req.PartnerAddr] = key
+		return nil
+	})
+	a.Handle(unp.S_ZDO, 0x23, func(r *znptest.Request) []byte {
+		req := &znp.ZdoSetLinkKey{}
+		r.Decode(req)
+		a.keys[req.IEEEAddr] = req.LinkKeyData
+		if a.index(req.IEEEAddr) < 0 {
+			a.table[a.index("0x0000000000000000")] = bin.Encode(&nv.TCLinkKeyEntry{ExtAddr: req.IEEEAddr})
+		}
+		return nil
+	})
+	return a
+}
+
+func (a *fakeAdapter) connect() *znp.Znp {
+	z := znp.New(a.Unp())
+	z.Start()
+	return z
+}
+
+//lookup returns the index of the device addressed by the request, all UTIL requests start with its address
+func (a *fakeAdapter) lookup(r *znptest.Request) int {
+	req := &znp.UtilApsmeLinkKeyDataGet{}
+	r.Decode(req)
+	return a.index(req.ExtAddr)
+}
+
+func (a *fakeAdapter) entry(itemID uint16, subID uint16) []byte {
+	if itemID != nv.ExTclkTable.ItemID || int(subID) >= len(a.table) {
+		return nil
+	}
+	return a.table[subID]
+}
+
+func (a *fakeAdapter) index(ieeeAddr string) int {
+	for i, value := range a.table {
+		entry := &nv.TCLinkKeyEntry{}
+		bin.Decode(value, entry)
+		if entry.ExtAddr == ieeeAddr {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *MySuite) TestKeyIsRedacted(c *C) {
+	key, err := ParseKey("000102030405060708090a0b0c0d0e0f")
+	c.Assert(err, IsNil)
+	c.Assert(key.Hex(), Equals, "000102030405060708090a0b0c0d0e0f")
+	c.Assert(fmt.Sprintf("%v %x %+v", key, key, &Entry{Key: key}), Not(Matches), ".*0102.*")
+	b, err := json.Marshal(&Entry{Key: key})
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"Key":"\[redacted\]".*`)
+}
+
+func (s *MySuite) TestLegacyKeyIsNotLogged(c *C) {
+	key := Key{0x5a, 0x69, 0x67, 0x42, 0x65, 0x65, 0x41, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x30, 0x39}
+	entry := bin.Encode(&nv.LegacyTCLinkKey{ExtAddr: device, Key: key})
+	a := znptest.New()
+	a.Handle(unp.S_SYS, 0x13, func(r *znptest.Request) []byte {
+		req := &znp.SysOsalNvLength{}
+		r.Decode(req)
+		if req.ID != nv.TclkTableStart.ID {
+			return []byte{0x00, 0x00}
+		}
+		return binary.LittleEndian.AppendUint16(nil, uint16(len(entry)))
+	})
+	a.Respond(unp.S_SYS, 0x08, append([]byte{0x00, uint8(len(entry))}, entry...))
+	var log bytes.Buffer
+	z := znp.New(a.Unp())
+	z.SetLogger(slog.New(slog.NewTextHandler(&log, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	z.Start()
+
+	addrs, err := New(z).legacyAddresses()
+	c.Assert(err, IsNil)
+	c.Assert(addrs, DeepEquals, []string{device})
+	c.Assert(log.String(), Matches, `(?s).*name=SysOsalNvReadResponse .*message.Value=\[redacted\].*`)
+	c.Assert(log.String(), Not(Matches), "(?s).*"+key.Hex()+".*")
+}
+
+func (s *MySuite) TestExportImport(c *C) {
+	old := newFakeAdapter(3)
+	old.table[1] = bin.Encode(&nv.TCLinkKeyEntry{TxFrameCounter: 1000, RxFrameCounter: 20, ExtAddr: device})
+	old.keys[device] = Key{0xab, 0xcd}
+	var file bytes.Buffer
+	c.Assert(New(old.connect()).Export(&file), IsNil)
+	c.Assert(file.String(), Matches, `(?s).*"key": "abcd0000000000000000000000000000".*`)
+
+	replacement := newFakeAdapter(2)
+	store := New(replacement.connect())
+	c.Assert(store.Import(&file), IsNil)
+	entries, err := store.List()
+	c.Assert(err, IsNil)
+	c.Assert(entries, DeepEquals, []*Entry{{IEEEAddr: device, NwkAddr: "0x1a2b", Key: Key{0xab, 0xcd},
+		TxFrameCounter: 1000, RxFrameCounter: 20}})
+}
+
+func (s *MySuite) TestRotate(c *C) {
+	a := newFakeAdapter(1)
+	a.table[0] = bin.Encode(&nv.TCLinkKeyEntry{ExtAddr: device})
+	a.keys[device] = Key{0x01}
+	store := New(a.connect())
+	store.interval = time.Millisecond
+	entry, err := store.Rotate(context.Background(), device)
+	c.Assert(err, IsNil)
+	c.Assert(entry.Key, Equals, Key{0x02})
+
+	_, err = store.Rotate(context.Background(), "0x00124b00deadbeef")
+	c.Assert(err, ErrorMatches, "linkkey: no key for the device.*")
+}
@@ -0,0 +1,129 @@
+package znp
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+
+	"github.com/dyrkin/unp-go"
+)
+
+//SetLogger installs a structured logger. It must be called before Start.
+//
+//Events are logged in the order they happen, from the goroutine processing them:
+//
+//	Debug: frames sent and received, decoded responses and async commands
+//	Info:  lifecycle changes
+//	Warn:  timeouts, unknown async commands, errors reported by the adapter
+//	Error: failures reading from or writing to the adapter
+//
+//Verbosity is controlled by the level of the logger's handler.
+func (znp *Znp) SetLogger(logger *slog.Logger) {
+	znp.logger = logger
+}
+
+//SetLogPayloads enables hex dumps of frame payloads in the frame log events. It is off by default, the dumps
+//include the keys which are redacted from the decoded messages.
+func (znp *Znp) SetLogPayloads(enabled bool) {
+	znp.logPayloads = enabled
+}
+
+func (znp *Znp) logEnabled(level slog.Level) bool {
+	return znp.logger != nil && znp.logger.Enabled(context.Background(), level)
+}
+
+func (znp *Znp) log(level slog.Level, msg string, args ...interface{}) {
+	if znp.logEnabled(level) {
+		znp.logger.Log(context.Background(), level, msg, args...)
+	}
+}
+
+func (znp *Znp) logFrame(msg string, frame *unp.Frame) {
+	if !znp.logEnabled(slog.LevelDebug) {
+		return
+	}
+	args := []interface{}{
+		slog.String("type", frame.CommandType.String()),
+		slog.String("subsystem", frame.Subsystem.String()),
+		slog.Int("command", int(frame.Command)),
+		slog.Int("length", len(frame.Payload)),
+	}
+	if znp.logPayloads {
+		args = append(args, slog.String("payload", hex.EncodeToString(frame.Payload)))
+	}
+	znp.logger.Log(context.Background(), slog.LevelDebug, msg, args...)
+}
+
+func (znp *Znp) logMessage(msg string, message interface{}) {
+	if !znp.logEnabled(slog.LevelDebug) {
+		return
+	}
+	znp.logger.Log(context.Background(), slog.LevelDebug, msg, slog.String("name", typeName(message)),
+		slog.Any("message", logValue{reflect.ValueOf(message)}))
+}
+
+//logValue renders a model as a group of its fields. Enums are rendered with their String() values and byte
+//slices and arrays as hex. Fields tagged with `log:"redact"`, e.g. link keys and the values read from NV, are never
+//rendered.
+type logValue struct {
+	v reflect.Value
+}
+
+func (l logValue) LogValue() slog.Value {
+	v := l.v
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return slog.StringValue("nil")
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return slog.StringValue("nil")
+	}
+	if stringer, ok := v.Interface().(fmt.Stringer); ok {
+		return slog.StringValue(stringer.String())
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		var attrs []slog.Attr
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			switch {
+			case field.PkgPath != "":
+			case field.Tag.Get("log") == "redact":
+				attrs = append(attrs, slog.String(field.Name, "[redacted]"))
+			default:
+				attrs = append(attrs, slog.Any(field.Name, logValue{v.Field(i)}))
+			}
+		}
+		return slog.GroupValue(attrs...)
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem() == reflect.TypeOf(uint8(0)) {
+			b := make([]byte, v.Len())
+			for i := range b {
+				b[i] = byte(v.Index(i).Uint())
+			}
+			return slog.StringValue(hex.EncodeToString(b))
+		}
+		attrs := make([]slog.Attr, v.Len())
+		for i := range attrs {
+			attrs[i] = slog.Any(strconv.Itoa(i), logValue{v.Index(i)})
+		}
+		return slog.GroupValue(attrs...)
+	}
+	return slog.AnyValue(v.Interface())
+}
+
+func typeName(v interface{}) string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return "nil"
+	}
+	return t.Name()
+}
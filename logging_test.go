@@ -0,0 +1,57 @@
+package znp
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/dyrkin/unp-go"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) TestLogMessage(c *C) {
+	var buf bytes.Buffer
+	z := New(nil)
+	z.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	z.logMessage("async command received", &ZdoMgmtLqiRsp{
+		SrcAddr: "0x0000",
+		Status:  StatusSuccess,
+		NeighborLqiList: []*NeighborLqi{
+			{ExtendedAddress: "0x00124b0001", DeviceType: LqiDeviceTypeRouter, LQI: 200},
+		},
+	})
+	c.Assert(buf.String(), Matches, `.*msg="async command received" name=ZdoMgmtLqiRsp message.SrcAddr=0x0000 message.Status=StatusSuccess .*`+
+		`message.NeighborLqiList.0.ExtendedAddress=0x00124b0001 .*message.NeighborLqiList.0.DeviceType=LqiDeviceTypeRouter .*message.NeighborLqiList.0.LQI=200\n`)
+}
+
+func (s *MySuite) TestLogMessageRedactsKeys(c *C) {
+	var buf bytes.Buffer
+	z := New(nil)
+	z.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	z.logMessage("request sent", &ZdoSetLinkKey{ShortAddr: "0x1a2b", IEEEAddr: "0x00124b0001020304",
+		LinkKeyData: [16]uint8{0xab, 0xcd}})
+	c.Assert(buf.String(), Matches, `.*message.IEEEAddr=0x00124b0001020304 message.LinkKeyData=\[redacted\]\n`)
+}
+
+func (s *MySuite) TestLogFramePayloadIsOptIn(c *C) {
+	var buf bytes.Buffer
+	z := New(nil)
+	z.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	frame := &unp.Frame{CommandType: unp.C_SREQ, Subsystem: unp.S_SYS, Command: 0x08, Payload: []byte{0x83, 0x00, 0x00}}
+
+	z.logFrame("frame sent", frame)
+	c.Assert(buf.String(), Matches, `.*msg="frame sent" type=C_SREQ subsystem=S_SYS command=8 length=3\n`)
+
+	buf.Reset()
+	z.SetLogPayloads(true)
+	z.logFrame("frame sent", frame)
+	c.Assert(buf.String(), Matches, `.*msg="frame sent" type=C_SREQ subsystem=S_SYS command=8 length=3 payload=830000\n`)
+}
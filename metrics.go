@@ -0,0 +1,42 @@
+package znp
+
+import (
+	"time"
+
+	"github.com/dyrkin/unp-go"
+)
+
+//Metrics receives measurements of the MT link. Implementations must be safe for concurrent use and must not block.
+//See the metrics package for a Prometheus implementation.
+type Metrics interface {
+	//FrameSent is called for every frame written to the adapter
+	FrameSent(frame *unp.Frame)
+	//FrameReceived is called for every frame read from the adapter
+	FrameReceived(frame *unp.Frame)
+	//SyncRequestCompleted is called when a synchronous request got its response
+	SyncRequestCompleted(subsystem unp.Subsystem, command byte, latency time.Duration)
+	//SyncRequestTimedOut is called when a synchronous request didn't get a response in time
+	SyncRequestTimedOut(subsystem unp.Subsystem, command byte)
+	//SyncRequestFailed is called when a synchronous request couldn't be written or was rejected by the adapter
+	SyncRequestFailed(subsystem unp.Subsystem, command byte, err error)
+	//AsyncDropped is called when a decoded async command is dropped because a consumer isn't keeping up.
+	//It is called once for every consumer (AsyncInbound or a Subscription) which missed the command
+	AsyncDropped(subsystem unp.Subsystem, command byte)
+	//UnknownAsync is called when an async command which is not registered is received
+	UnknownAsync(subsystem unp.Subsystem, command byte)
+}
+
+//SetMetrics installs metrics. It must be called before Start.
+func (znp *Znp) SetMetrics(metrics Metrics) {
+	znp.metrics = metrics
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) FrameSent(*unp.Frame)                                    {}
+func (noopMetrics) FrameReceived(*unp.Frame)                                {}
+func (noopMetrics) SyncRequestCompleted(unp.Subsystem, byte, time.Duration) {}
+func (noopMetrics) SyncRequestTimedOut(unp.Subsystem, byte)                 {}
+func (noopMetrics) SyncRequestFailed(unp.Subsystem, byte, error)            {}
+func (noopMetrics) AsyncDropped(unp.Subsystem, byte)                        {}
+func (noopMetrics) UnknownAsync(unp.Subsystem, byte)                        {}
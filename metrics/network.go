@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/dyrkin/znp-go"
+)
+
+//zdiagsAttributes are the statistics read with SysZDiagsGetStats, see ZDiags.h in Z-Stack.
+var zdiagsAttributes = []struct {
+	id   uint16
+	name string
+}{
+	{0x0001, "number_of_resets"},
+	{0x0064, "mac_rx_crc_pass"},
+	{0x0065, "mac_rx_crc_fail"},
+	{0x0066, "mac_rx_bcast"},
+	{0x0067, "mac_tx_bcast"},
+	{0x0068, "mac_rx_ucast"},
+	{0x0069, "mac_tx_ucast"},
+	{0x006A, "mac_tx_ucast_retry"},
+	{0x006B, "mac_tx_ucast_fail"},
+	{0x00C8, "route_disc_initiated"},
+	{0x00C9, "nwk_decrypt_failures"},
+	{0x00CA, "packet_buffer_allocate_failures"},
+	{0x00CB, "relayed_ucast"},
+	{0x012C, "aps_rx_bcast"},
+	{0x012D, "aps_tx_bcast"},
+	{0x012E, "aps_rx_ucast"},
+	{0x012F, "aps_tx_ucast_success"},
+	{0x0130, "aps_tx_ucast_retry"},
+	{0x0131, "aps_tx_ucast_fail"},
+	{0x0132, "aps_decrypt_failures"},
+	{0x0133, "aps_invalid_packets"},
+	{0x0134, "mac_retries_per_aps_tx_success"},
+}
+
+var relations = []znp.Relation{
+	znp.RelationParent,
+	znp.RelationChildRfd,
+	znp.RelationChildRfdRxIdle,
+	znp.RelationChildFfd,
+	znp.RelationChildFfdRxIdle,
+	znp.RelationNeighbor,
+}
+
+//Poll refreshes the network gauges: Z-Stack diagnostics, the number of associated devices per relation and
+//the link quality of the adapter's neighbors. Failed reads are counted in znp_poll_errors_total.
+func (p *Prometheus) Poll(z *znp.Znp) {
+	for _, attribute := range zdiagsAttributes {
+		rsp, err := z.SysZDiagsGetStats(attribute.id)
+		if err != nil {
+			p.pollErrors.add(1, "zdiags")
+			continue
+		}
+		p.zdiags.set(float64(rsp.AttributeValue), attribute.name)
+	}
+	for _, relation := range relations {
+		rsp, err := z.UtilAssocCount(relation, relation)
+		if err != nil {
+			p.pollErrors.add(1, "assoc_count")
+			continue
+		}
+		p.devices.set(float64(rsp.Count), relation.String())
+	}
+	p.pollNeighbors(z)
+}
+
+//Watch polls the network gauges with the given interval until the returned function is called.
+func (p *Prometheus) Watch(z *znp.Znp, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			p.Poll(z)
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+//pollNeighbors reads the neighbor table of the adapter page by page with ZDO_MGMT_LQI_REQ.
+func (p *Prometheus) pollNeighbors(z *znp.Znp) {
+	subscription := z.Subscribe()
+	defer subscription.Unsubscribe()
+
+	var neighbors []*znp.NeighborLqi
+	for start := uint8(0); ; {
+		if rsp, err := z.ZdoMgmtLqiReq("0x0000", start); err != nil || rsp.Status != znp.StatusSuccess {
+			p.pollErrors.add(1, "lqi")
+			return
+		}
+		rsp := awaitLqi(subscription, 5*time.Second)
+		if rsp == nil || rsp.Status != znp.StatusSuccess {
+			p.pollErrors.add(1, "lqi")
+			return
+		}
+		neighbors = append(neighbors, rsp.NeighborLqiList...)
+		start += uint8(len(rsp.NeighborLqiList))
+		if len(rsp.NeighborLqiList) == 0 || int(start) >= int(rsp.NeighborTableEntries) {
+			break
+		}
+	}
+	p.neighborLqi.reset()
+	for _, neighbor := range neighbors {
+		p.neighborLqi.set(float64(neighbor.LQI), neighbor.ExtendedAddress, neighbor.NetworkAddress)
+	}
+}
+
+func awaitLqi(subscription *znp.Subscription, timeout time.Duration) *znp.ZdoMgmtLqiRsp {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case async := <-subscription.Events():
+			if rsp, ok := async.(*znp.ZdoMgmtLqiRsp); ok && rsp.SrcAddr == "0x0000" {
+				return rsp
+			}
+		case <-deadline:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,94 @@
+//Package metrics implements znp.Metrics and exposes the link and network health in the Prometheus text format.
+//
+//	p := metrics.NewPrometheus()
+//	z.SetMetrics(p)
+//	z.Start()
+//	stop := p.Watch(z, time.Minute)
+//	http.Handle("/metrics", p)
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+)
+
+//Prometheus collects link metrics through the znp.Metrics hooks and network gauges through Poll.
+type Prometheus struct {
+	framesSent     *vec
+	framesReceived *vec
+	latency        *histogramVec
+	timeouts       *vec
+	failures       *vec
+	asyncDropped   *vec
+	unknownAsync   *vec
+
+	zdiags      *vec
+	devices     *vec
+	neighborLqi *vec
+	pollErrors  *vec
+
+	metrics []metric
+}
+
+func NewPrometheus() *Prometheus {
+	p := &Prometheus{
+		framesSent:     newVec("counter", "znp_frames_sent_total", "MT frames written to the adapter.", "type", "subsystem"),
+		framesReceived: newVec("counter", "znp_frames_received_total", "MT frames read from the adapter.", "type", "subsystem"),
+		latency: newHistogramVec("znp_sreq_duration_seconds", "Time between a synchronous request and its response.",
+			[]float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5}, "subsystem", "command"),
+		timeouts:     newVec("counter", "znp_sreq_timeouts_total", "Synchronous requests which got no response in time.", "subsystem", "command"),
+		failures:     newVec("counter", "znp_sreq_errors_total", "Synchronous requests which failed to be sent or were rejected by the adapter.", "subsystem", "command"),
+		asyncDropped: newVec("counter", "znp_async_dropped_total", "Async commands dropped because a consumer wasn't keeping up.", "subsystem", "command"),
+		unknownAsync: newVec("counter", "znp_async_unknown_total", "Async commands which are not supported.", "subsystem", "command"),
+		zdiags:       newVec("gauge", "znp_zdiags", "Z-Stack diagnostics counters read with SYS_ZDIAGS_GET_STATS.", "attribute"),
+		devices:      newVec("gauge", "znp_associated_devices", "Devices in the association table of the adapter.", "relation"),
+		neighborLqi:  newVec("gauge", "znp_neighbor_lqi", "Link quality of the adapter's neighbors.", "ieee", "nwk"),
+		pollErrors:   newVec("counter", "znp_poll_errors_total", "Failed attempts to read network metrics from the adapter.", "source"),
+	}
+	p.metrics = []metric{p.framesSent, p.framesReceived, p.latency, p.timeouts, p.failures, p.asyncDropped,
+		p.unknownAsync, p.zdiags, p.devices, p.neighborLqi, p.pollErrors}
+	return p
+}
+
+func (p *Prometheus) FrameSent(frame *unp.Frame) {
+	p.framesSent.add(1, frame.CommandType.String(), frame.Subsystem.String())
+}
+
+func (p *Prometheus) FrameReceived(frame *unp.Frame) {
+	p.framesReceived.add(1, frame.CommandType.String(), frame.Subsystem.String())
+}
+
+func (p *Prometheus) SyncRequestCompleted(subsystem unp.Subsystem, command byte, latency time.Duration) {
+	p.latency.observe(latency.Seconds(), subsystem.String(), commandLabel(command))
+}
+
+func (p *Prometheus) SyncRequestTimedOut(subsystem unp.Subsystem, command byte) {
+	p.timeouts.add(1, subsystem.String(), commandLabel(command))
+}
+
+func (p *Prometheus) SyncRequestFailed(subsystem unp.Subsystem, command byte, err error) {
+	p.failures.add(1, subsystem.String(), commandLabel(command))
+}
+
+func (p *Prometheus) AsyncDropped(subsystem unp.Subsystem, command byte) {
+	p.asyncDropped.add(1, subsystem.String(), commandLabel(command))
+}
+
+func (p *Prometheus) UnknownAsync(subsystem unp.Subsystem, command byte) {
+	p.unknownAsync.add(1, subsystem.String(), commandLabel(command))
+}
+
+//ServeHTTP writes all metrics in the Prometheus text exposition format.
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	for _, m := range p.metrics {
+		m.write(w)
+	}
+}
+
+func commandLabel(command byte) string {
+	return fmt.Sprintf("0x%02x", command)
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) TestTextFormat(c *C) {
+	p := NewPrometheus()
+	p.FrameSent(&unp.Frame{CommandType: unp.C_SREQ, Subsystem: unp.S_SYS, Command: 0x01})
+	p.FrameSent(&unp.Frame{CommandType: unp.C_SREQ, Subsystem: unp.S_SYS, Command: 0x02})
+	p.SyncRequestCompleted(unp.S_SYS, 0x01, 20*time.Millisecond)
+	p.SyncRequestTimedOut(unp.S_ZDO, 0x36)
+	p.SyncRequestFailed(unp.S_AF, 0x01, errors.New("Invalid parameter"))
+	p.AsyncDropped(unp.S_AF, 0x81)
+	p.zdiags.set(3, "number_of_resets")
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+
+	c.Assert(body, Matches, `(?s).*# TYPE znp_frames_sent_total counter\nznp_frames_sent_total\{type="C_SREQ",subsystem="S_SYS"\} 2\n.*`)
+	c.Assert(body, Matches, `(?s).*znp_sreq_duration_seconds_bucket\{subsystem="S_SYS",command="0x01",le="0.01"\} 0\n`+
+		`znp_sreq_duration_seconds_bucket\{subsystem="S_SYS",command="0x01",le="0.025"\} 1\n.*`)
+	c.Assert(body, Matches, `(?s).*znp_sreq_duration_seconds_count\{subsystem="S_SYS",command="0x01"\} 1\n.*`)
+	c.Assert(body, Matches, `(?s).*znp_sreq_timeouts_total\{subsystem="S_ZDO",command="0x36"\} 1\n.*`)
+	c.Assert(body, Matches, `(?s).*znp_sreq_errors_total\{subsystem="S_AF",command="0x01"\} 1\n.*`)
+	c.Assert(body, Matches, `(?s).*znp_async_dropped_total\{subsystem="S_AF",command="0x81"\} 1\n.*`)
+	c.Assert(body, Matches, `(?s).*znp_zdiags\{attribute="number_of_resets"\} 3\n.*`)
+}
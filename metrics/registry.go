@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//metric is a family of samples sharing a name, e.g. a counter vector with subsystem and command labels.
+type metric interface {
+	write(w io.Writer)
+}
+
+type vec struct {
+	name   string
+	help   string
+	typ    string
+	labels []string
+
+	mu     sync.Mutex
+	values map[string]float64 //label values joined by \xff -> value
+}
+
+func newVec(typ string, name string, help string, labels ...string) *vec {
+	return &vec{name: name, help: help, typ: typ, labels: labels, values: map[string]float64{}}
+}
+
+func (v *vec) add(delta float64, labelValues ...string) {
+	v.mu.Lock()
+	v.values[strings.Join(labelValues, "\xff")] += delta
+	v.mu.Unlock()
+}
+
+func (v *vec) set(value float64, labelValues ...string) {
+	v.mu.Lock()
+	v.values[strings.Join(labelValues, "\xff")] = value
+	v.mu.Unlock()
+}
+
+func (v *vec) reset() {
+	v.mu.Lock()
+	v.values = map[string]float64{}
+	v.mu.Unlock()
+}
+
+func (v *vec) write(w io.Writer) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	header(w, v.name, v.help, v.typ)
+	for _, key := range sortedKeys(v.values) {
+		fmt.Fprintf(w, "%s%s %s\n", v.name, labels(v.labels, split(key, len(v.labels))), formatFloat(v.values[key]))
+	}
+}
+
+type histogram struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+type histogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	values map[string]*histogram
+}
+
+func newHistogramVec(name string, help string, buckets []float64, labels ...string) *histogramVec {
+	return &histogramVec{name: name, help: help, labels: labels, buckets: buckets, values: map[string]*histogram{}}
+}
+
+func (h *histogramVec) observe(value float64, labelValues ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := strings.Join(labelValues, "\xff")
+	hist, ok := h.values[key]
+	if !ok {
+		hist = &histogram{buckets: make([]uint64, len(h.buckets))}
+		h.values[key] = hist
+	}
+	for i, bound := range h.buckets {
+		if value <= bound {
+			hist.buckets[i]++
+		}
+	}
+	hist.count++
+	hist.sum += value
+}
+
+func (h *histogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	header(w, h.name, h.help, "histogram")
+	bucketLabels := append(append([]string{}, h.labels...), "le")
+	keys := make([]string, 0, len(h.values))
+	for key := range h.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		hist := h.values[key]
+		values := split(key, len(h.labels))
+		for i, bound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labels(bucketLabels, append(values, formatFloat(bound))), hist.buckets[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labels(bucketLabels, append(values, "+Inf")), hist.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, labels(h.labels, values), formatFloat(hist.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, labels(h.labels, values), hist.count)
+	}
+}
+
+func header(w io.Writer, name string, help string, typ string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+}
+
+func labels(names []string, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + strconv.Quote(values[i])
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func split(key string, n int) []string {
+	if n == 0 {
+		return nil
+	}
+	return strings.Split(key, "\xff")
+}
+
+func sortedKeys(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
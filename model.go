@@ -1,3 +1,5 @@
+// Code generated by mtgen from internal/mtgen/spec. DO NOT EDIT.
+
 package znp
 
 type StatusResponse struct {
@@ -49,7 +51,7 @@ type AfDataRequestExt struct {
 }
 
 type AfDataRequestSrcRtgOptions struct {
-	APSAck      uint8 `bits:"0b00000001" bitmask:"start`
+	APSAck      uint8 `bits:"0b00000001" bitmask:"start"`
 	APSSecurity uint8 `bits:"0b00000100"`
 	SkipRouting uint8 `bits:"0b00001000" bitmask:"end" `
 }
@@ -105,7 +107,7 @@ type AfDataRetrieve struct {
 }
 
 type AfDataRetrieveResponse struct {
-	Status StatusResponse
+	Status Status
 	Data   []uint8 `size:"1"`
 }
 
@@ -192,6 +194,118 @@ type DebugMsg struct {
 	String string `size:"1"`
 }
 
+// =======NWK=======
+
+type NwkNldeDataReq struct {
+	DstAddr           string  `hex:"2"`
+	Nsdu              []uint8 `size:"1"`
+	NsduHandle        uint8
+	NsduHandleOptions uint16
+	SecurityEnable    uint8
+	DiscoverRoute     uint8
+	RadiusCounter     uint8
+}
+
+type NwkNlmeNetworkFormationReq struct {
+	PanID                uint16
+	ChannelList          *Channels
+	ScanDuration         uint8
+	BeaconOrder          uint8
+	SuperframeOrder      uint8
+	BatteryLifeExtension uint8
+}
+
+type NwkNlmePermitJoiningReq struct {
+	PermitDuration uint8 //Seconds, 0x00 disables and 0xFF enables joining permanently
+}
+
+type NwkNlmeJoinReq struct {
+	ExtendedPanID         string `hex:"8"`
+	PanID                 uint16
+	Channel               uint8
+	CapabilityInformation *CapInfo
+}
+
+type NwkNlmeLeaveReq struct {
+	ExtendedAddress string `hex:"8"`
+	RemoveChildren  uint8
+	Rejoin          uint8
+}
+
+type NwkNlmeRouteDiscoveryReq struct {
+	DstAddr string `hex:"2"`
+	Options uint8
+	Radius  uint8
+}
+
+type NwkNlmeNetworkDiscoveryReq struct {
+	ScanChannels *Channels
+	ScanDuration uint8
+}
+
+type NwkNldeDataCnf struct {
+	NsduHandle uint8
+	Status     Status
+}
+
+type NwkNldeDataInd struct {
+	SrcAddr     string  `hex:"2"`
+	Nsdu        []uint8 `size:"1"`
+	LinkQuality uint8
+}
+
+type NwkNlmeNetworkFormationCnf struct {
+	Status Status
+}
+
+type NwkNlmeJoinCnf struct {
+	PanID  uint16
+	Status Status
+}
+
+type NwkNlmeJoinInd struct {
+	ShortAddr             string `hex:"2"`
+	ExtendedAddress       string `hex:"8"`
+	CapabilityInformation *CapInfo
+}
+
+type NwkNlmeLeaveCnf struct {
+	ExtendedAddress string `hex:"8"`
+	RemoveChildren  uint8
+	Rejoin          uint8
+	Status          Status
+}
+
+type NwkNlmeLeaveInd struct {
+	SrcAddr         string `hex:"2"`
+	ExtendedAddress string `hex:"8"`
+	Request         uint8
+	RemoveChildren  uint8
+	Rejoin          uint8
+}
+
+type NwkNlmePollCnf struct {
+	Status Status
+}
+
+type NwkNlmeSyncInd struct{}
+
+type NetworkDescriptor struct {
+	PanID           uint16
+	LogicalChannel  uint8
+	BeaconOrder     uint8
+	SuperframeOrder uint8
+	RouterCapacity  uint8
+	DeviceCapacity  uint8
+	ProtocolVersion uint8
+	StackProfile    uint8
+	ExtendedPanID   string `hex:"8"`
+}
+
+type NwkNlmeNetworkDiscoveryCnf struct {
+	NetworkList []*NetworkDescriptor `size:"1"`
+}
+
 // =======SAPI=======
 
 type EmptyResponse struct{}
@@ -309,11 +423,12 @@ type SysPingResponse struct {
 }
 
 type SysVersionResponse struct {
-	TransportRev uint8 //Transport protocol revision
-	Product      uint8 //Product Id
-	MajorRel     uint8 //Software major release number
-	MinorRel     uint8 //Software minor release number
-	MaintRel     uint8 //Software maintenance release number
+	TransportRev uint8   //Transport protocol revision
+	Product      Product //Product Id
+	MajorRel     uint8   //Software major release number
+	MinorRel     uint8   //Software minor release number
+	MaintRel     uint8   //Software maintenance release number
+	Revision     uint32  //Build date, only reported by Z-Stack 3.x
 }
 
 type SysSetExtAddr struct {
@@ -346,7 +461,7 @@ type SysOsalNvRead struct {
 
 type SysOsalNvReadResponse struct {
 	Status Status
-	Value  []uint8 `size:"1"`
+	Value  []uint8 `size:"1" log:"redact"`
 }
 
 type SysOsalNvWrite struct {
@@ -463,7 +578,7 @@ type SysNvLength struct {
 }
 
 type SysNvLengthResponse struct {
-	Length uint8
+	Length uint32
 }
 
 type SysNvRead struct {
@@ -476,7 +591,7 @@ type SysNvRead struct {
 
 type SysNvReadResponse struct {
 	Status Status
-	Value  []uint8 `size:"1"`
+	Value  []uint8 `size:"1" log:"redact"`
 }
 
 type SysNvWrite struct {
@@ -658,7 +773,7 @@ type UtilApsmeLinkKeyDataGet struct {
 
 type UtilApsmeLinkKeyDataGetResponse struct {
 	Status    Status
-	SecKey    [16]uint8
+	SecKey    [16]uint8 `log:"redact"`
 	TxFrmCntr uint32
 	RxFrmCntr uint32
 }
@@ -686,14 +801,14 @@ type UtilAssocCountResponse struct {
 }
 
 type LinkInfo struct {
-	TxCounter uint8 // Counter of transmission success/failures
-	TxCost    uint8 // Average of sending rssi values if link staus is enabled
-	// i.e. NWK_LINK_STATUS_PERIOD is defined as non zero
-	RxLqi uint8 // average of received rssi values
-	// needs to be converted to link cost (1-7) before used
-	InKeySeqNum uint8  // security key sequence number
-	InFrmCntr   uint32 // security frame counter..
-	TxFailure   uint16 // higher values indicate more failures
+	TxCounter uint8 //Counter of transmission success/failures
+	TxCost    uint8 //Average of sending rssi values if link staus is enabled
+	//i.e. NWK_LINK_STATUS_PERIOD is defined as non zero
+	RxLqi uint8 //average of received rssi values
+	//needs to be converted to link cost (1-7) before used
+	InKeySeqNum uint8  //security key sequence number
+	InFrmCntr   uint32 //security frame counter..
+	TxFailure   uint16 //higher values indicate more failures
 }
 
 type AgingEndDevice struct {
@@ -702,10 +817,10 @@ type AgingEndDevice struct {
 }
 
 type Device struct {
-	ShortAddr      string `hex:"2"` // Short address of associated device, or invalid 0xfffe
-	AddrIdx        uint16 // Index from the address manager
+	ShortAddr      string `hex:"2"` //Short address of associated device, or invalid 0xfffe
+	AddrIdx        uint16 //Index from the address manager
 	NodeRelation   uint8
-	DevStatus      uint8 // bitmap of various status values
+	DevStatus      uint8 //bitmap of various status values
 	AssocCnt       uint8
 	Age            uint8
 	LinkInfo       *LinkInfo
@@ -907,7 +1022,7 @@ type Channels struct {
 	Channel23 uint32 `bits:"0x00800000"`
 	Channel24 uint32 `bits:"0x01000000"`
 	Channel25 uint32 `bits:"0x02000000"`
-	Channel26 uint32 `bits:"0b04000000" bitmask:"end"`
+	Channel26 uint32 `bits:"0x04000000" bitmask:"end"`
 }
 
 type ZdoMgmtNwkDiskReq struct {
@@ -957,10 +1072,12 @@ type ZdoMgmtPermitJoinReq struct {
 }
 
 type ZdoMgmtNwkUpdateReq struct {
-	DstAddr      string `hex:"2"`
-	DstAddrMode  AddrMode
-	ChannelMask  *Channels
-	ScanDuration uint8
+	DstAddr        string `hex:"2"`
+	DstAddrMode    AddrMode
+	ChannelMask    *Channels
+	ScanDuration   uint8 //0x00-0x05 scans, 0xFE changes the channel, 0xFF updates the channel mask
+	ScanCount      uint8
+	NwkManagerAddr string `hex:"2"`
 }
 
 type ZdoMsgCbRegister struct {
@@ -980,9 +1097,9 @@ type ZdoStartupFromAppResponse struct {
 }
 
 type ZdoSetLinkKey struct {
-	ShortAddr   string `hex:"2"`
-	IEEEAddr    string `hex:"8"`
-	LinkKeyData [16]uint8
+	ShortAddr   string    `hex:"2"`
+	IEEEAddr    string    `hex:"8"`
+	LinkKeyData [16]uint8 `log:"redact"`
 }
 
 type ZdoRemoveLinkKey struct {
@@ -995,8 +1112,8 @@ type ZdoGetLinkKey struct {
 
 type ZdoGetLinkKeyResponse struct {
 	Status      Status
-	IEEEAddr    string `hex:"8"`
-	LinkKeyData [16]uint8
+	IEEEAddr    string    `hex:"8"`
+	LinkKeyData [16]uint8 `log:"redact"`
 }
 
 type ZdoNwkDiscoveryReq struct {
@@ -1019,9 +1136,9 @@ type ZdoSetRejoinParameters struct {
 }
 
 type ZdoSecAddLinkKey struct {
-	ShortAddress    string `hex:"2"`
-	ExtendedAddress string `hex:"8"`
-	Key             [16]uint8
+	ShortAddress    string    `hex:"2"`
+	ExtendedAddress string    `hex:"8"`
+	Key             [16]uint8 `log:"redact"`
 }
 
 type ZdoSecEntryLookupExt struct {
@@ -1098,7 +1215,7 @@ type ZdoExtRxIdle struct {
 type ZdoExtUpdateNwkKey struct {
 	DestinationAddress string `hex:"2"`
 	KeySeqNum          uint8
-	Key                [128]uint8
+	Key                [16]uint8
 }
 
 type ZdoExtSwitchNwkKey struct {
@@ -1112,7 +1229,7 @@ type ZdoExtNwkInfoResponse struct {
 	ParentAddress         string `hex:"2"`
 	ExtendedPanID         uint64
 	ExtendedParentAddress string `hex:"8"`
-	Channel               uint16 //uint16 or uint8?????
+	Channel               uint8
 }
 
 type ZdoExtSeqApsRemoveReq struct {
@@ -1332,6 +1449,15 @@ type ZdoMgmtPermitJoinRsp struct {
 	Status  Status
 }
 
+type ZdoMgmtNwkUpdateNotify struct {
+	SrcAddr              string `hex:"2"`
+	Status               Status
+	ScannedChannels      *Channels
+	TotalTransmissions   uint16
+	TransmissionFailures uint16
+	EnergyValues         []uint8 `size:"1"` //One value per scanned channel in ascending order
+}
+
 type ZdoStateChangeInd struct {
 	State DeviceState
 }
@@ -1419,7 +1545,7 @@ type ZdoPermitJoinInd struct {
 // =======APP_CNF=======
 
 type AppCnfSetNwkFrameCounter struct {
-	FrameCounterValue uint8
+	FrameCounterValue uint32
 }
 
 type AppCnfSetDefaultEndDeviceTimeout struct {
@@ -1445,8 +1571,8 @@ type AppCnfBdbSetChannel struct {
 
 type AppCnfBdbAddInstallCode struct {
 	InstallCodeFormat InstallCodeFormat
-	IEEEAddr          string `hex:"8"`
-	InstallCode       []uint8
+	IEEEAddr          string  `hex:"8"`
+	InstallCode       []uint8 //16 bytes of code and 2 of CRC, or the 16 bytes of the derived key
 }
 
 type AppCnfBdbSetTcRequireKeyExchange struct {
@@ -0,0 +1,326 @@
+// Code generated by mtgen from internal/mtgen/spec. DO NOT EDIT.
+
+package znp
+
+import (
+	"encoding/hex"
+	"reflect"
+
+	"github.com/dyrkin/bin"
+	. "gopkg.in/check.v1"
+)
+
+var modelExamples = []struct {
+	value   interface{}
+	payload string
+}{
+	{&AfDataRequest{DstAddr: "0x1234", DstEndpoint: 1, SrcEndpoint: 1, ClusterID: 0x0006, TransID: 1, Options: &AfDataRequestOptions{APSAck: 1}, Radius: 15, Data: []uint8{0x01, 0x02}}, "34120101060001100f020102"},
+	{&AfDataConfirm{Status: StatusSuccess, Endpoint: 1, TransID: 5}, "000105"},
+	{&NwkNldeDataReq{DstAddr: "0x1234", Nsdu: []uint8{0xaa, 0xbb}, NsduHandle: 7, NsduHandleOptions: 0, SecurityEnable: 1, DiscoverRoute: 1, RadiusCounter: 30}, "341202aabb07000001011e"},
+	{&NwkNldeDataInd{SrcAddr: "0x1234", Nsdu: []uint8{0xaa, 0xbb}, LinkQuality: 200}, "341202aabbc8"},
+	{&NwkNlmeNetworkDiscoveryCnf{NetworkList: []*NetworkDescriptor{{PanID: 0x1a62, LogicalChannel: 11, BeaconOrder: 15, SuperframeOrder: 15, RouterCapacity: 1, DeviceCapacity: 1, ProtocolVersion: 2, StackProfile: 2, ExtendedPanID: "0x00124b0001020304"}}}, "01621a0b0f0f0101020204030201004b1200"},
+	{&SysVersionResponse{TransportRev: 2, Product: ProductZStack3x0, MajorRel: 2, MinorRel: 7, MaintRel: 1, Revision: 20210708}, "020102070114643401"},
+	{&SysOsalNvRead{ID: 0x0083, Offset: 0}, "830000"},
+	{&SysOsalNvReadResponse{Status: StatusSuccess, Value: []uint8{0x62, 0x1a}}, "0002621a"},
+	{&ZdoMgmtPermitJoinReq{AddrMode: AddrModeAddr16Bit, DstAddr: "0xfffc", Duration: 60, TCSignificance: 0}, "02fcff3c00"},
+	{&ZdoMgmtNwkUpdateReq{DstAddr: "0x1234", DstAddrMode: AddrModeAddr16Bit, ChannelMask: &Channels{Channel15: 1, Channel26: 1}, ScanDuration: 3, ScanCount: 1, NwkManagerAddr: "0x0000"}, "3412020080000403010000"},
+	{&ZdoMgmtNwkUpdateNotify{SrcAddr: "0x1234", Status: StatusSuccess, ScannedChannels: &Channels{Channel15: 1, Channel26: 1}, TotalTransmissions: 100, TransmissionFailures: 2, EnergyValues: []uint8{0x20, 0xc0}}, "34120000800004640002000220c0"},
+	{&ZdoStateChangeInd{State: DeviceStateStartedAsZigBeeCoordinator}, "09"},
+	{&ZdoEndDeviceAnnceInd{SrcAddr: "0x1234", NwkAddr: "0x1234", IEEEAddr: "0x00124b0001020304", Capabilities: &CapInfo{MainPowered: 1, ReceiverOnWhenIdle: 1, AllocAddr: 1}}, "3412341204030201004b12008c"},
+	{&AppCnfSetNwkFrameCounter{FrameCounterValue: 0x00010000}, "00000100"},
+	{&AppCnfSetDefaultEndDeviceTimeout{Timeout: Timeout256Minutes}, "08"},
+	{&AppCnfSetAllowRejoinTcPolicy{AllowRejoin: 1}, "01"},
+	{&AppCnfBdbStartCommissioning{CommissioningMode: CommissioningModeNetworkFormation}, "04"},
+	{&AppCnfBdbSetTcRequireKeyExchange{BdbTrustCenterRequireKeyExchange: 1}, "01"},
+	{&AppCnfBdbSetJoinUsesInstallCodeKey{BdbJoinUsesInstallCodeKey: 1}, "01"},
+	{&AppCnfBdbCommissioningNotification{CommissioningStatus: CommissioningStatusSuccess, CommissioningMode: CommissioningModeNetworkFormation, RemainingCommissioningModes: &RemainingCommissioningModes{FindingBinding: 1}}, "000408"},
+}
+
+func (s *MySuite) TestModelExamples(c *C) {
+	for _, example := range modelExamples {
+		payload, err := hex.DecodeString(example.payload)
+		c.Assert(err, IsNil)
+		c.Check(hex.EncodeToString(bin.Encode(example.value)), Equals, example.payload,
+			Commentf("encoding %T", example.value))
+		decoded := reflect.New(reflect.TypeOf(example.value).Elem()).Interface()
+		bin.Decode(payload, decoded)
+		c.Check(decoded, DeepEquals, example.value, Commentf("decoding %T", example.value))
+	}
+}
+
+//modelSamples holds a value of every model, with every field set
+var modelSamples = []interface{}{
+	&StatusResponse{Status: 1},
+	&AfRegister{EndPoint: 1, AppProfID: 2, AppDeviceID: 3, AddDevVer: 4, LatencyReq: 5, AppInClusterList: []uint16{6, 7}, AppOutClusterList: []uint16{7, 8}},
+	&AfDataRequestOptions{WildcardProfileID: 1, APSAck: 1, DiscoverRoute: 1, APSSecurity: 1, SkipRouting: 1},
+	&AfDataRequest{DstAddr: "0x0101", DstEndpoint: 2, SrcEndpoint: 3, ClusterID: 4, TransID: 5, Options: &AfDataRequestOptions{WildcardProfileID: 1, APSAck: 1, DiscoverRoute: 1, APSSecurity: 1, SkipRouting: 1}, Radius: 7, Data: []uint8{8, 9}},
+	&AfDataRequestExt{DstAddrMode: 1, DstAddr: "0x0202020202020202", DstEndpoint: 3, DstPanID: 4, SrcEndpoint: 5, ClusterID: 6, TransID: 7, Options: &AfDataRequestOptions{WildcardProfileID: 1, APSAck: 1, DiscoverRoute: 1, APSSecurity: 1, SkipRouting: 1}, Radius: 9, Data: []uint8{10, 11}},
+	&AfDataRequestSrcRtgOptions{APSAck: 1, APSSecurity: 1, SkipRouting: 1},
+	&AfDataRequestSrcRtg{DstAddr: "0x0101", DstEndpoint: 2, SrcEndpoint: 3, ClusterID: 4, TransID: 5, Options: &AfDataRequestSrcRtgOptions{APSAck: 1, APSSecurity: 1, SkipRouting: 1}, Radius: 7, RelayList: []string{"0x0808", "0x0808"}, Data: []uint8{9, 10}},
+	&AfInterPanClrData{},
+	&AfInterPanSetData{Channel: 1},
+	&AfInterPanRegData{Endpoint: 1},
+	&AfInterPanChkData{PanID: 1, Endpoint: 2},
+	&AfDataRetrieve{Timestamp: 1, Index: 2, Length: 3},
+	&AfDataRetrieveResponse{Status: 1, Data: []uint8{2, 3}},
+	&AfApsfConfigSet{Endpoint: 1, FrameDelay: 2, WindowSize: 3},
+	&AfDataConfirm{Status: 1, Endpoint: 2, TransID: 3},
+	&AfReflectError{Status: 1, Endpoint: 2, TransID: 3, DstAddrMode: 4, DstAddr: "0x0505"},
+	&AfIncomingMessage{GroupID: 1, ClusterID: 2, SrcAddr: "0x0303", SrcEndpoint: 4, DstEndpoint: 5, WasBroadcast: 6, LinkQuality: 7, SecurityUse: 8, Timestamp: 9, TransSeqNumber: 10, Data: []uint8{11, 12}},
+	&AfDataStore{Index: 1, Data: []uint8{2, 3}},
+	&AfIncomingMessageExt{GroupID: 1, ClusterID: 2, SrcAddrMode: 3, SrcAddr: "0x0404040404040404", SrcEndpoint: 5, SrcPanID: 6, DstEndpoint: 7, WasBroadcast: 8, LinkQuality: 9, SecurityUse: 10, Timestamp: 11, TransSeqNumber: 12, Data: []uint8{13, 14}},
+	&AppMsg{AppEndpoint: 1, DstAddr: "0x0202", DstEndpoint: 3, ClusterID: 4, Message: []uint8{5, 6}},
+	&AppUserTest{SrcEndpoint: 1, CommandID: 2, Parameter1: 3, Parameter2: 4},
+	&DebugSetThreshold{ComponentID: 1, Threshold: 2},
+	&DebugMsg{String: "0x"},
+	&NwkNldeDataReq{DstAddr: "0x0101", Nsdu: []uint8{2, 3}, NsduHandle: 3, NsduHandleOptions: 4, SecurityEnable: 5, DiscoverRoute: 6, RadiusCounter: 7},
+	&NwkNlmeNetworkFormationReq{PanID: 1, ChannelList: &Channels{Channel11: 1, Channel12: 1, Channel13: 1, Channel14: 1, Channel15: 1, Channel16: 1, Channel17: 1, Channel18: 1, Channel19: 1, Channel20: 1, Channel21: 1, Channel22: 1, Channel23: 1, Channel24: 1, Channel25: 1, Channel26: 1}, ScanDuration: 3, BeaconOrder: 4, SuperframeOrder: 5, BatteryLifeExtension: 6},
+	&NwkNlmePermitJoiningReq{PermitDuration: 1},
+	&NwkNlmeJoinReq{ExtendedPanID: "0x0101010101010101", PanID: 2, Channel: 3, CapabilityInformation: &CapInfo{AlternatePANCoordinator: 1, Router: 1, MainPowered: 1, ReceiverOnWhenIdle: 1, Reserved1: 1, Reserved2: 1, Security: 1, AllocAddr: 1}},
+	&NwkNlmeLeaveReq{ExtendedAddress: "0x0101010101010101", RemoveChildren: 2, Rejoin: 3},
+	&NwkNlmeRouteDiscoveryReq{DstAddr: "0x0101", Options: 2, Radius: 3},
+	&NwkNlmeNetworkDiscoveryReq{ScanChannels: &Channels{Channel11: 1, Channel12: 1, Channel13: 1, Channel14: 1, Channel15: 1, Channel16: 1, Channel17: 1, Channel18: 1, Channel19: 1, Channel20: 1, Channel21: 1, Channel22: 1, Channel23: 1, Channel24: 1, Channel25: 1, Channel26: 1}, ScanDuration: 2},
+	&NwkNldeDataCnf{NsduHandle: 1, Status: 2},
+	&NwkNldeDataInd{SrcAddr: "0x0101", Nsdu: []uint8{2, 3}, LinkQuality: 3},
+	&NwkNlmeNetworkFormationCnf{Status: 1},
+	&NwkNlmeJoinCnf{PanID: 1, Status: 2},
+	&NwkNlmeJoinInd{ShortAddr: "0x0101", ExtendedAddress: "0x0202020202020202", CapabilityInformation: &CapInfo{AlternatePANCoordinator: 1, Router: 1, MainPowered: 1, ReceiverOnWhenIdle: 1, Reserved1: 1, Reserved2: 1, Security: 1, AllocAddr: 1}},
+	&NwkNlmeLeaveCnf{ExtendedAddress: "0x0101010101010101", RemoveChildren: 2, Rejoin: 3, Status: 4},
+	&NwkNlmeLeaveInd{SrcAddr: "0x0101", ExtendedAddress: "0x0202020202020202", Request: 3, RemoveChildren: 4, Rejoin: 5},
+	&NwkNlmePollCnf{Status: 1},
+	&NwkNlmeSyncInd{},
+	&NetworkDescriptor{PanID: 1, LogicalChannel: 2, BeaconOrder: 3, SuperframeOrder: 4, RouterCapacity: 5, DeviceCapacity: 6, ProtocolVersion: 7, StackProfile: 8, ExtendedPanID: "0x0909090909090909"},
+	&NwkNlmeNetworkDiscoveryCnf{NetworkList: []*NetworkDescriptor{{PanID: 1, LogicalChannel: 2, BeaconOrder: 3, SuperframeOrder: 4, RouterCapacity: 5, DeviceCapacity: 6, ProtocolVersion: 7, StackProfile: 8, ExtendedPanID: "0x0909090909090909"}}},
+	&EmptyResponse{},
+	&SapiZbPermitJoiningRequest{Destination: "0x0101", Timeout: 2},
+	&SapiZbBindDevice{Create: 1, CommandID: 2, Destination: "0x0303030303030303"},
+	&SapiZbAllowBind{Timeout: 1},
+	&SapiZbSendDataRequest{Destination: "0x0101", CommandID: 2, Handle: 3, Ack: 4, Radius: 5, Data: []uint8{6, 7}},
+	&SapiZbReadConfiguration{ConfigID: 1},
+	&SapiZbReadConfigurationResponse{Status: 1, ConfigID: 2, Value: []uint8{3, 4}},
+	&SapiZbWriteConfiguration{ConfigID: 1, Value: []uint8{2, 3}},
+	&SapiZbGetDeviceInfo{Param: 1},
+	&SapiZbGetDeviceInfoResponse{Param: 1, Value: 2},
+	&SapiZbFindDeviceRequest{SearchKey: "0x0101010101010101"},
+	&SapiZbStartConfirm{Status: 1},
+	&SapiZbBindConfirm{CommandID: 1, Status: 2},
+	&SapiZbAllowBindConfirm{Source: "0x0101"},
+	&SapiZbSendDataConfirm{Handle: 1, Status: 2},
+	&SapiZbReceiveDataIndication{Source: "0x0101", CommandID: 2, Data: []uint8{3, 4}},
+	&SapiZbFindDeviceConfirm{SearchType: 1, Result: "0x0202", SearchKey: "0x0303030303030303"},
+	&SysResetReq{ResetType: 1},
+	&Capabilities{Sys: 1, Mac: 1, Nwk: 1, Af: 1, Zdo: 1, Sapi: 1, Util: 1, Debug: 1, App: 1, Zoad: 1},
+	&SysPingResponse{Capabilities: &Capabilities{Sys: 1, Mac: 1, Nwk: 1, Af: 1, Zdo: 1, Sapi: 1, Util: 1, Debug: 1, App: 1, Zoad: 1}},
+	&SysVersionResponse{TransportRev: 1, Product: 2, MajorRel: 3, MinorRel: 4, MaintRel: 5, Revision: 6},
+	&SysSetExtAddr{ExtAddress: "0x0101010101010101"},
+	&SysGetExtAddrResponse{ExtAddress: "0x0101010101010101"},
+	&SysRamRead{Address: 1, Len: 2},
+	&SysRamReadResponse{Status: 1, Value: []uint8{2, 3}},
+	&SysRamWrite{Address: 1, Value: []uint8{2, 3}},
+	&SysOsalNvRead{ID: 1, Offset: 2},
+	&SysOsalNvReadResponse{Status: 1, Value: []uint8{2, 3}},
+	&SysOsalNvWrite{ID: 1, Offset: 2, Value: []uint8{3, 4}},
+	&SysOsalNvItemInit{ID: 1, ItemLen: 2, InitData: []uint8{3, 4}},
+	&SysOsalNvDelete{ID: 1, ItemLen: 2},
+	&SysOsalNvLength{ID: 1},
+	&SysOsalNvLengthResponse{Length: 1},
+	&SysOsalStartTimer{ID: 1, Timeout: 2},
+	&SysOsalStopTimer{ID: 1},
+	&SysRandomResponse{Value: 1},
+	&SysAdcRead{Channel: 1, Resolution: 2},
+	&SysAdcReadResponse{Value: 1},
+	&SysGpio{Operation: 1, Value: 2},
+	&SysGpioResponse{Value: 1},
+	&SysTime{UTCTime: 1, Hour: 2, Minute: 3, Second: 4, Month: 5, Day: 6, Year: 7},
+	&SysSetTxPower{TXPower: 1},
+	&SysSetTxPowerResponse{TXPower: 1},
+	&SysZDiagsClearStats{ClearNV: 1},
+	&SysZDiagsClearStatsResponse{SysClock: 1},
+	&SysZDiagsGetStats{AttributeID: 1},
+	&SysZDiagsGetStatsResponse{AttributeValue: 1},
+	&SysZDiagsSaveStatsToNvResponse{SysClock: 1},
+	&SysNvCreate{SysID: 1, ItemID: 2, SubID: 3, Length: 4},
+	&SysNvDelete{SysID: 1, ItemID: 2, SubID: 3},
+	&SysNvLength{SysID: 1, ItemID: 2, SubID: 3},
+	&SysNvLengthResponse{Length: 1},
+	&SysNvRead{SysID: 1, ItemID: 2, SubID: 3, Offset: 4, Length: 5},
+	&SysNvReadResponse{Status: 1, Value: []uint8{2, 3}},
+	&SysNvWrite{SysID: 1, ItemID: 2, SubID: 3, Offset: 4, Value: []uint8{5, 6}},
+	&SysNvUpdate{SysID: 1, ItemID: 2, SubID: 3, Value: []uint8{4, 5}},
+	&SysNvCompact{Threshold: 1},
+	&SysNvReadExt{ID: 1, Offset: 2},
+	&SysNvWriteExt{ID: 1, Offset: 2, Value: []uint8{3, 4}},
+	&SysResetInd{Reason: 1, TransportRev: 2, Product: 3, MinorRel: 4, HwRev: 5},
+	&SysOsalTimerExpired{ID: 1},
+	&DeviceType{Coordinator: 1, Router: 1, EndDevice: 1},
+	&UtilGetDeviceInfoResponse{Status: 1, IEEEAddr: "0x0202020202020202", ShortAddr: "0x0303", DeviceType: &DeviceType{Coordinator: 1, Router: 1, EndDevice: 1}, DeviceState: 5, AssocDevicesList: []string{"0x0606", "0x0606"}},
+	&NvInfoStatus{IEEEAddress: 1, ScanChannels: 1, PanID: 1, SecurityLevel: 1, PreConfigKey: 1},
+	&UtilGetNvInfoResponse{Status: &NvInfoStatus{IEEEAddress: 1, ScanChannels: 1, PanID: 1, SecurityLevel: 1, PreConfigKey: 1}, IEEEAddr: "0x0202020202020202", ScanChannels: 3, PanID: 4, SecurityLevel: 5, PreConfigKey: [16]uint8{6, 7}},
+	&UtilSetPanId{PanID: 1},
+	&UtilSetChannels{Channels: &Channels{Channel11: 1, Channel12: 1, Channel13: 1, Channel14: 1, Channel15: 1, Channel16: 1, Channel17: 1, Channel18: 1, Channel19: 1, Channel20: 1, Channel21: 1, Channel22: 1, Channel23: 1, Channel24: 1, Channel25: 1, Channel26: 1}},
+	&UtilSetSecLevel{SecLevel: 1},
+	&UtilSetPreCfgKey{PreCfgKey: [16]uint8{1, 2}},
+	&UtilCallbackSubCmd{SubsystemID: 1, Action: 2},
+	&Keys{Key1: 1, Key2: 1, Key3: 1, Key4: 1, Key5: 1, Key6: 1, Key7: 1, Key8: 1},
+	&UtilKeyEvent{Keys: &Keys{Key1: 1, Key2: 1, Key3: 1, Key4: 1, Key5: 1, Key6: 1, Key7: 1, Key8: 1}, Shift: 2},
+	&UtilTimeAliveResponse{Seconds: 1},
+	&UtilLedControl{LedID: 1, Mode: 2},
+	&UtilLoopback{Data: []uint8{1, 2}},
+	&UtilDataReq{SecurityUse: 1},
+	&UtilSrcMatchAddEntry{AddrMode: 1, Address: "0x0202020202020202", PanID: 3},
+	&UtilSrcMatchDelEntry{AddrMode: 1, Address: "0x0202020202020202", PanID: 3},
+	&UtilSrcMatchCheckSrcAddr{AddrMode: 1, Address: "0x0202020202020202", PanID: 3},
+	&UtilSrcMatchAckAllPending{Option: 1},
+	&UtilSrcMatchCheckAllPendingResponse{Status: 1, Value: 2},
+	&UtilAddrMgrExtAddrLookup{ExtAddr: "0x0101010101010101"},
+	&UtilAddrMgrExtAddrLookupResponse{NwkAddr: "0x0101"},
+	&UtilAddrMgrAddrLookup{NwkAddr: "0x0101"},
+	&UtilAddrMgrAddrLookupResponse{ExtAddr: "0x0101010101010101"},
+	&UtilApsmeLinkKeyDataGet{ExtAddr: "0x0101010101010101"},
+	&UtilApsmeLinkKeyDataGetResponse{Status: 1, SecKey: [16]uint8{2, 3}, TxFrmCntr: 3, RxFrmCntr: 4},
+	&UtilApsmeLinkKeyNvIdGet{ExtAddr: "0x0101010101010101"},
+	&UtilApsmeLinkKeyNvIdGetResponse{Status: 1, LinkKeyNvId: 2},
+	&UtilApsmeRequestKeyCmd{PartnerAddr: "0x0101010101010101"},
+	&UtilAssocCount{StartRelation: 1, EndRelation: 2},
+	&UtilAssocCountResponse{Count: 1},
+	&LinkInfo{TxCounter: 1, TxCost: 2, RxLqi: 3, InKeySeqNum: 4, InFrmCntr: 5, TxFailure: 6},
+	&AgingEndDevice{EndDevCfg: 1, DeviceTimeout: 2},
+	&Device{ShortAddr: "0x0101", AddrIdx: 2, NodeRelation: 3, DevStatus: 4, AssocCnt: 5, Age: 6, LinkInfo: &LinkInfo{TxCounter: 1, TxCost: 2, RxLqi: 3, InKeySeqNum: 4, InFrmCntr: 5, TxFailure: 6}, EndDev: &AgingEndDevice{EndDevCfg: 1, DeviceTimeout: 2}, TimeoutCounter: 9, KeepaliveRcv: 10},
+	&UtilAssocFindDevice{Number: 1},
+	&UtilAssocFindDeviceResponse{Device: &Device{ShortAddr: "0x0101", AddrIdx: 2, NodeRelation: 3, DevStatus: 4, AssocCnt: 5, Age: 6, LinkInfo: &LinkInfo{TxCounter: 1, TxCost: 2, RxLqi: 3, InKeySeqNum: 4, InFrmCntr: 5, TxFailure: 6}, EndDev: &AgingEndDevice{EndDevCfg: 1, DeviceTimeout: 2}, TimeoutCounter: 9, KeepaliveRcv: 10}},
+	&UtilAssocGetWithAddr{ExtAddr: "0x0101010101010101", NwkAddr: "0x0202"},
+	&UtilAssocGetWithAddrResponse{Device: &Device{ShortAddr: "0x0101", AddrIdx: 2, NodeRelation: 3, DevStatus: 4, AssocCnt: 5, Age: 6, LinkInfo: &LinkInfo{TxCounter: 1, TxCost: 2, RxLqi: 3, InKeySeqNum: 4, InFrmCntr: 5, TxFailure: 6}, EndDev: &AgingEndDevice{EndDevCfg: 1, DeviceTimeout: 2}, TimeoutCounter: 9, KeepaliveRcv: 10}},
+	&UtilBindAddEntry{AddrMode: 1, DstAddr: "0x0202020202020202", DstEndpoint: 3, ClusterIDs: []uint16{4, 5}},
+	&BindEntry{SrcEP: 1, DstGroupMode: 2, DstIdx: 3, DstEP: 4, ClusterIDList: []uint16{5, 6}},
+	&UtilBindAddEntryResponse{BindEntry: &BindEntry{SrcEP: 1, DstGroupMode: 2, DstIdx: 3, DstEP: 4, ClusterIDList: []uint16{5, 6}}},
+	&UtilZclKeyEstInitEst{TaskID: 1, SeqNum: 2, EndPoint: 3, AddrMode: 4, Addr: "0x0505050505050505"},
+	&UtilZclKeyEstSign{Input: []uint8{1, 2}},
+	&UtilZclKeyEstSignResponse{Status: 1, Key: [42]uint8{2, 3}},
+	&UtilSrngGenResponse{SecureRandomNumbers: [100]uint8{1, 2}},
+	&UtilSyncReq{},
+	&UtilZclKeyEstablishInd{TaskId: 1, Event: 2, Status: 3, WaitTime: 4, Suite: 5},
+	&ZdoNwkAddrReq{IEEEAddress: "0x0101010101010101", ReqType: 2, StartIndex: 3},
+	&ZdoIeeeAddrReq{ShortAddr: "0x0101", ReqType: 2, StartIndex: 3},
+	&ZdoNodeDescReq{DstAddr: "0x0101", NWKAddrOfInterest: "0x0202"},
+	&ZdoPowerDescReq{DstAddr: "0x0101", NWKAddrOfInterest: "0x0202"},
+	&ZdoUserDescReq{DstAddr: "0x0101", NWKAddrOfInterest: "0x0202"},
+	&ZdoComplexDescReq{DstAddr: "0x0101", NWKAddrOfInterest: "0x0202"},
+	&ZdoMatchDescReq{DstAddr: "0x0101", NWKAddrOfInterest: "0x0202", ProfileID: 3, InClusterList: []uint16{4, 5}, OutClusterList: []uint16{5, 6}},
+	&ZdoSimpleDescReq{DstAddr: "0x0101", NWKAddrOfInterest: "0x0202", Endpoint: 3},
+	&ZdoActiveEpReq{DstAddr: "0x0101", NWKAddrOfInterest: "0x0202"},
+	&CapInfo{AlternatePANCoordinator: 1, Router: 1, MainPowered: 1, ReceiverOnWhenIdle: 1, Reserved1: 1, Reserved2: 1, Security: 1, AllocAddr: 1},
+	&ZdoEndDeviceAnnce{NwkAddr: "0x0101", IEEEAddr: "0x0202020202020202", Capabilities: &CapInfo{AlternatePANCoordinator: 1, Router: 1, MainPowered: 1, ReceiverOnWhenIdle: 1, Reserved1: 1, Reserved2: 1, Security: 1, AllocAddr: 1}},
+	&ZdoUserDescSet{DstAddr: "0x0101", NWKAddrOfInterest: "0x0202", UserDescriptor: "0x"},
+	&ServerMask{PrimTrustCenter: 1, BkupTrustCenter: 1, PrimBindTable: 1, BkupBindTable: 1, PrimDiscTable: 1, BkupDiscTable: 1, NetworkManager: 1},
+	&ZdoServerDiscReq{ServerMask: &ServerMask{PrimTrustCenter: 1, BkupTrustCenter: 1, PrimBindTable: 1, BkupBindTable: 1, PrimDiscTable: 1, BkupDiscTable: 1, NetworkManager: 1}},
+	&ZdoEndDeviceBindReq{DstAddr: "0x0101", LocalCoordinatorAddr: "0x0202", IEEEAddr: "0x0303030303030303", Endpoint: 4, ProfileID: 5, InClusterList: []uint16{6, 7}, OutClusterList: []uint16{7, 8}},
+	&ZdoBindUnbindReq{DstAddr: "0x0101", SrcAddress: "0x0202020202020202", SrcEndpoint: 3, ClusterID: 4, DstAddrMode: 5, DstAddress: "0x0606060606060606", DstEndpoint: 7},
+	&Channels{Channel11: 1, Channel12: 1, Channel13: 1, Channel14: 1, Channel15: 1, Channel16: 1, Channel17: 1, Channel18: 1, Channel19: 1, Channel20: 1, Channel21: 1, Channel22: 1, Channel23: 1, Channel24: 1, Channel25: 1, Channel26: 1},
+	&ZdoMgmtNwkDiskReq{DstAddr: "0x0101", ScanChannels: &Channels{Channel11: 1, Channel12: 1, Channel13: 1, Channel14: 1, Channel15: 1, Channel16: 1, Channel17: 1, Channel18: 1, Channel19: 1, Channel20: 1, Channel21: 1, Channel22: 1, Channel23: 1, Channel24: 1, Channel25: 1, Channel26: 1}, ScanDuration: 3, StartIndex: 4},
+	&ZdoMgmtLqiReq{DstAddr: "0x0101", StartIndex: 2},
+	&ZdoMgmtRtgReq{DstAddr: "0x0101", StartIndex: 2},
+	&ZdoMgmtBindReq{DstAddr: "0x0101", StartIndex: 2},
+	&RemoveChildrenRejoin{Rejoin: 1, RemoveChildren: 1},
+	&ZdoMgmtLeaveReq{DstAddr: "0x0101", DeviceAddr: "0x0202020202020202", RemoveChildrenRejoin: &RemoveChildrenRejoin{Rejoin: 1, RemoveChildren: 1}},
+	&ZdoMgmtDirectJoinReq{DstAddr: "0x0101", DeviceAddr: "0x0202020202020202", CapInfo: &CapInfo{AlternatePANCoordinator: 1, Router: 1, MainPowered: 1, ReceiverOnWhenIdle: 1, Reserved1: 1, Reserved2: 1, Security: 1, AllocAddr: 1}},
+	&ZdoMgmtPermitJoinReq{AddrMode: 1, DstAddr: "0x0202", Duration: 3, TCSignificance: 4},
+	&ZdoMgmtNwkUpdateReq{DstAddr: "0x0101", DstAddrMode: 2, ChannelMask: &Channels{Channel11: 1, Channel12: 1, Channel13: 1, Channel14: 1, Channel15: 1, Channel16: 1, Channel17: 1, Channel18: 1, Channel19: 1, Channel20: 1, Channel21: 1, Channel22: 1, Channel23: 1, Channel24: 1, Channel25: 1, Channel26: 1}, ScanDuration: 4, ScanCount: 5, NwkManagerAddr: "0x0606"},
+	&ZdoMsgCbRegister{ClusterID: 1},
+	&ZdoMsgCbRemove{ClusterID: 1},
+	&ZdoStartupFromApp{StartDelay: 1},
+	&ZdoStartupFromAppResponse{Status: 1},
+	&ZdoSetLinkKey{ShortAddr: "0x0101", IEEEAddr: "0x0202020202020202", LinkKeyData: [16]uint8{3, 4}},
+	&ZdoRemoveLinkKey{IEEEAddr: "0x0101010101010101"},
+	&ZdoGetLinkKey{IEEEAddr: "0x0101010101010101"},
+	&ZdoGetLinkKeyResponse{Status: 1, IEEEAddr: "0x0202020202020202", LinkKeyData: [16]uint8{3, 4}},
+	&ZdoNwkDiscoveryReq{ScanChannels: &Channels{Channel11: 1, Channel12: 1, Channel13: 1, Channel14: 1, Channel15: 1, Channel16: 1, Channel17: 1, Channel18: 1, Channel19: 1, Channel20: 1, Channel21: 1, Channel22: 1, Channel23: 1, Channel24: 1, Channel25: 1, Channel26: 1}, ScanDuration: 2},
+	&ZdoJoinReq{LogicalChannel: 1, PanID: 2, ExtendedPanID: 3, ChosenParent: "0x0404", ParentDepth: 5, StackProfile: 6},
+	&ZdoSetRejoinParameters{BackoffDuration: 1, ScanDuration: 2},
+	&ZdoSecAddLinkKey{ShortAddress: "0x0101", ExtendedAddress: "0x0202020202020202", Key: [16]uint8{3, 4}},
+	&ZdoSecEntryLookupExt{ExtendedAddress: "0x0101010101010101", Entry: [5]uint8{2, 3}},
+	&ZdoSecEntryLookupExtResponse{AMI: 1, KeyNVID: 2, AuthenticationOption: 3},
+	&ZdoSecDeviceRemove{ExtendedAddress: "0x0101010101010101"},
+	&ZdoExtRouteDisc{DestinationAddress: "0x0101", Options: 2, Radius: 3},
+	&ZdoExtRouteCheck{DestinationAddress: "0x0101", RTStatus: 2, Options: 3},
+	&ZdoExtRemoveGroup{Endpoint: 1, GroupID: 2},
+	&ZdoExtRemoveAllGroup{Endpoint: 1},
+	&ZdoExtFindAllGroupsEndpoint{Endpoint: 1, GroupList: []uint16{2, 3}},
+	&ZdoExtFindAllGroupsEndpointResponse{Groups: []uint16{1, 2}},
+	&ZdoExtFindGroup{Endpoint: 1, GroupID: 2},
+	&ZdoExtFindGroupResponse{Status: 1, GroupID: 2, Name: "0x"},
+	&ZdoExtAddGroup{Endpoint: 1, GroupID: 2, GroupName: "0x"},
+	&ZdoExtCountAllGroupsResponse{Count: 1},
+	&ZdoExtRxIdle{SetFlag: 1, SetValue: 2},
+	&ZdoExtUpdateNwkKey{DestinationAddress: "0x0101", KeySeqNum: 2, Key: [16]uint8{3, 4}},
+	&ZdoExtSwitchNwkKey{DestinationAddress: "0x0101", KeySeqNum: 2},
+	&ZdoExtNwkInfoResponse{ShortAddress: "0x0101", PanID: 2, ParentAddress: "0x0303", ExtendedPanID: 4, ExtendedParentAddress: "0x0505050505050505", Channel: 6},
+	&ZdoExtSeqApsRemoveReq{NwkAddress: "0x0101", ExtendedAddress: "0x0202020202020202", ParentAddress: "0x0303"},
+	&ZdoExtSetParams{UseMulticast: 1},
+	&ZdoNwkAddrOfInterestReq{DestAddr: "0x0101", NwkAddrOfInterest: "0x0202", Cmd: 3},
+	&ZdoNwkAddrRsp{Status: 1, IEEEAddr: "0x0202020202020202", NwkAddr: "0x0303", StartIndex: 4, AssocDevList: []string{"0x0505", "0x0505"}},
+	&ZdoIEEEAddrRsp{Status: 1, IEEEAddr: "0x0202020202020202", NwkAddr: "0x0303", StartIndex: 4, AssocDevList: []string{"0x0505", "0x0505"}},
+	&ZdoNodeDescRsp{SrcAddr: "0x0101", Status: 2, NWKAddrOfInterest: "0x0303", LogicalType: 1, ComplexDescriptorAvailable: 1, UserDescriptorAvailable: 1, APSFlags: 1, FrequencyBand: 1, MacCapabilitiesFlags: &CapInfo{AlternatePANCoordinator: 1, Router: 1, MainPowered: 1, ReceiverOnWhenIdle: 1, Reserved1: 1, Reserved2: 1, Security: 1, AllocAddr: 1}, ManufacturerCode: 10, MaxBufferSize: 11, MaxInTransferSize: 12, ServerMask: &ServerMask{PrimTrustCenter: 1, BkupTrustCenter: 1, PrimBindTable: 1, BkupBindTable: 1, PrimDiscTable: 1, BkupDiscTable: 1, NetworkManager: 1}, MaxOutTransferSize: 14, DescriptorCapabilities: 15},
+	&ZdoPowerDescRsp{SrcAddr: "0x0101", Status: 2, NWKAddr: "0x0303", CurrentPowerMode: 1, AvailablePowerSources: 1, CurrentPowerSource: 1, CurrentPowerSourceLevel: 1},
+	&ZdoSimpleDescRsp{SrcAddr: "0x0101", Status: 2, NWKAddr: "0x0303", Len: 4, Endpoint: 5, ProfileID: 6, DeviceID: 7, DeviceVersion: 8, InClusterList: []uint16{9, 10}, OutClusterList: []uint16{10, 11}},
+	&ZdoActiveEpRsp{SrcAddr: "0x0101", Status: 2, NWKAddr: "0x0303", ActiveEPList: []uint8{4, 5}},
+	&ZdoMatchDescRsp{SrcAddr: "0x0101", Status: 2, NWKAddr: "0x0303", MatchList: []uint8{4, 5}},
+	&ZdoComplexDescRsp{SrcAddr: "0x0101", Status: 2, NWKAddr: "0x0303", ComplexDescriptor: "0x"},
+	&ZdoUserDescRsp{SrcAddr: "0x0101", Status: 2, NWKAddr: "0x0303", UserDescriptor: "0x"},
+	&ZdoUserDescConf{SrcAddr: "0x0101", Status: 2, NWKAddr: "0x0303"},
+	&ZdoServerDiscRsp{SrcAddr: "0x0101", Status: 2, ServerMask: &ServerMask{PrimTrustCenter: 1, BkupTrustCenter: 1, PrimBindTable: 1, BkupBindTable: 1, PrimDiscTable: 1, BkupDiscTable: 1, NetworkManager: 1}},
+	&ZdoEndDeviceBindRsp{SrcAddr: "0x0101", Status: 2},
+	&ZdoBindRsp{SrcAddr: "0x0101", Status: 2},
+	&ZdoUnbindRsp{SrcAddr: "0x0101", Status: 2},
+	&Network{PanID: 1, LogicalChannel: 2, StackProfile: 1, ZigbeeVersion: 1, BeaconOrder: 1, SuperFrameOrder: 1, PermitJoin: 7},
+	&ZdoMgmtNwkDiscRsp{SrcAddr: "0x0101", Status: 2, NetworkCount: 3, StartIndex: 4, NetworkList: []*Network{{PanID: 1, LogicalChannel: 2, StackProfile: 1, ZigbeeVersion: 1, BeaconOrder: 1, SuperFrameOrder: 1, PermitJoin: 7}}},
+	&NeighborLqi{ExtendedPanID: 1, ExtendedAddress: "0x0202020202020202", NetworkAddress: "0x0303", DeviceType: 1, RxOnWhenIdle: 1, Relationship: 1, PermitJoining: 7, Depth: 8, LQI: 9},
+	&ZdoMgmtLqiRsp{SrcAddr: "0x0101", Status: 2, NeighborTableEntries: 3, StartIndex: 4, NeighborLqiList: []*NeighborLqi{{ExtendedPanID: 1, ExtendedAddress: "0x0202020202020202", NetworkAddress: "0x0303", DeviceType: 1, RxOnWhenIdle: 1, Relationship: 1, PermitJoining: 7, Depth: 8, LQI: 9}}},
+	&Route{DestinationAddress: "0x0101", Status: 2, NextHop: "0x0303"},
+	&ZdoMgmtRtgRsp{SrcAddr: "0x0101", Status: 2, RoutingTableEntries: 3, StartIndex: 4, RoutingTable: []*Route{{DestinationAddress: "0x0101", Status: 2, NextHop: "0x0303"}}},
+	&Addr{AddrMode: 3, ExtendedAddr: "0x0303030303030303", DstEndpoint: 4},
+	&Binding{SrcAddr: "0x0101010101010101", SrcEndpoint: 2, ClusterID: 3, DstAddr: &Addr{AddrMode: 3, ExtendedAddr: "0x0303030303030303", DstEndpoint: 4}},
+	&ZdoMgmtBindRsp{SrcAddr: "0x0101", Status: 2, BindTableEntries: 3, StartIndex: 4, BindTable: []*Binding{{SrcAddr: "0x0101010101010101", SrcEndpoint: 2, ClusterID: 3, DstAddr: &Addr{AddrMode: 3, ExtendedAddr: "0x0303030303030303", DstEndpoint: 4}}}},
+	&ZdoMgmtLeaveRsp{SrcAddr: "0x0101", Status: 2},
+	&ZdoMgmtDirectJoinRsp{SrcAddr: "0x0101", Status: 2},
+	&ZdoMgmtPermitJoinRsp{SrcAddr: "0x0101", Status: 2},
+	&ZdoMgmtNwkUpdateNotify{SrcAddr: "0x0101", Status: 2, ScannedChannels: &Channels{Channel11: 1, Channel12: 1, Channel13: 1, Channel14: 1, Channel15: 1, Channel16: 1, Channel17: 1, Channel18: 1, Channel19: 1, Channel20: 1, Channel21: 1, Channel22: 1, Channel23: 1, Channel24: 1, Channel25: 1, Channel26: 1}, TotalTransmissions: 4, TransmissionFailures: 5, EnergyValues: []uint8{6, 7}},
+	&ZdoStateChangeInd{State: 1},
+	&ZdoEndDeviceAnnceInd{SrcAddr: "0x0101", NwkAddr: "0x0202", IEEEAddr: "0x0303030303030303", Capabilities: &CapInfo{AlternatePANCoordinator: 1, Router: 1, MainPowered: 1, ReceiverOnWhenIdle: 1, Reserved1: 1, Reserved2: 1, Security: 1, AllocAddr: 1}},
+	&ZdoMatchDescRpsSent{NwkAddr: "0x0101", InClusterList: []uint16{2, 3}, OutClusterList: []uint16{3, 4}},
+	&ZdoStatusErrorRsp{SrcAddr: "0x0101", Status: 2},
+	&ZdoSrcRtgInd{DstAddr: "0x0101", RelayList: []string{"0x0202", "0x0202"}},
+	&Beacon{SrcAddr: "0x0101", PanID: 2, LogicalChannel: 3, PermitJoining: 4, RouterCapacity: 5, DeviceCapacity: 6, ProtocolVersion: 7, StackProfile: 8, LQI: 9, Depth: 10, UpdateID: 11, ExtendedPanID: 12},
+	&ZdoBeaconNotifyInd{BeaconList: []*Beacon{{SrcAddr: "0x0101", PanID: 2, LogicalChannel: 3, PermitJoining: 4, RouterCapacity: 5, DeviceCapacity: 6, ProtocolVersion: 7, StackProfile: 8, LQI: 9, Depth: 10, UpdateID: 11, ExtendedPanID: 12}}},
+	&ZdoJoinCnf{Status: 1, DeviceAddress: "0x0202", ParentAddress: "0x0303"},
+	&ZdoNwkDiscoveryCnf{Status: 1},
+	&ZdoLeaveInd{SrcAddr: "0x0101", ExtAddr: "0x0202020202020202", Request: 3, Remove: 4, Rejoin: 5},
+	&ZdoMsgCbIncoming{SrcAddr: "0x0101", WasBroadcast: 2, ClusterID: 3, SecurityUse: 4, SeqNum: 5, MacDstAddr: "0x0606", Data: []uint8{7, 8}},
+	&ZdoTcDevInd{SrcNwkAddr: "0x0101", SrcIEEEAddr: "0x0202020202020202", ParentNwkAddr: "0x0303"},
+	&ZdoPermitJoinInd{PermitJoinDuration: 1},
+	&AppCnfSetNwkFrameCounter{FrameCounterValue: 1},
+	&AppCnfSetDefaultEndDeviceTimeout{Timeout: 1},
+	&AppCnfSetEndDeviceTimeout{Timeout: 1},
+	&AppCnfSetAllowRejoinTcPolicy{AllowRejoin: 1},
+	&AppCnfBdbStartCommissioning{CommissioningMode: 1},
+	&AppCnfBdbSetChannel{IsPrimary: 1, Channel: &Channels{Channel11: 1, Channel12: 1, Channel13: 1, Channel14: 1, Channel15: 1, Channel16: 1, Channel17: 1, Channel18: 1, Channel19: 1, Channel20: 1, Channel21: 1, Channel22: 1, Channel23: 1, Channel24: 1, Channel25: 1, Channel26: 1}},
+	&AppCnfBdbAddInstallCode{InstallCodeFormat: 1, IEEEAddr: "0x0202020202020202", InstallCode: []uint8{3, 4}},
+	&AppCnfBdbSetTcRequireKeyExchange{BdbTrustCenterRequireKeyExchange: 1},
+	&AppCnfBdbSetJoinUsesInstallCodeKey{BdbJoinUsesInstallCodeKey: 1},
+	&AppCnfBdbSetActiveDefaultCentralizedKey{UseGlobal: 1, InstallCode: [18]uint8{2, 3}},
+	&RemainingCommissioningModes{InitiatorTl: 1, NwkSteering: 1, NwkFormation: 1, FindingBinding: 1, Initialization: 1, ParentLost: 1},
+	&AppCnfBdbCommissioningNotification{CommissioningStatus: 1, CommissioningMode: 2, RemainingCommissioningModes: &RemainingCommissioningModes{InitiatorTl: 1, NwkSteering: 1, NwkFormation: 1, FindingBinding: 1, Initialization: 1, ParentLost: 1}},
+	&TxOptions{UseGpTxQueue: 1, UseCSMAorCA: 1, UseMacAck: 1, GPDFFrameTypeForTx: 1, TxOnMatchingEndpoint: 1},
+	&GpDataReq{Action: 1, TxOptions: &TxOptions{UseGpTxQueue: 1, UseCSMAorCA: 1, UseMacAck: 1, GPDFFrameTypeForTx: 1, TxOnMatchingEndpoint: 1}, ApplicationID: 3, SrcID: 4, GPDIEEEAddress: "0x0505050505050505", Endpoint: 6, GPDCommandID: 7, GPDASDU: []uint8{8, 9}, GPEPHandle: 9, GPTxQueueEntryLifetime: 10},
+	&GpSecRsp{Status: 1, DGPStubHandle: 2, ApplicationID: 3, SrcID: 4, GPDIEEEAddress: "0x0505050505050505", Endpoint: 6, GPDFSecurityLevel: 7, GPDFKeyType: 8, GPDKey: [16]uint8{9, 10}, GPDSecurityFrameCounter: 10},
+	&GpDataCnf{Status: 1, GPMPDUHandle: 2},
+	&GpSecReq{ApplicationID: 1, SrcID: 2, GPDIEEEAddress: "0x0303030303030303", Endpoint: 4, GPDFSecurityLevel: 5, GPDFKeyType: 6, GPDSecurityFrameCounter: 7, DGPStubHandle: 8},
+	&GpDataInd{Status: 1, RSSI: 2, LinkQuality: 3, SeqNumber: 4, SrcAddrMode: 5, SrcPANId: 6, SrcAddress: "0x0707070707070707", DstAddrMode: 8, DstPANId: 9, DstAddress: "0x0a0a0a0a0a0a0a0a", GPMPDU: []uint8{11, 12}},
+}
+
+func (s *MySuite) TestModelRoundTrips(c *C) {
+	for _, value := range modelSamples {
+		decoded := reflect.New(reflect.TypeOf(value).Elem()).Interface()
+		bin.Decode(bin.Encode(value), decoded)
+		c.Check(decoded, DeepEquals, value, Commentf("%T", value))
+	}
+}
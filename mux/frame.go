@@ -0,0 +1,66 @@
+package mux
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	unp "github.com/dyrkin/unp-go"
+)
+
+const sof byte = 0xFE
+
+var errChecksum = errors.New("invalid checksum")
+
+//readFrame reads a frame with one byte length field from a client connection. Unlike unp.Unp it doesn't start a
+//goroutine per connection, which would outlive the connection.
+func readFrame(r *bufio.Reader) (*unp.Frame, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if b == sof {
+			break
+		}
+	}
+	header := make([]byte, 3)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	payload := make([]byte, header[0])
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+	fcs, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if checksum(header, payload) != fcs {
+		return nil, errChecksum
+	}
+	return &unp.Frame{
+		CommandType: unp.CommandType(header[1] >> 5),
+		Subsystem:   unp.Subsystem(header[1] & 0x1F),
+		Command:     header[2],
+		Payload:     payload,
+	}, nil
+}
+
+func renderFrame(frame *unp.Frame) []byte {
+	header := []byte{uint8(len(frame.Payload)), byte(frame.CommandType)<<5&0xE0 | byte(frame.Subsystem)&0x1F, frame.Command}
+	rendered := append([]byte{sof}, header...)
+	rendered = append(rendered, frame.Payload...)
+	return append(rendered, checksum(header, frame.Payload))
+}
+
+func checksum(header []byte, payload []byte) byte {
+	fcs := byte(0)
+	for _, b := range header {
+		fcs ^= b
+	}
+	for _, b := range payload {
+		fcs ^= b
+	}
+	return fcs
+}
@@ -0,0 +1,319 @@
+//Package mux shares one adapter between several clients.
+//
+//Every client talks the regular MT protocol, so a client is usually a Znp created on top of a connection returned by
+//Mux.Connect or accepted by Mux.Serve. Synchronous requests of all clients are serialized: the next request is sent to
+//the adapter only after the previous one got its response. Async commands are delivered to every client.
+//
+//The transaction ID of AF data requests is remapped to a free one of the range owned by the client, so that the
+//matching AfDataConfirm and AfReflectError are delivered only to the client which sent the request, with its original
+//transaction ID. A request is rejected when all the transaction IDs of the range await their confirmation.
+//
+//Requests which can't be sent to the adapter are answered with an RPC error, code RPCErrUnavailable, instead of
+//leaving the client waiting for a response.
+package mux
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	unp "github.com/dyrkin/unp-go"
+)
+
+//ErrTooManyClients is returned when all transaction ID ranges are taken.
+var ErrTooManyClients = errors.New("mux: too many clients")
+
+//RPCErrUnavailable is the error code of the RPC errors sent back for requests which couldn't be sent to the adapter,
+//or which got no free transaction ID.
+const RPCErrUnavailable = 0x80
+
+//Mux multiplexes one adapter between several clients.
+type Mux struct {
+	u         *unp.Unp
+	rangeSize int
+	requests  chan *request
+	srsp      chan *unp.Frame
+
+	mu      sync.Mutex
+	clients []*client //indexed by transaction ID range
+	started bool
+}
+
+type request struct {
+	client  *client
+	frame   *unp.Frame
+	transID int //transaction ID taken from the range of the client, -1 when none
+}
+
+type client struct {
+	mux   *Mux
+	conn  io.ReadWriteCloser
+	index int
+
+	mu       sync.Mutex
+	transIDs map[uint8]uint8 //transaction ID sent to the adapter -> transaction ID used by the client
+	next     int             //position in the range the search for a free transaction ID starts at
+}
+
+//New creates a multiplexer for up to maxClients simultaneous clients. Every client gets 256/maxClients
+//transaction IDs.
+func New(u *unp.Unp, maxClients int) *Mux {
+	if maxClients < 1 || maxClients > 256 {
+		panic("mux: maxClients must be between 1 and 256")
+	}
+	return &Mux{
+		u:         u,
+		rangeSize: 256 / maxClients,
+		requests:  make(chan *request),
+		srsp:      make(chan *unp.Frame, 1),
+		clients:   make([]*client, maxClients),
+	}
+}
+
+func (m *Mux) Start() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.started {
+		return
+	}
+	m.started = true
+	go m.adapterLoop()
+	go m.requestLoop()
+}
+
+//Connect creates an in-process client. Pass the returned connection to unp.New.
+func (m *Mux) Connect() (io.ReadWriteCloser, error) {
+	local, remote := net.Pipe()
+	c, err := m.add(remote)
+	if err != nil {
+		return nil, err
+	}
+	go c.serve()
+	return local, nil
+}
+
+//Serve accepts clients on the listener until it fails. Connections above the client limit are closed immediately.
+func (m *Mux) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		c, err := m.add(conn)
+		if err != nil {
+			conn.Close()
+			continue
+		}
+		go c.serve()
+	}
+}
+
+func (m *Mux) add(conn io.ReadWriteCloser) (*client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, c := range m.clients {
+		if c == nil {
+			c = &client{mux: m, conn: conn, index: i, transIDs: map[uint8]uint8{}}
+			m.clients[i] = c
+			return c, nil
+		}
+	}
+	return nil, ErrTooManyClients
+}
+
+func (m *Mux) remove(c *client) {
+	m.mu.Lock()
+	m.clients[c.index] = nil
+	m.mu.Unlock()
+	c.conn.Close()
+}
+
+func (m *Mux) adapterLoop() {
+	for {
+		frame, err := m.u.ReadFrame()
+		if err != nil {
+			continue
+		}
+		switch frame.CommandType {
+		case unp.C_SRSP:
+			select {
+			case m.srsp <- frame:
+			default:
+			}
+		case unp.C_AREQ:
+			m.dispatch(frame)
+		}
+	}
+}
+
+func (m *Mux) requestLoop() {
+	for req := range m.requests {
+		if err := m.u.WriteFrame(req.frame); err != nil {
+			req.client.release(req.transID)
+			if req.frame.CommandType == unp.C_SREQ {
+				req.client.reject(req.frame)
+			}
+			continue
+		}
+		if req.frame.CommandType != unp.C_SREQ {
+			continue
+		}
+		deadline := time.NewTimer(5 * time.Second)
+	await:
+		for {
+			select {
+			case rsp := <-m.srsp:
+				//a late response to a request which already timed out must not be taken for this one
+				if isResponseTo(rsp, req.frame) {
+					deadline.Stop()
+					//no confirmation follows a rejected data request
+					if len(rsp.Payload) == 0 || rsp.Payload[0] != 0 {
+						req.client.release(req.transID)
+					}
+					req.client.write(rsp)
+					break await
+				}
+			case <-deadline.C:
+				req.client.release(req.transID)
+				break await
+			}
+		}
+	}
+}
+
+func isResponseTo(rsp *unp.Frame, req *unp.Frame) bool {
+	if rsp.Subsystem == unp.S_RES0 && rsp.Command == 0 {
+		return true
+	}
+	return rsp.Subsystem == req.Subsystem && rsp.Command == req.Command
+}
+
+func (m *Mux) dispatch(frame *unp.Frame) {
+	m.mu.Lock()
+	clients := make([]*client, len(m.clients))
+	copy(clients, m.clients)
+	m.mu.Unlock()
+
+	if offset, ok := confirmTransIDOffset(frame); ok {
+		transID := frame.Payload[offset]
+		if c := clients[int(transID)/m.rangeSize%len(clients)]; c != nil {
+			c.write(c.restoreTransID(frame, offset))
+		}
+		return
+	}
+	for _, c := range clients {
+		if c != nil {
+			c.write(frame)
+		}
+	}
+}
+
+func (c *client) serve() {
+	defer c.mux.remove(c)
+	r := bufio.NewReader(c.conn)
+	for {
+		frame, err := readFrame(r)
+		if err == errChecksum {
+			continue
+		}
+		if err != nil {
+			return
+		}
+		transID := -1
+		if offset, ok := requestTransIDOffset(frame); ok {
+			if transID, ok = c.remapTransID(frame, offset); !ok {
+				c.reject(frame)
+				continue
+			}
+		}
+		c.mux.requests <- &request{c, frame, transID}
+	}
+}
+
+//write sends the frame to the client. A client which doesn't read for a second is disconnected, so that it can't
+//stall the other ones.
+func (c *client) write(frame *unp.Frame) {
+	if d, ok := c.conn.(interface{ SetWriteDeadline(time.Time) error }); ok {
+		d.SetWriteDeadline(time.Now().Add(time.Second))
+	}
+	if _, err := c.conn.Write(renderFrame(frame)); err != nil {
+		c.conn.Close()
+	}
+}
+
+//reject answers the request with an RPC error, as the adapter does for the requests it can't process
+func (c *client) reject(frame *unp.Frame) {
+	cmd0 := byte(frame.CommandType)<<5&0xE0 | byte(frame.Subsystem)&0x1F
+	c.write(&unp.Frame{CommandType: unp.C_SRSP, Subsystem: unp.S_RES0, Command: 0,
+		Payload: []byte{RPCErrUnavailable, cmd0, frame.Command}})
+}
+
+//remapTransID replaces the transaction ID of the request with a free one of the range of the client and returns
+//it. It fails when all of them await their confirmation.
+func (c *client) remapTransID(frame *unp.Frame, offset int) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := 0; i < c.mux.rangeSize; i++ {
+		mapped := uint8(c.index*c.mux.rangeSize + (c.next+i)%c.mux.rangeSize)
+		if _, ok := c.transIDs[mapped]; !ok {
+			c.transIDs[mapped] = frame.Payload[offset]
+			c.next = (c.next + i + 1) % c.mux.rangeSize
+			frame.Payload[offset] = mapped
+			return int(mapped), true
+		}
+	}
+	return -1, false
+}
+
+//release frees the transaction ID of a request which won't be confirmed
+func (c *client) release(transID int) {
+	if transID < 0 {
+		return
+	}
+	c.mu.Lock()
+	delete(c.transIDs, uint8(transID))
+	c.mu.Unlock()
+}
+
+//restoreTransID puts the transaction ID of the client back into the confirmation. AfDataConfirm completes the
+//request and frees its transaction ID.
+func (c *client) restoreTransID(frame *unp.Frame, offset int) *unp.Frame {
+	c.mu.Lock()
+	original, ok := c.transIDs[frame.Payload[offset]]
+	if ok && frame.Command == 0x80 {
+		delete(c.transIDs, frame.Payload[offset])
+	}
+	c.mu.Unlock()
+	if !ok {
+		return frame
+	}
+	payload := append([]byte{}, frame.Payload...)
+	payload[offset] = original
+	return &unp.Frame{CommandType: frame.CommandType, Subsystem: frame.Subsystem, Command: frame.Command, Payload: payload}
+}
+
+//requestTransIDOffset returns the position of TransID in AF_DATA_REQUEST, AF_DATA_REQUEST_EXT and AF_DATA_REQUEST_SRC_RTG
+func requestTransIDOffset(frame *unp.Frame) (int, bool) {
+	if frame.CommandType != unp.C_SREQ || frame.Subsystem != unp.S_AF {
+		return 0, false
+	}
+	offset := -1
+	switch frame.Command {
+	case 0x01, 0x03:
+		offset = 6
+	case 0x02:
+		offset = 15
+	}
+	return offset, offset >= 0 && offset < len(frame.Payload)
+}
+
+//confirmTransIDOffset returns the position of TransID in AF_DATA_CONFIRM and AF_REFLECT_ERROR
+func confirmTransIDOffset(frame *unp.Frame) (int, bool) {
+	if frame.Subsystem != unp.S_AF || (frame.Command != 0x80 && frame.Command != 0x83) {
+		return 0, false
+	}
+	return 2, len(frame.Payload) > 2
+}
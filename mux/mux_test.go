@@ -0,0 +1,122 @@
+package mux
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	unp "github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func connect(c *C, m *Mux) (*znp.Znp, *znp.Subscription) {
+	conn, err := m.Connect()
+	c.Assert(err, IsNil)
+	z := znp.New(unp.New(1, conn))
+	z.Start()
+	return z, z.Subscribe()
+}
+
+func (s *MySuite) TestMultiplexing(c *C) {
+	a := znptest.New()
+	afRequests := make(chan *unp.Frame, 1)
+	a.Handle(unp.S_AF, 0x01, func(r *znptest.Request) []byte {
+		afRequests <- r.Frame
+		return nil
+	})
+
+	m := New(a.Unp(), 2)
+	m.Start()
+	z1, s1 := connect(c, m)
+	z2, s2 := connect(c, m)
+	_, err := m.Connect()
+	c.Assert(err, Equals, ErrTooManyClients)
+
+	ping, err := z1.SysPing()
+	c.Assert(err, IsNil)
+	c.Assert(ping.Capabilities.Sys, Equals, uint16(1))
+	_, err = z2.SysPing()
+	c.Assert(err, IsNil)
+
+	_, err = z2.AfDataRequest("0x1234", 1, 1, 6, 5, &znp.AfDataRequestOptions{}, 30, []uint8{1})
+	c.Assert(err, IsNil)
+	frame := <-afRequests
+	//the first transaction ID of the range of the second client
+	c.Assert(frame.Payload[6], Equals, uint8(128))
+
+	a.Send(unp.S_AF, 0x80, []byte{0x00, 0x01, 128})
+	a.Send(unp.S_ZDO, 0xC0, []byte{0x09})
+
+	c.Assert(<-s2.Events(), DeepEquals, &znp.AfDataConfirm{Status: znp.StatusSuccess, Endpoint: 1, TransID: 5})
+	c.Assert(<-s2.Events(), DeepEquals, &znp.ZdoStateChangeInd{State: znp.DeviceStateStartedAsZigBeeCoordinator})
+	select {
+	case async := <-s1.Events():
+		c.Assert(async, DeepEquals, &znp.ZdoStateChangeInd{State: znp.DeviceStateStartedAsZigBeeCoordinator})
+	case <-time.After(time.Second):
+		c.Fatal("state change was not delivered to the first client")
+	}
+}
+
+func (s *MySuite) TestPendingTransIDs(c *C) {
+	a := znptest.New()
+	afRequests := make(chan *unp.Frame, 4)
+	a.Handle(unp.S_AF, 0x01, func(r *znptest.Request) []byte {
+		afRequests <- r.Frame
+		return nil
+	})
+
+	//two transaction IDs per client
+	m := New(a.Unp(), 128)
+	m.Start()
+	z, subscription := connect(c, m)
+
+	var mapped []uint8
+	for _, transID := range []uint8{0, 2} {
+		_, err := z.AfDataRequest("0x1234", 1, 1, 6, transID, &znp.AfDataRequestOptions{}, 30, []uint8{1})
+		c.Assert(err, IsNil)
+		mapped = append(mapped, (<-afRequests).Payload[6])
+	}
+	c.Assert(mapped[0], Not(Equals), mapped[1])
+	_, err := z.AfDataRequest("0x1234", 1, 1, 6, 4, &znp.AfDataRequestOptions{}, 30, []uint8{1})
+	c.Assert(err, ErrorMatches, "RPC error 0x80")
+
+	a.Send(unp.S_AF, 0x80, []byte{0x00, 0x01, mapped[1]})
+	a.Send(unp.S_AF, 0x80, []byte{0x00, 0x01, mapped[0]})
+	c.Assert(<-subscription.Events(), DeepEquals, &znp.AfDataConfirm{Status: znp.StatusSuccess, Endpoint: 1, TransID: 2})
+	c.Assert(<-subscription.Events(), DeepEquals, &znp.AfDataConfirm{Status: znp.StatusSuccess, Endpoint: 1, TransID: 0})
+
+	//the confirmations freed the transaction IDs
+	_, err = z.AfDataRequest("0x1234", 1, 1, 6, 4, &znp.AfDataRequestOptions{}, 30, []uint8{1})
+	c.Assert(err, IsNil)
+}
+
+//brokenAdapter fails every write
+type brokenAdapter struct {
+	io.Reader
+}
+
+func (brokenAdapter) Write([]byte) (int, error) {
+	return 0, errors.New("broken")
+}
+
+func (s *MySuite) TestFailedWrite(c *C) {
+	r, w := io.Pipe()
+	defer w.Close()
+	m := New(unp.New(1, brokenAdapter{r}), 1)
+	m.Start()
+	z, _ := connect(c, m)
+
+	started := time.Now()
+	_, err := z.SysPing()
+	c.Assert(err, ErrorMatches, "RPC error 0x80")
+	c.Assert(time.Since(started) < time.Second, Equals, true)
+}
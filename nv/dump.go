@@ -0,0 +1,193 @@
+package nv
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dyrkin/znp-go"
+)
+
+//Bytes is a value marshalled to JSON as hex
+type Bytes []byte
+
+func (b Bytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+func (b *Bytes) UnmarshalText(text []byte) (err error) {
+	*b, err = hex.DecodeString(string(text))
+	return
+}
+
+//Entry is an item found in the NV memory
+type Entry struct {
+	Name string `json:"name"`
+	//SysID is 0 for legacy items, their ID is stored in ItemID
+	SysID  uint8  `json:"sysId,omitempty"`
+	ItemID uint16 `json:"itemId"`
+	SubID  uint16 `json:"subId,omitempty"`
+	Value  Bytes  `json:"value,omitempty"`
+	//Error tells why the value of an existing item couldn't be read
+	Error string `json:"error,omitempty"`
+}
+
+//Key identifies the item across snapshots
+func (e *Entry) Key() string {
+	if e.SysID == 0 {
+		return fmt.Sprintf("0x%04x", e.ItemID)
+	}
+	return fmt.Sprintf("%d/0x%04x/0x%04x", e.SysID, e.ItemID, e.SubID)
+}
+
+//Fields returns the decoded value or nil when the layout of the item is unknown
+func (e *Entry) Fields() []*Field {
+	if e.SysID == 0 {
+		return decode(legacyLayouts[e.ItemID], e.Value)
+	}
+	return decode(tableLayouts[e.ItemID], e.Value)
+}
+
+//Snapshot is the content of the NV memory of an adapter
+type Snapshot struct {
+	Product string   `json:"product,omitempty"`
+	Version string   `json:"version,omitempty"`
+	Items   []*Entry `json:"items"`
+}
+
+//Dump reads all known legacy items and, from Z-Stack 3.x adapters, the entries of the known tables. Items which
+//exist but can't be read are included with the error. Older firmwares are told apart by the probe of Start.
+func Dump(z *znp.Znp) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if info := z.DeviceInfo(); info != nil {
+		snapshot.Product, snapshot.Version = info.Product.String(), info.Version
+	}
+	items := append([]Item{}, Known...)
+	for _, table := range legacyTables {
+		for id := table.first; id <= table.last; id++ {
+			items = append(items, Item{id, fmt.Sprintf("%s[%d]", table.name, id-table.first)})
+		}
+	}
+	for _, item := range items {
+		length, err := Length(z, item.ID)
+		if err != nil {
+			return nil, err
+		}
+		if length == 0 {
+			continue
+		}
+		entry := &Entry{Name: item.Name, ItemID: item.ID}
+		if entry.Value, err = item.Read(z); err != nil {
+			entry.Error = err.Error()
+		}
+		snapshot.Items = append(snapshot.Items, entry)
+	}
+	for _, table := range Tables {
+		for subID := uint16(0); ; subID++ {
+			length, err := ExtendedLength(z, SysIDZStack, table.ItemID, subID)
+			if errors.Is(err, znp.ErrUnsupported) {
+				return snapshot, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if length == 0 || subID == 0xFFFF {
+				break
+			}
+			entry := &Entry{Name: fmt.Sprintf("%s[%d]", table.Name, subID), SysID: SysIDZStack,
+				ItemID: table.ItemID, SubID: subID}
+			if entry.Value, err = table.Read(z, subID); err != nil {
+				entry.Error = err.Error()
+			}
+			snapshot.Items = append(snapshot.Items, entry)
+		}
+	}
+	return snapshot, nil
+}
+
+//Change is an item which differs between two snapshots
+type Change struct {
+	Name string
+	Key  string
+	//Old is nil when the item was added, New is nil when it was removed
+	Old *Entry
+	New *Entry
+	//Fields are the decoded fields which differ, they are nil when the layout of the item is unknown
+	Fields []*FieldChange
+}
+
+type FieldChange struct {
+	Name string
+	Old  string
+	New  string
+}
+
+func (c *Change) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s)\n", c.Name, c.Key)
+	switch {
+	case c.Old == nil:
+		fmt.Fprintf(&b, "\t+ %s\n", describe(c.New))
+	case c.New == nil:
+		fmt.Fprintf(&b, "\t- %s\n", describe(c.Old))
+	case c.Fields != nil:
+		for _, f := range c.Fields {
+			fmt.Fprintf(&b, "\t%s: %s -> %s\n", f.Name, f.Old, f.New)
+		}
+	default:
+		fmt.Fprintf(&b, "\t- %s\n\t+ %s\n", describe(c.Old), describe(c.New))
+	}
+	return b.String()
+}
+
+func describe(e *Entry) string {
+	if e.Error != "" {
+		return "error: " + e.Error
+	}
+	return hex.EncodeToString(e.Value)
+}
+
+//Diff returns the items which were added, removed or changed between the snapshots, in the order of the items
+//of the old snapshot followed by the added ones
+func Diff(old *Snapshot, new *Snapshot) []*Change {
+	newItems := map[string]*Entry{}
+	for _, e := range new.Items {
+		newItems[e.Key()] = e
+	}
+	var changes []*Change
+	seen := map[string]bool{}
+	for _, o := range old.Items {
+		key := o.Key()
+		seen[key] = true
+		n := newItems[key]
+		if n == nil {
+			changes = append(changes, &Change{Name: o.Name, Key: key, Old: o})
+			continue
+		}
+		if describe(o) == describe(n) {
+			continue
+		}
+		changes = append(changes, &Change{Name: o.Name, Key: key, Old: o, New: n, Fields: diffFields(o, n)})
+	}
+	for _, n := range new.Items {
+		if !seen[n.Key()] {
+			changes = append(changes, &Change{Name: n.Name, Key: n.Key(), New: n})
+		}
+	}
+	return changes
+}
+
+func diffFields(old *Entry, new *Entry) []*FieldChange {
+	oldFields, newFields := old.Fields(), new.Fields()
+	if oldFields == nil || newFields == nil {
+		return nil
+	}
+	changes := []*FieldChange{}
+	for i := range oldFields {
+		if oldFields[i].Value != newFields[i].Value {
+			changes = append(changes, &FieldChange{oldFields[i].Name, oldFields[i].Value, newFields[i].Value})
+		}
+	}
+	return changes
+}
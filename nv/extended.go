@@ -0,0 +1,117 @@
+package nv
+
+import (
+	"fmt"
+
+	"github.com/dyrkin/znp-go"
+)
+
+//SysIDZStack is the system ID of the Z-Stack items in the NV memory of Z-Stack 3.x
+const SysIDZStack uint8 = 0x01
+
+//Table is an item of Z-Stack 3.x holding a table, its entries are addressed by the sub ID
+type Table struct {
+	ItemID uint16
+	Name   string
+}
+
+//Tables of Z-Stack 3.x, see ZCD_NV_EX_* in ZComDef.h. The legacy items are stored under item ID 0x0000 and are
+//accessed with their legacy IDs instead.
+var (
+	ExAddrMgr             = Table{0x0001, "ZCD_NV_EX_ADDRMGR"}
+	ExBindingTable        = Table{0x0002, "ZCD_NV_EX_BINDING_TABLE"}
+	ExDeviceList          = Table{0x0003, "ZCD_NV_EX_DEVICE_LIST"}
+	ExTclkTable           = Table{0x0004, "ZCD_NV_EX_TCLK_TABLE"}
+	ExTclkIcTable         = Table{0x0005, "ZCD_NV_EX_TCLK_IC_TABLE"}
+	ExApsKeyDataTable     = Table{0x0006, "ZCD_NV_EX_APS_KEY_DATA_TABLE"}
+	ExNwkSecMaterialTable = Table{0x0007, "ZCD_NV_EX_NWK_SEC_MATERIAL_TABLE"}
+)
+
+//Tables are all known tables
+var Tables = []Table{
+	ExAddrMgr, ExBindingTable, ExDeviceList, ExTclkTable, ExTclkIcTable, ExApsKeyDataTable, ExNwkSecMaterialTable,
+}
+
+//Read reads the entry of the table
+func (t Table) Read(z *znp.Znp, subID uint16) ([]byte, error) {
+	return ReadExtended(z, SysIDZStack, t.ItemID, subID)
+}
+
+//Write stores the entry of the table
+func (t Table) Write(z *znp.Znp, subID uint16, value []byte) error {
+	return WriteExtended(z, SysIDZStack, t.ItemID, subID, value)
+}
+
+//ExtendedLength returns the length of the Z-Stack 3.x item or 0 when it doesn't exist
+func ExtendedLength(z *znp.Znp, sysID uint8, itemID uint16, subID uint16) (uint32, error) {
+	rsp, err := z.SysNvLength(sysID, itemID, subID)
+	if err != nil {
+		return 0, err
+	}
+	return rsp.Length, nil
+}
+
+//ReadExtended reads the whole value of the Z-Stack 3.x item in chunks
+func ReadExtended(z *znp.Znp, sysID uint8, itemID uint16, subID uint16) ([]byte, error) {
+	length, err := ExtendedLength(z, sysID, itemID, subID)
+	if err != nil {
+		return nil, err
+	}
+	if length == 0 {
+		return nil, fmt.Errorf("%w: %d/0x%04x/0x%04x", ErrNotFound, sysID, itemID, subID)
+	}
+	if length > 0xFFFF {
+		return nil, fmt.Errorf("nv: item %d/0x%04x/0x%04x is too long: %d bytes", sysID, itemID, subID, length)
+	}
+	value := make([]byte, 0, length)
+	for len(value) < int(length) {
+		n := min(int(length)-len(value), maxChunk)
+		rsp, err := z.SysNvRead(sysID, itemID, subID, uint16(len(value)), uint8(n))
+		if err != nil {
+			return nil, err
+		}
+		if rsp.Status != znp.StatusSuccess {
+			return nil, fmt.Errorf("nv: reading item %d/0x%04x/0x%04x at offset %d failed: %s", sysID, itemID, subID,
+				len(value), rsp.Status)
+		}
+		if len(rsp.Value) == 0 {
+			return nil, fmt.Errorf("nv: item %d/0x%04x/0x%04x has no data at offset %d", sysID, itemID, subID,
+				len(value))
+		}
+		value = append(value, rsp.Value...)
+	}
+	return value[:length], nil
+}
+
+//WriteExtended stores the value of the Z-Stack 3.x item in chunks. Missing items are created with the length of
+//the value, the length of existing items can't be changed.
+func WriteExtended(z *znp.Znp, sysID uint8, itemID uint16, subID uint16, value []byte) error {
+	length, err := ExtendedLength(z, sysID, itemID, subID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case length == 0:
+		rsp, err := z.SysNvCreate(sysID, itemID, subID, uint32(len(value)))
+		if err != nil {
+			return err
+		}
+		if rsp.Status != znp.StatusSuccess && rsp.Status != znp.StatusItemCreatedAndInitialized {
+			return fmt.Errorf("nv: creating item %d/0x%04x/0x%04x failed: %s", sysID, itemID, subID, rsp.Status)
+		}
+	case int(length) != len(value):
+		return fmt.Errorf("nv: item %d/0x%04x/0x%04x is %d bytes long, got %d bytes", sysID, itemID, subID, length,
+			len(value))
+	}
+	for offset := 0; offset < len(value); offset += maxChunk {
+		rsp, err := z.SysNvWrite(sysID, itemID, subID, uint16(offset), value[offset:min(offset+maxChunk, len(value))])
+		if err != nil {
+			return err
+		}
+		if rsp.Status != znp.StatusSuccess {
+			return fmt.Errorf("nv: writing item %d/0x%04x/0x%04x at offset %d failed: %s", sysID, itemID, subID,
+				offset, rsp.Status)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package nv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dyrkin/bin"
+	"github.com/dyrkin/znp-go"
+)
+
+//Item is an NV item holding raw bytes
+type Item struct {
+	ID   uint16
+	Name string
+}
+
+func (i Item) String() string {
+	return fmt.Sprintf("%s (0x%04x)", i.Name, i.ID)
+}
+
+//Read reads the value of the item
+func (i Item) Read(z *znp.Znp) ([]byte, error) {
+	return Read(z, i.ID)
+}
+
+//Write stores the value of the item
+func (i Item) Write(z *znp.Znp, value []byte) error {
+	return Write(z, i.ID, value)
+}
+
+//Decode reads the value of the item into v, a pointer to one of the layouts of this package, e.g. NwkKeyInfo
+func (i Item) Decode(z *znp.Znp, v interface{}) error {
+	value, err := i.Read(z)
+	if err != nil {
+		return err
+	}
+	bin.Decode(value, v)
+	if length := len(bin.Encode(v)); length != len(value) {
+		return fmt.Errorf("nv: %s is %d bytes long, expected %d", i, len(value), length)
+	}
+	return nil
+}
+
+//Encode stores v as the value of the item
+func (i Item) Encode(z *znp.Znp, v interface{}) error {
+	return i.Write(z, bin.Encode(v))
+}
+
+//read reads the value of the item and checks that it has the length of the typed value
+func (i Item) read(z *znp.Znp, length int) ([]byte, error) {
+	value, err := i.Read(z)
+	if err != nil {
+		return nil, err
+	}
+	if len(value) != length {
+		return nil, fmt.Errorf("nv: %s is %d bytes long, expected %d", i, len(value), length)
+	}
+	return value, nil
+}
+
+type Uint8Item struct{ Item }
+
+func (i Uint8Item) Get(z *znp.Znp) (uint8, error) {
+	value, err := i.read(z, 1)
+	if err != nil {
+		return 0, err
+	}
+	return value[0], nil
+}
+
+func (i Uint8Item) Set(z *znp.Znp, value uint8) error {
+	return i.Write(z, []byte{value})
+}
+
+type Uint16Item struct{ Item }
+
+func (i Uint16Item) Get(z *znp.Znp) (uint16, error) {
+	value, err := i.read(z, 2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(value), nil
+}
+
+func (i Uint16Item) Set(z *znp.Znp, value uint16) error {
+	return i.Write(z, binary.LittleEndian.AppendUint16(nil, value))
+}
+
+type Uint32Item struct{ Item }
+
+func (i Uint32Item) Get(z *znp.Znp) (uint32, error) {
+	value, err := i.read(z, 4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(value), nil
+}
+
+func (i Uint32Item) Set(z *znp.Znp, value uint32) error {
+	return i.Write(z, binary.LittleEndian.AppendUint32(nil, value))
+}
+
+//BoolItem is a single byte flag
+type BoolItem struct{ Item }
+
+func (i BoolItem) Get(z *znp.Znp) (bool, error) {
+	value, err := i.read(z, 1)
+	if err != nil {
+		return false, err
+	}
+	return value[0] != 0, nil
+}
+
+func (i BoolItem) Set(z *znp.Znp, value bool) error {
+	if value {
+		return i.Write(z, []byte{0x01})
+	}
+	return i.Write(z, []byte{0x00})
+}
+
+//KeyItem is a 128-bit security key
+type KeyItem struct{ Item }
+
+func (i KeyItem) Get(z *znp.Znp) ([16]uint8, error) {
+	var key [16]uint8
+	value, err := i.read(z, len(key))
+	if err != nil {
+		return key, err
+	}
+	copy(key[:], value)
+	return key, nil
+}
+
+func (i KeyItem) Set(z *znp.Znp, key [16]uint8) error {
+	return i.Write(z, key[:])
+}
+
+//AddressItem is a 64-bit address in the hex form used by the models, e.g. 0x00124b0001020304
+type AddressItem struct{ Item }
+
+func (i AddressItem) Get(z *znp.Znp) (string, error) {
+	value, err := i.read(z, 8)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("0x%016x", binary.LittleEndian.Uint64(value)), nil
+}
+
+func (i AddressItem) Set(z *znp.Znp, address string) error {
+	v, err := strconv.ParseUint(strings.TrimPrefix(address, "0x"), 16, 64)
+	if err != nil {
+		return fmt.Errorf("nv: invalid address %q: %w", address, err)
+	}
+	return i.Write(z, binary.LittleEndian.AppendUint64(nil, v))
+}
+
+type ChannelsItem struct{ Item }
+
+func (i ChannelsItem) Get(z *znp.Znp) (*znp.Channels, error) {
+	value, err := i.read(z, 4)
+	if err != nil {
+		return nil, err
+	}
+	channels := &znp.Channels{}
+	bin.Decode(value, channels)
+	return channels, nil
+}
+
+func (i ChannelsItem) Set(z *znp.Znp, channels *znp.Channels) error {
+	return i.Write(z, bin.Encode(channels))
+}
+
+type LogicalTypeItem struct{ Item }
+
+func (i LogicalTypeItem) Get(z *znp.Znp) (znp.LogicalType, error) {
+	value, err := i.read(z, 1)
+	if err != nil {
+		return 0, err
+	}
+	return znp.LogicalType(value[0]), nil
+}
+
+func (i LogicalTypeItem) Set(z *znp.Znp, logicalType znp.LogicalType) error {
+	return i.Write(z, []byte{uint8(logicalType)})
+}
@@ -0,0 +1,157 @@
+package nv
+
+//Legacy OSAL NV items, see ZComDef.h in Z-Stack
+var (
+	ExtAddr          = AddressItem{Item{0x0001, "ZCD_NV_EXTADDR"}}
+	StartupOption    = Uint8Item{Item{0x0003, "ZCD_NV_STARTUP_OPTION"}}
+	NIB              = Item{0x0021, "ZCD_NV_NIB"}
+	ExtendedPanID    = AddressItem{Item{0x002D, "ZCD_NV_EXTENDED_PAN_ID"}}
+	NwkActiveKeyInfo = Item{0x003A, "ZCD_NV_NWK_ACTIVE_KEY_INFO"}
+	NwkAlternKeyInfo = Item{0x003B, "ZCD_NV_NWK_ALTERN_KEY_INFO"}
+	ApsUseExtPanID   = AddressItem{Item{0x0047, "ZCD_NV_APS_USE_EXT_PANID"}}
+	//HasConfiguredZStack3 is set by applications after the first configuration of a Z-Stack 3.x adapter
+	HasConfiguredZStack3 = Uint8Item{Item{0x0060, "ZCD_NV_ZNP_HAS_CONFIGURED_ZSTACK3"}}
+	PreCfgKey            = KeyItem{Item{0x0062, "ZCD_NV_PRECFGKEY"}}
+	PreCfgKeysEnable     = BoolItem{Item{0x0063, "ZCD_NV_PRECFGKEYS_ENABLE"}}
+	SecurityMode         = BoolItem{Item{0x0064, "ZCD_NV_SECURITY_MODE"}}
+	UseDefaultTclk       = BoolItem{Item{0x006D, "ZCD_NV_USE_DEFAULT_TCLK"}}
+	NwkKey               = Item{0x0082, "ZCD_NV_NWKKEY"}
+	PanID                = Uint16Item{Item{0x0083, "ZCD_NV_PANID"}}
+	ChanList             = ChannelsItem{Item{0x0084, "ZCD_NV_CHANLIST"}}
+	LogicalType          = LogicalTypeItem{Item{0x0087, "ZCD_NV_LOGICAL_TYPE"}}
+	ZdoDirectCb          = BoolItem{Item{0x008F, "ZCD_NV_ZDO_DIRECT_CB"}}
+	TclkTableStart       = Item{0x0101, "ZCD_NV_TCLK_TABLE_START"}
+	//HasConfiguredZStack1 is set by applications after the first configuration of a ZNP 1.2 adapter
+	HasConfiguredZStack1 = Uint8Item{Item{0x0F00, "ZCD_NV_ZNP_HAS_CONFIGURED_ZSTACK1"}}
+)
+
+//Bits of StartupOption, applied on the next reset
+const (
+	StartupOptionClearConfig uint8 = 0x01
+	StartupOptionClearState  uint8 = 0x02
+)
+
+//Known are the legacy items enumerated by Dump
+var Known = []Item{
+	ExtAddr.Item,
+	{0x0002, "ZCD_NV_BOOTCOUNTER"},
+	StartupOption.Item,
+	{0x0004, "ZCD_NV_START_DELAY"},
+	NIB,
+	{0x0022, "ZCD_NV_DEVICE_LIST"},
+	{0x0023, "ZCD_NV_ADDRMGR"},
+	{0x0024, "ZCD_NV_POLL_RATE"},
+	{0x0025, "ZCD_NV_QUEUED_POLL_RATE"},
+	{0x0026, "ZCD_NV_RESPONSE_POLL_RATE"},
+	{0x0027, "ZCD_NV_REJOIN_POLL_RATE"},
+	{0x0028, "ZCD_NV_DATA_RETRIES"},
+	{0x0029, "ZCD_NV_POLL_FAILURE_RETRIES"},
+	{0x002A, "ZCD_NV_STACK_PROFILE"},
+	{0x002B, "ZCD_NV_INDIRECT_MSG_TIMEOUT"},
+	{0x002C, "ZCD_NV_ROUTE_EXPIRY_TIME"},
+	ExtendedPanID.Item,
+	{0x002E, "ZCD_NV_BCAST_RETRIES"},
+	{0x002F, "ZCD_NV_PASSIVE_ACK_TIMEOUT"},
+	{0x0030, "ZCD_NV_BCAST_DELIVERY_TIME"},
+	{0x0031, "ZCD_NV_NWK_MODE"},
+	{0x0032, "ZCD_NV_CONCENTRATOR_ENABLE"},
+	{0x0033, "ZCD_NV_CONCENTRATOR_DISCOVERY"},
+	{0x0034, "ZCD_NV_CONCENTRATOR_RADIUS"},
+	{0x0036, "ZCD_NV_CONCENTRATOR_RC"},
+	{0x0037, "ZCD_NV_NWK_MGR_MODE"},
+	{0x0038, "ZCD_NV_SRC_RTG_EXPIRY_TIME"},
+	{0x0039, "ZCD_NV_ROUTE_DISCOVERY_TIME"},
+	NwkActiveKeyInfo,
+	NwkAlternKeyInfo,
+	{0x003C, "ZCD_NV_ROUTER_OFF_ASSOC_CLEANUP"},
+	{0x003D, "ZCD_NV_NWK_LEAVE_REQ_ALLOWED"},
+	{0x003E, "ZCD_NV_NWK_CHILD_AGE_ENABLE"},
+	{0x003F, "ZCD_NV_DEVICE_LIST_KA_TIMEOUT"},
+	{0x0041, "ZCD_NV_BINDING_TABLE"},
+	{0x0042, "ZCD_NV_GROUP_TABLE"},
+	{0x0043, "ZCD_NV_APS_FRAME_RETRIES"},
+	{0x0044, "ZCD_NV_APS_ACK_WAIT_DURATION"},
+	{0x0045, "ZCD_NV_APS_ACK_WAIT_MULTIPLIER"},
+	{0x0046, "ZCD_NV_BINDING_TIME"},
+	ApsUseExtPanID.Item,
+	{0x0048, "ZCD_NV_APS_USE_INSECURE_JOIN"},
+	{0x0049, "ZCD_NV_COMMISSIONED_NWK_ADDR"},
+	{0x004B, "ZCD_NV_APS_NONMEMBER_RADIUS"},
+	{0x004C, "ZCD_NV_APS_LINK_KEY_TABLE"},
+	{0x004D, "ZCD_NV_APS_DUPREJ_TIMEOUT_INC"},
+	{0x004E, "ZCD_NV_APS_DUPREJ_TIMEOUT_COUNT"},
+	{0x004F, "ZCD_NV_APS_DUPREJ_TABLE_SIZE"},
+	{0x0050, "ZCD_NV_DIAGNOSTIC_STATS"},
+	{0x0051, "ZCD_NV_NWK_PARENT_INFO"},
+	{0x0052, "ZCD_NV_NWK_ENDDEV_TIMEOUT_DEF"},
+	{0x0053, "ZCD_NV_END_DEV_TIMEOUT_VALUE"},
+	{0x0054, "ZCD_NV_END_DEV_CONFIGURATION"},
+	{0x0055, "ZCD_NV_BDBNODEISONANETWORK"},
+	{0x0056, "ZCD_NV_BDBREPORTINGCONFIG"},
+	HasConfiguredZStack3.Item,
+	{0x0061, "ZCD_NV_SECURITY_LEVEL"},
+	PreCfgKey.Item,
+	PreCfgKeysEnable.Item,
+	SecurityMode.Item,
+	{0x0065, "ZCD_NV_SECURE_PERMIT_JOIN"},
+	{0x0066, "ZCD_NV_APS_LINK_KEY_TYPE"},
+	{0x0067, "ZCD_NV_APS_ALLOW_R19_SECURITY"},
+	{0x0068, "ZCD_NV_DISTRIBUTED_KEY"},
+	{0x0069, "ZCD_NV_IMPLICIT_CERTIFICATE"},
+	{0x006A, "ZCD_NV_DEVICE_PRIVATE_KEY"},
+	{0x006B, "ZCD_NV_CA_PUBLIC_KEY"},
+	{0x006C, "ZCD_NV_KE_MAX_DEVICES"},
+	UseDefaultTclk.Item,
+	{0x006F, "ZCD_NV_RNG_COUNTER"},
+	{0x0070, "ZCD_NV_RANDOM_SEED"},
+	{0x0071, "ZCD_NV_TRUSTCENTER_ADDR"},
+	{0x0081, "ZCD_NV_USERDESC"},
+	NwkKey,
+	PanID.Item,
+	ChanList.Item,
+	{0x0085, "ZCD_NV_LEAVE_CTRL"},
+	{0x0086, "ZCD_NV_SCAN_DURATION"},
+	LogicalType.Item,
+	{0x0088, "ZCD_NV_NWKMGR_MIN_TX"},
+	{0x0089, "ZCD_NV_NWKMGR_ADDR"},
+	ZdoDirectCb.Item,
+	{0x0091, "ZCD_NV_SCENE_TABLE"},
+	{0x0092, "ZCD_NV_MIN_FREE_NWK_ADDR"},
+	{0x0093, "ZCD_NV_MAX_FREE_NWK_ADDR"},
+	{0x0094, "ZCD_NV_MIN_FREE_GRP_ID"},
+	{0x0095, "ZCD_NV_MAX_FREE_GRP_ID"},
+	{0x0096, "ZCD_NV_MIN_GRP_IDS"},
+	{0x0097, "ZCD_NV_MAX_GRP_IDS"},
+	{0x0098, "ZCD_NV_OTA_BLOCK_REQ_DELAY"},
+	{0x00A1, "ZCD_NV_SAPI_ENDPOINT"},
+	{0x00B1, "ZCD_NV_SAS_SHORT_ADDR"},
+	{0x00B2, "ZCD_NV_SAS_EXT_PANID"},
+	{0x00B3, "ZCD_NV_SAS_PANID"},
+	{0x00B4, "ZCD_NV_SAS_CHANNEL_MASK"},
+	{0x00B5, "ZCD_NV_SAS_PROTOCOL_VER"},
+	{0x00B6, "ZCD_NV_SAS_STACK_PROFILE"},
+	{0x00B7, "ZCD_NV_SAS_STARTUP_CTRL"},
+	{0x00C1, "ZCD_NV_SAS_TC_ADDR"},
+	{0x00C2, "ZCD_NV_SAS_TC_MASTER_KEY"},
+	{0x00C3, "ZCD_NV_SAS_NWK_KEY"},
+	{0x00C4, "ZCD_NV_SAS_USE_INSEC_JOIN"},
+	{0x00C5, "ZCD_NV_SAS_PRECFG_LINK_KEY"},
+	{0x00C6, "ZCD_NV_SAS_NWK_KEY_SEQ_NUM"},
+	{0x00C7, "ZCD_NV_SAS_NWK_KEY_TYPE"},
+	{0x00C8, "ZCD_NV_SAS_NWK_MGR_ADDR"},
+	{0x00D1, "ZCD_NV_SAS_CURR_TC_MASTER_KEY"},
+	{0x00D2, "ZCD_NV_SAS_CURR_NWK_KEY"},
+	{0x00D3, "ZCD_NV_SAS_CURR_PRECFG_LINK_KEY"},
+	HasConfiguredZStack1.Item,
+}
+
+//legacyTables are ranges of legacy items holding one table entry each
+var legacyTables = []struct {
+	first uint16
+	last  uint16
+	name  string
+}{
+	{0x0075, 0x0080, "ZCD_NV_LEGACY_NWK_SEC_MATERIAL_TABLE"},
+	{0x0101, 0x01FF, "ZCD_NV_TCLK_TABLE"},
+	{0x0201, 0x02FF, "ZCD_NV_APS_LINK_KEY_DATA_TABLE"},
+}
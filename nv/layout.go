@@ -0,0 +1,154 @@
+package nv
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dyrkin/bin"
+	"github.com/dyrkin/znp-go"
+)
+
+//NwkKeyInfo is the value of NwkActiveKeyInfo and NwkAlternKeyInfo
+type NwkKeyInfo struct {
+	KeySeqNum uint8
+	Key       [16]uint8
+}
+
+//NwkActiveKey is the value of NwkKey
+type NwkActiveKey struct {
+	KeySeqNum    uint8
+	Key          [16]uint8
+	FrameCounter uint32
+}
+
+//NwkSecMaterial is an entry of ExNwkSecMaterialTable and of the legacy network security material table
+type NwkSecMaterial struct {
+	FrameCounter  uint32
+	ExtendedPanID string `hex:"8"`
+}
+
+//TCLinkKeyEntry is an entry of ExTclkTable
+type TCLinkKeyEntry struct {
+	TxFrameCounter   uint32
+	RxFrameCounter   uint32
+	ExtAddr          string `hex:"8"`
+	KeyAttributes    uint8
+	KeyType          uint8
+	SeedShiftIcIndex uint8
+}
+
+//LegacyTCLinkKey is an entry of the legacy trust center link key table of Z-Stack 1.2 and Z-Stack Home
+type LegacyTCLinkKey struct {
+	ExtAddr        string `hex:"8"`
+	Key            [16]uint8
+	TxFrameCounter uint32
+	RxFrameCounter uint32
+}
+
+//ApsKeyData is an entry of ExApsKeyDataTable
+type ApsKeyData struct {
+	Key            [16]uint8
+	TxFrameCounter uint32
+	RxFrameCounter uint32
+}
+
+//legacyLayouts and tableLayouts return an empty value of the layout of an item. Values are only decoded when
+//they have the length of the layout.
+var legacyLayouts = map[uint16]func() interface{}{
+	ExtAddr.ID: func() interface{} {
+		return &struct {
+			ExtAddr string `hex:"8"`
+		}{}
+	},
+	StartupOption.ID: func() interface{} { return &struct{ StartupOption uint8 }{} },
+	ExtendedPanID.ID: func() interface{} {
+		return &struct {
+			ExtendedPanID string `hex:"8"`
+		}{}
+	},
+	NwkActiveKeyInfo.ID: func() interface{} { return &NwkKeyInfo{} },
+	NwkAlternKeyInfo.ID: func() interface{} { return &NwkKeyInfo{} },
+	NwkKey.ID:           func() interface{} { return &NwkActiveKey{} },
+	ApsUseExtPanID.ID: func() interface{} {
+		return &struct {
+			ApsUseExtPanID string `hex:"8"`
+		}{}
+	},
+	PreCfgKey.ID:        func() interface{} { return &struct{ PreCfgKey [16]uint8 }{} },
+	PreCfgKeysEnable.ID: func() interface{} { return &struct{ PreCfgKeysEnable uint8 }{} },
+	SecurityMode.ID:     func() interface{} { return &struct{ SecurityMode uint8 }{} },
+	UseDefaultTclk.ID:   func() interface{} { return &struct{ UseDefaultTclk uint8 }{} },
+	PanID.ID:            func() interface{} { return &struct{ PanID uint16 }{} },
+	ChanList.ID:         func() interface{} { return &struct{ ChanList znp.Channels }{} },
+	LogicalType.ID:      func() interface{} { return &struct{ LogicalType znp.LogicalType }{} },
+	ZdoDirectCb.ID:      func() interface{} { return &struct{ ZdoDirectCb uint8 }{} },
+}
+
+var tableLayouts = map[uint16]func() interface{}{
+	ExTclkTable.ItemID:           func() interface{} { return &TCLinkKeyEntry{} },
+	ExApsKeyDataTable.ItemID:     func() interface{} { return &ApsKeyData{} },
+	ExNwkSecMaterialTable.ItemID: func() interface{} { return &NwkSecMaterial{} },
+}
+
+func init() {
+	for id := legacyTables[0].first; id <= legacyTables[0].last; id++ {
+		legacyLayouts[id] = func() interface{} { return &NwkSecMaterial{} }
+	}
+	for id := legacyTables[1].first; id <= legacyTables[1].last; id++ {
+		legacyLayouts[id] = func() interface{} { return &LegacyTCLinkKey{} }
+	}
+}
+
+//Field is a decoded field of an item value
+type Field struct {
+	Name  string
+	Value string
+}
+
+//decode splits the value into the fields of the layout. It returns nil when the layout is unknown or the value
+//has another length.
+func decode(layout func() interface{}, value []byte) []*Field {
+	if layout == nil {
+		return nil
+	}
+	v := layout()
+	bin.Decode(value, v)
+	if len(bin.Encode(v)) != len(value) {
+		return nil
+	}
+	s := reflect.ValueOf(v).Elem()
+	fields := make([]*Field, s.NumField())
+	for i := range fields {
+		fields[i] = &Field{s.Type().Field(i).Name, format(s.Field(i))}
+	}
+	return fields
+}
+
+//format renders byte arrays as hex and structs, e.g. channel masks, by their non-zero fields
+func format(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "nil"
+		}
+		v = v.Elem()
+	}
+	switch {
+	case v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8:
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return hex.EncodeToString(b)
+	case v.Kind() == reflect.Struct:
+		var fields []string
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Field(i).IsZero() {
+				fields = append(fields, fmt.Sprintf("%s:%s", v.Type().Field(i).Name, format(v.Field(i))))
+			}
+		}
+		return "{" + strings.Join(fields, " ") + "}"
+	case v.Kind() == reflect.Uint16 || v.Kind() == reflect.Uint32:
+		return fmt.Sprintf("0x%x", v.Uint())
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,122 @@
+//Package nv reads and writes the non-volatile items of the adapter, e.g. the PAN ID or the channel list.
+//
+//Items are addressed by their legacy OSAL IDs (ZCD_NV_* in ZComDef.h), which are implemented by every Z-Stack
+//family:
+//
+//	panID, err := nv.PanID.Get(z)
+//	err = nv.ChanList.Set(z, &znp.Channels{Channel15: 1})
+package nv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dyrkin/znp-go"
+)
+
+//ErrNotFound is returned when the item doesn't exist in the NV memory of the adapter
+var ErrNotFound = errors.New("nv: item doesn't exist")
+
+//maxChunk is the number of bytes written with a single request. It keeps the frames below the 250 bytes the
+//MT transport accepts.
+const maxChunk = 240
+
+//Length returns the length of the item or 0 when it doesn't exist
+func Length(z *znp.Znp, id uint16) (uint16, error) {
+	rsp, err := z.SysOsalNvLength(id)
+	if err != nil {
+		return 0, err
+	}
+	return rsp.Length, nil
+}
+
+//Read reads the whole value of the item. The adapter returns as much as fits into a single response, the rest is
+//read in chunks starting at the following offset. Offsets above 255 are read with SysNvReadExt, so items longer
+//than that can only be read completely from Z-Stack 3.x adapters.
+func Read(z *znp.Znp, id uint16) ([]byte, error) {
+	length, err := Length(z, id)
+	if err != nil {
+		return nil, err
+	}
+	if length == 0 {
+		return nil, fmt.Errorf("%w: 0x%04x", ErrNotFound, id)
+	}
+	value := make([]byte, 0, length)
+	for len(value) < int(length) {
+		chunk, err := readChunk(z, id, len(value))
+		if err != nil {
+			return nil, err
+		}
+		if len(chunk) == 0 {
+			return nil, fmt.Errorf("nv: item 0x%04x has no data at offset %d", id, len(value))
+		}
+		value = append(value, chunk...)
+	}
+	return value[:length], nil
+}
+
+func readChunk(z *znp.Znp, id uint16, offset int) ([]byte, error) {
+	var status znp.Status
+	var value []byte
+	if offset <= 0xFF {
+		rsp, err := z.SysOsalNvRead(id, uint8(offset))
+		if err != nil {
+			return nil, err
+		}
+		status, value = rsp.Status, rsp.Value
+	} else {
+		rsp, err := z.SysNvReadExt(id, uint16(offset))
+		if err != nil {
+			return nil, err
+		}
+		status, value = rsp.Status, rsp.Value
+	}
+	if status != znp.StatusSuccess {
+		return nil, fmt.Errorf("nv: reading item 0x%04x at offset %d failed: %s", id, offset, status)
+	}
+	return value, nil
+}
+
+//Write stores the value of the item in chunks. Missing items are created with the length of the value, the
+//length of existing items can't be changed.
+func Write(z *znp.Znp, id uint16, value []byte) error {
+	length, err := Length(z, id)
+	if err != nil {
+		return err
+	}
+	switch {
+	case length == 0:
+		rsp, err := z.SysOsalNvItemInit(id, uint16(len(value)), nil)
+		if err != nil {
+			return err
+		}
+		if rsp.Status != znp.StatusSuccess && rsp.Status != znp.StatusItemCreatedAndInitialized {
+			return fmt.Errorf("nv: creating item 0x%04x failed: %s", id, rsp.Status)
+		}
+	case int(length) != len(value):
+		return fmt.Errorf("nv: item 0x%04x is %d bytes long, got %d bytes", id, length, len(value))
+	}
+	for offset := 0; offset < len(value); offset += maxChunk {
+		if err := writeChunk(z, id, offset, value[offset:min(offset+maxChunk, len(value))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeChunk(z *znp.Znp, id uint16, offset int, chunk []byte) error {
+	var rsp *znp.StatusResponse
+	var err error
+	if offset <= 0xFF {
+		rsp, err = z.SysOsalNvWrite(id, uint8(offset), chunk)
+	} else {
+		rsp, err = z.SysNvWriteExt(id, uint16(offset), chunk)
+	}
+	if err != nil {
+		return err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return fmt.Errorf("nv: writing item 0x%04x at offset %d failed: %s", id, offset, rsp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package nv
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+//fakeNv is an adapter storing the NV items in memory. Reads return at most readLimit bytes, like the firmware
+//truncates values which don't fit into a response.
+type fakeNv struct {
+	version   []byte
+	readLimit int
+	items     map[uint16][]byte
+	extended  map[[3]uint16][]byte
+}
+
+func newFakeNv() *fakeNv {
+	return &fakeNv{
+		version:   []byte{0x02, 0x01, 0x02, 0x07, 0x01, 0x14, 0x64, 0x34, 0x01},
+		readLimit: 100,
+		items:     map[uint16][]byte{},
+		extended:  map[[3]uint16][]byte{},
+	}
+}
+
+func (a *fakeNv) connect() *znp.Znp {
+	adapter := znptest.New()
+	adapter.Respond(unp.S_SYS, 0x02, a.version)
+	adapter.Handle(unp.S_SYS, 0x07, func(r *znptest.Request) []byte {
+		req := &znp.SysOsalNvItemInit{}
+		r.Decode(req)
+		if _, ok := a.items[req.ID]; ok {
+			return []byte{0x00}
+		}
+		a.items[req.ID] = make([]byte, req.ItemLen)
+		return []byte{0x09}
+	})
+	adapter.Handle(unp.S_SYS, 0x08, func(r *znptest.Request) []byte {
+		req := &znp.SysOsalNvRead{}
+		r.Decode(req)
+		return a.read(req.ID, int(req.Offset))
+	})
+	adapter.Handle(unp.S_SYS, 0x1C, func(r *znptest.Request) []byte {
+		req := &znp.SysNvReadExt{}
+		r.Decode(req)
+		return a.read(req.ID, int(req.Offset))
+	})
+	adapter.Handle(unp.S_SYS, 0x09, func(r *znptest.Request) []byte {
+		req := &znp.SysOsalNvWrite{}
+		r.Decode(req)
+		return a.write(req.ID, int(req.Offset), req.Value)
+	})
+	adapter.Handle(unp.S_SYS, 0x1D, func(r *znptest.Request) []byte {
+		req := &znp.SysNvWriteExt{}
+		r.Decode(req)
+		return a.write(req.ID, int(req.Offset), req.Value)
+	})
+	adapter.Handle(unp.S_SYS, 0x13, func(r *znptest.Request) []byte {
+		req := &znp.SysOsalNvLength{}
+		r.Decode(req)
+		return binary.LittleEndian.AppendUint16(nil, uint16(len(a.items[req.ID])))
+	})
+	adapter.Handle(unp.S_SYS, 0x32, func(r *znptest.Request) []byte {
+		req := &znp.SysNvLength{}
+		r.Decode(req)
+		value := a.extended[[3]uint16{uint16(req.SysID), req.ItemID, req.SubID}]
+		return binary.LittleEndian.AppendUint32(nil, uint32(len(value)))
+	})
+	adapter.Handle(unp.S_SYS, 0x33, func(r *znptest.Request) []byte {
+		req := &znp.SysNvRead{}
+		r.Decode(req)
+		value := a.extended[[3]uint16{uint16(req.SysID), req.ItemID, req.SubID}]
+		value = value[req.Offset:min(int(req.Offset)+int(req.Length), len(value))]
+		return append([]byte{0x00, uint8(len(value))}, value...)
+	})
+	z := znp.New(adapter.Unp())
+	z.Start()
+	return z
+}
+
+func (a *fakeNv) read(id uint16, offset int) []byte {
+	value, ok := a.items[id]
+	if !ok || offset > len(value) {
+		return []byte{0x0a, 0x00}
+	}
+	value = value[offset:min(offset+a.readLimit, len(value))]
+	return append([]byte{0x00, uint8(len(value))}, value...)
+}
+
+func (a *fakeNv) write(id uint16, offset int, value []byte) []byte {
+	item, ok := a.items[id]
+	if !ok || offset+len(value) > len(item) {
+		return []byte{0x0a}
+	}
+	copy(item[offset:], value)
+	return []byte{0x00}
+}
+
+func sequence(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return b
+}
+
+func (s *MySuite) TestTypedItems(c *C) {
+	a := newFakeNv()
+	a.items[PanID.ID] = []byte{0x62, 0x1a}
+	a.items[ChanList.ID] = []byte{0x00, 0x08, 0x00, 0x00}
+	a.items[ExtendedPanID.ID] = []byte{0x04, 0x03, 0x02, 0x01, 0x00, 0x4b, 0x12, 0x00}
+	z := a.connect()
+
+	panID, err := PanID.Get(z)
+	c.Assert(err, IsNil)
+	c.Assert(panID, Equals, uint16(0x1a62))
+	c.Assert(PanID.Set(z, 0xabcd), IsNil)
+	c.Assert(a.items[PanID.ID], DeepEquals, []byte{0xcd, 0xab})
+
+	channels, err := ChanList.Get(z)
+	c.Assert(err, IsNil)
+	c.Assert(channels, DeepEquals, &znp.Channels{Channel11: 1})
+
+	extendedPanID, err := ExtendedPanID.Get(z)
+	c.Assert(err, IsNil)
+	c.Assert(extendedPanID, Equals, "0x00124b0001020304")
+
+	c.Assert(PreCfgKey.Set(z, [16]uint8{1, 2, 3}), IsNil)
+	key, err := PreCfgKey.Get(z)
+	c.Assert(err, IsNil)
+	c.Assert(key, Equals, [16]uint8{1, 2, 3})
+
+	_, err = LogicalType.Get(z)
+	c.Assert(err, ErrorMatches, "nv: item doesn't exist: 0x0087")
+	c.Assert(PanID.Write(z, []byte{0x01}), ErrorMatches, "nv: item 0x0083 is 2 bytes long, got 1 bytes")
+}
+
+func (s *MySuite) TestLongItemIsReadInChunks(c *C) {
+	a := newFakeNv()
+	a.items[TclkTableStart.ID] = sequence(400)
+	z := a.connect()
+
+	value, err := TclkTableStart.Read(z)
+	c.Assert(err, IsNil)
+	c.Assert(value, DeepEquals, sequence(400))
+
+	c.Assert(Write(z, 0x0500, sequence(600)), IsNil)
+	c.Assert(a.items[0x0500], DeepEquals, sequence(600))
+}
+
+func (s *MySuite) TestLongOffsetsNeedZStack3(c *C) {
+	a := newFakeNv()
+	a.version = []byte{0x02, 0x00, 0x02, 0x06, 0x03}
+	a.items[TclkTableStart.ID] = sequence(400)
+	z := a.connect()
+
+	_, err := TclkTableStart.Read(z)
+	c.Assert(err, ErrorMatches, "command is not supported by the adapter: SysNvReadExt .*")
+}
+
+func (s *MySuite) TestDumpAndDiff(c *C) {
+	a := newFakeNv()
+	a.items[PanID.ID] = []byte{0x62, 0x1a}
+	a.items[NIB.ID] = sequence(116)
+	a.items[0x0102] = sequence(20)
+	a.extended[[3]uint16{1, ExNwkSecMaterialTable.ItemID, 0}] = []byte{0x01, 0, 0, 0, 4, 3, 2, 1, 0, 0x4b, 0x12, 0}
+	a.extended[[3]uint16{1, ExNwkSecMaterialTable.ItemID, 1}] = sequence(12)
+	old, err := Dump(a.connect())
+	c.Assert(err, IsNil)
+	c.Assert(old.Product, Equals, "ProductZStack3x0")
+	var keys []string
+	for _, e := range old.Items {
+		keys = append(keys, e.Name+" "+e.Key())
+	}
+	c.Assert(keys, DeepEquals, []string{
+		"ZCD_NV_NIB 0x0021",
+		"ZCD_NV_PANID 0x0083",
+		"ZCD_NV_TCLK_TABLE[1] 0x0102",
+		"ZCD_NV_EX_NWK_SEC_MATERIAL_TABLE[0] 1/0x0007/0x0000",
+		"ZCD_NV_EX_NWK_SEC_MATERIAL_TABLE[1] 1/0x0007/0x0001",
+	})
+
+	a.items[PanID.ID] = []byte{0x34, 0x12}
+	a.items[NIB.ID][5] = 0xff
+	delete(a.items, 0x0102)
+	a.extended[[3]uint16{1, ExNwkSecMaterialTable.ItemID, 0}][0] = 0x02
+	a.items[LogicalType.ID] = []byte{0x00}
+	new, err := Dump(a.connect())
+	c.Assert(err, IsNil)
+
+	var changes []string
+	for _, change := range Diff(old, new) {
+		changes = append(changes, change.String())
+	}
+	c.Assert(changes, DeepEquals, []string{
+		"ZCD_NV_NIB (0x0021)\n\t- " + hex.EncodeToString(sequence(116)) +
+			"\n\t+ " + hex.EncodeToString(a.items[NIB.ID]) + "\n",
+		"ZCD_NV_PANID (0x0083)\n\tPanID: 0x1a62 -> 0x1234\n",
+		"ZCD_NV_TCLK_TABLE[1] (0x0102)\n\t- " + hex.EncodeToString(sequence(20)) + "\n",
+		"ZCD_NV_EX_NWK_SEC_MATERIAL_TABLE[0] (1/0x0007/0x0000)\n\tFrameCounter: 0x1 -> 0x2\n",
+		"ZCD_NV_LOGICAL_TYPE (0x0087)\n\t+ 00\n",
+	})
+}
@@ -0,0 +1,169 @@
+//Package nwkkey rotates the network key of a network formed by the adapter.
+//
+//	result, err := (&nwkkey.Rotator{Devices: routers}).Rotate(ctx, z)
+//	fmt.Println(result.KeySeqNum, result.Unacknowledged)
+package nwkkey
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/nv"
+)
+
+//broadcast reaches all devices, sleepy end devices receive the key from their parents
+const broadcast = "0xffff"
+
+//Rotator contains options of the key rotation. The zero value is a valid configuration.
+type Rotator struct {
+	Key           *[16]uint8    //New key. Default is a random key
+	UseAdapterRNG bool          //Generate the key with UtilSrngGen instead of crypto/rand
+	Propagation   time.Duration //Delay between the distribution of the key and the switch. Default is 30s
+	//Devices are the network addresses of the devices checked after the switch. Sleepy end devices answer
+	//only when they poll, use a longer ResponseTimeout for them
+	Devices         []string
+	ResponseTimeout time.Duration //Time the devices have to answer after the switch. Default is 10s
+}
+
+//Rotation is the outcome of Rotate
+type Rotation struct {
+	KeySeqNum uint8
+	Key       [16]uint8
+	//Unacknowledged are the devices which didn't answer with the new key, or answered with a failure, they have
+	//to rejoin
+	Unacknowledged []string
+}
+
+//Rotate distributes a new key with the next sequence number, switches the network to it and stores it as the
+//active and the preconfigured key in NV, so that it survives a reset of the adapter. The devices are then queried
+//for their node descriptor to find the ones which missed the key.
+//
+//Canceling the context during the propagation delay aborts the rotation, the distributed key isn't switched to then.
+//Once the network switched, canceling it only cuts the check of the devices short.
+func (r *Rotator) Rotate(ctx context.Context, z *znp.Znp) (*Rotation, error) {
+	current := &nv.NwkKeyInfo{}
+	if err := nv.NwkActiveKeyInfo.Decode(z, current); err != nil {
+		return nil, err
+	}
+	key, err := r.key(z)
+	if err != nil {
+		return nil, err
+	}
+	result := &Rotation{KeySeqNum: current.KeySeqNum + 1, Key: key}
+
+	if err := status(z.ZdoExtUpdateNwkKey(broadcast, result.KeySeqNum, key)); err != nil {
+		return nil, fmt.Errorf("nwkkey: distributing the key failed: %w", err)
+	}
+	propagation := time.NewTimer(durationOrDefault(r.Propagation, 30*time.Second))
+	defer propagation.Stop()
+	select {
+	case <-propagation.C:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	subscription := z.Subscribe()
+	defer subscription.Unsubscribe()
+	if err := status(z.ZdoExtSwitchNwkKey(broadcast, result.KeySeqNum)); err != nil {
+		return nil, fmt.Errorf("nwkkey: switching the key failed: %w", err)
+	}
+	if err := persist(z, result); err != nil {
+		return nil, err
+	}
+	result.Unacknowledged = r.check(ctx, z, subscription)
+	return result, nil
+}
+
+func (r *Rotator) key(z *znp.Znp) (key [16]uint8, err error) {
+	switch {
+	case r.Key != nil:
+		key = *r.Key
+	case r.UseAdapterRNG:
+		var rsp *znp.UtilSrngGenResponse
+		if rsp, err = z.UtilSrngGen(); err == nil {
+			copy(key[:], rsp.SecureRandomNumbers[:])
+		}
+	default:
+		_, err = rand.Read(key[:])
+	}
+	return
+}
+
+//persist updates the key items unless the firmware already stored them
+func persist(z *znp.Znp, result *Rotation) error {
+	active := &nv.NwkKeyInfo{}
+	if err := nv.NwkActiveKeyInfo.Decode(z, active); err != nil {
+		return err
+	}
+	if active.KeySeqNum != result.KeySeqNum || active.Key != result.Key {
+		active = &nv.NwkKeyInfo{KeySeqNum: result.KeySeqNum, Key: result.Key}
+		if err := nv.NwkActiveKeyInfo.Encode(z, active); err != nil {
+			return fmt.Errorf("nwkkey: storing the active key failed: %w", err)
+		}
+	}
+	if err := nv.PreCfgKey.Set(z, result.Key); err != nil {
+		return fmt.Errorf("nwkkey: storing the preconfigured key failed: %w", err)
+	}
+	return nil
+}
+
+//check requests the node descriptor of every device and returns the ones which didn't answer successfully. The
+//requests are sent while the responses are collected, so that the subscription doesn't overflow.
+func (r *Rotator) check(ctx context.Context, z *znp.Znp, subscription *znp.Subscription) []string {
+	pending := map[string]bool{}
+	for _, device := range r.Devices {
+		pending[strings.ToLower(device)] = true
+	}
+	go func() {
+		for _, device := range r.Devices {
+			z.ZdoNodeDescReq(device, device)
+		}
+	}()
+	deadline := time.NewTimer(durationOrDefault(r.ResponseTimeout, 10*time.Second))
+	defer deadline.Stop()
+	for len(pending) > 0 {
+		select {
+		case async := <-subscription.Events():
+			if rsp, ok := async.(*znp.ZdoNodeDescRsp); ok && rsp.Status == znp.StatusSuccess {
+				delete(pending, rsp.SrcAddr)
+			}
+		case <-deadline.C:
+			return r.unacknowledged(pending)
+		case <-ctx.Done():
+			return r.unacknowledged(pending)
+		}
+	}
+	return nil
+}
+
+//unacknowledged returns the devices which are still pending, in the order of Devices
+func (r *Rotator) unacknowledged(pending map[string]bool) []string {
+	var unacknowledged []string
+	for _, device := range r.Devices {
+		if pending[strings.ToLower(device)] {
+			unacknowledged = append(unacknowledged, device)
+		}
+	}
+	return unacknowledged
+}
+
+func status(rsp *znp.StatusResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return fmt.Errorf("status %s", rsp.Status)
+	}
+	return nil
+}
+
+func durationOrDefault(d time.Duration, def time.Duration) time.Duration {
+	if d == 0 {
+		return def
+	}
+	return d
+}
@@ -0,0 +1,106 @@
+package nwkkey
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	"github.com/dyrkin/znp-go/nv"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+//fakeAdapter stores NV items and answers node descriptor requests of the alive devices with their status
+type fakeAdapter struct {
+	*znptest.Adapter
+	items   map[uint16][]byte
+	alive   map[string]znp.Status
+	updates chan *znp.ZdoExtUpdateNwkKey
+}
+
+func (a *fakeAdapter) connect() *znp.Znp {
+	a.Adapter = znptest.New()
+	a.Handle(unp.S_SYS, 0x13, func(r *znptest.Request) []byte {
+		id := binary.LittleEndian.Uint16(r.Payload)
+		return binary.LittleEndian.AppendUint16(nil, uint16(len(a.items[id])))
+	})
+	a.Handle(unp.S_SYS, 0x08, func(r *znptest.Request) []byte {
+		value := a.items[binary.LittleEndian.Uint16(r.Payload)]
+		return append([]byte{0x00, uint8(len(value))}, value...)
+	})
+	a.Handle(unp.S_SYS, 0x09, func(r *znptest.Request) []byte {
+		req := &znp.SysOsalNvWrite{}
+		r.Decode(req)
+		copy(a.items[req.ID][req.Offset:], req.Value)
+		return nil
+	})
+	a.Handle(unp.S_ZDO, 0x4E, func(r *znptest.Request) []byte {
+		req := &znp.ZdoExtUpdateNwkKey{}
+		r.Decode(req)
+		a.updates <- req
+		return nil
+	})
+	a.Handle(unp.S_ZDO, 0x02, func(r *znptest.Request) []byte {
+		req := &znp.ZdoNodeDescReq{}
+		r.Decode(req)
+		if status, ok := a.alive[req.DstAddr]; ok {
+			r.Reply(unp.S_ZDO, 0x82, append(append(r.Payload[:2:2], uint8(status)), make([]byte, 13)...))
+		}
+		return nil
+	})
+	z := znp.New(a.Unp())
+	z.Start()
+	return z
+}
+
+func (s *MySuite) TestRotate(c *C) {
+	a := &fakeAdapter{
+		items: map[uint16][]byte{
+			nv.NwkActiveKeyInfo.ID: append([]byte{0x04}, make([]byte, 16)...),
+			nv.PreCfgKey.ID:        make([]byte, 16),
+		},
+		alive:   map[string]znp.Status{"0x1111": znp.StatusSuccess, "0x3333": znp.StatusZdpTimeout},
+		updates: make(chan *znp.ZdoExtUpdateNwkKey, 1),
+	}
+	z := a.connect()
+
+	key := [16]uint8{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	rotator := &Rotator{Key: &key, Propagation: time.Millisecond, Devices: []string{"0x1111", "0x2222", "0x3333"},
+		ResponseTimeout: 100 * time.Millisecond}
+	result, err := rotator.Rotate(context.Background(), z)
+	c.Assert(err, IsNil)
+	c.Assert(result, DeepEquals, &Rotation{KeySeqNum: 5, Key: key, Unacknowledged: []string{"0x2222", "0x3333"}})
+	c.Assert(<-a.updates, DeepEquals, &znp.ZdoExtUpdateNwkKey{DestinationAddress: "0xffff", KeySeqNum: 5, Key: key})
+	c.Assert(a.items[nv.NwkActiveKeyInfo.ID], DeepEquals, append([]byte{0x05}, key[:]...))
+	c.Assert(a.items[nv.PreCfgKey.ID], DeepEquals, key[:])
+}
+
+func (s *MySuite) TestRotateIsCanceledBeforeTheSwitch(c *C) {
+	a := &fakeAdapter{
+		items:   map[uint16][]byte{nv.NwkActiveKeyInfo.ID: append([]byte{0x04}, make([]byte, 16)...)},
+		updates: make(chan *znp.ZdoExtUpdateNwkKey, 1),
+	}
+	z := a.connect()
+	switched := make(chan struct{}, 1)
+	a.Handle(unp.S_ZDO, 0x4F, func(*znptest.Request) []byte {
+		switched <- struct{}{}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := (&Rotator{Propagation: time.Minute}).Rotate(ctx, z)
+	c.Assert(err, Equals, context.DeadlineExceeded)
+	c.Assert(<-a.updates, NotNil)
+	c.Assert(switched, HasLen, 0)
+	c.Assert(a.items[nv.NwkActiveKeyInfo.ID][0], Equals, uint8(0x04))
+}
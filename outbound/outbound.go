@@ -0,0 +1,295 @@
+//Package outbound queues AF messages in front of the adapter. It caps the requests in flight, pauses when the adapter
+//runs out of buffers and holds the messages for sleeping end devices until they wake up.
+//
+//	sender := af.NewSender(z)
+//	s := outbound.New(z, sender, outbound.Options{})
+//	err := s.Send(ctx, outbound.PriorityCommand, af.Destination{Addr: "0x1a2b", Endpoint: 1}, 0x0006, zcl, nil)
+package outbound
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/af"
+)
+
+var errStopped = errors.New("outbound: scheduler stopped")
+
+//Priority of a message, messages of a higher priority are sent first
+type Priority uint8
+
+const (
+	PriorityCommand   Priority = iota //Commands sent on behalf of a user
+	PriorityDefault                   //Everything else
+	PriorityReporting                 //Reporting configuration and other background traffic
+)
+
+//Options of the scheduler. The zero value is a valid configuration.
+type Options struct {
+	MaxInFlight               int //Messages awaiting their confirmation. Default is 4
+	MaxInFlightPerDestination int //Default is 1
+	//Backoff is the pause after the adapter reported it is busy, doubled for each consecutive report up to
+	//MaxBackoff. Default is 250ms
+	Backoff    time.Duration
+	MaxBackoff time.Duration //Default is 8s
+	//AwakeWindow is how long a sleeping end device receives messages after it was seen. Default is 5s
+	AwakeWindow time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxInFlight == 0 {
+		o.MaxInFlight = 4
+	}
+	if o.MaxInFlightPerDestination == 0 {
+		o.MaxInFlightPerDestination = 1
+	}
+	if o.Backoff == 0 {
+		o.Backoff = 250 * time.Millisecond
+	}
+	if o.MaxBackoff == 0 {
+		o.MaxBackoff = 8 * time.Second
+	}
+	if o.AwakeWindow == 0 {
+		o.AwakeWindow = 5 * time.Second
+	}
+	return o
+}
+
+type message struct {
+	ctx      context.Context
+	priority Priority
+	seq      uint64
+	dst      af.Destination
+	cluster  uint16
+	data     []byte
+	options  *af.SendOptions
+	done     chan error
+}
+
+//Scheduler sends the queued messages through an af.Sender
+type Scheduler struct {
+	sender       *af.Sender
+	subscription *znp.Subscription
+	options      Options
+	wake         chan struct{}
+	stop         chan struct{}
+
+	mu          sync.Mutex
+	queue       []*message //ordered by priority and then by seq
+	seq         uint64
+	inFlight    int
+	perDst      map[string]int
+	sleepy      map[string]bool
+	seen        map[string]time.Time
+	backoff     time.Duration
+	pausedUntil time.Time
+	stopped     bool
+}
+
+//New returns a scheduler watching the adapter for announcements and messages of end devices. Call Stop when it is
+//no longer needed.
+func New(z *znp.Znp, sender *af.Sender, options Options) *Scheduler {
+	s := &Scheduler{
+		sender:       sender,
+		subscription: z.Subscribe(),
+		options:      options.withDefaults(),
+		wake:         make(chan struct{}, 1),
+		stop:         make(chan struct{}),
+		perDst:       map[string]int{},
+		sleepy:       map[string]bool{},
+		seen:         map[string]time.Time{},
+	}
+	go s.watch()
+	go s.dispatch()
+	return s
+}
+
+//Send queues the message and waits until it is delivered, failed or the context is done. Messages to sleeping end
+//devices are held until the device announces itself or sends a message.
+func (s *Scheduler) Send(ctx context.Context, priority Priority, dst af.Destination, cluster uint16, data []byte,
+	options *af.SendOptions) error {
+	m := &message{ctx: ctx, priority: priority, dst: dst, cluster: cluster, data: data, options: options,
+		done: make(chan error, 1)}
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return errStopped
+	}
+	s.seq++
+	m.seq = s.seq
+	s.enqueueLocked(m)
+	s.mu.Unlock()
+	s.signal()
+
+	select {
+	case err := <-m.done:
+		return err
+	case <-ctx.Done():
+		if s.remove(m) {
+			return ctx.Err()
+		}
+		//the message is in flight and is sent with the same context
+		return <-m.done
+	}
+}
+
+//SetSleepy marks the device with the network address as a sleeping end device or not. End devices are marked
+//from their announcements, and when a message to them expired.
+func (s *Scheduler) SetSleepy(addr string, sleepy bool) {
+	s.mu.Lock()
+	s.sleepy[strings.ToLower(addr)] = sleepy
+	s.mu.Unlock()
+	s.signal()
+}
+
+//Pending returns the number of queued messages, including the held ones
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+//Stop fails the queued messages. Messages in flight complete.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+	s.stopped = true
+	close(s.stop)
+	s.subscription.Unsubscribe()
+	for _, m := range s.queue {
+		m.done <- errStopped
+	}
+	s.queue = nil
+}
+
+func (s *Scheduler) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) enqueueLocked(m *message) {
+	i := len(s.queue)
+	for i > 0 && (s.queue[i-1].priority > m.priority ||
+		s.queue[i-1].priority == m.priority && s.queue[i-1].seq > m.seq) {
+		i--
+	}
+	s.queue = append(s.queue, nil)
+	copy(s.queue[i+1:], s.queue[i:])
+	s.queue[i] = m
+}
+
+func (s *Scheduler) remove(m *message) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, queued := range s.queue {
+		if queued == m {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Scheduler) dispatch() {
+	for {
+		s.mu.Lock()
+		now := time.Now()
+		var timer <-chan time.Time
+		if now.Before(s.pausedUntil) {
+			timer = time.After(s.pausedUntil.Sub(now))
+		} else {
+			s.startLocked(now)
+		}
+		s.mu.Unlock()
+		select {
+		case <-s.wake:
+		case <-timer:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+//startLocked sends the queued messages in order, skipping those whose destination is busy or asleep
+func (s *Scheduler) startLocked(now time.Time) {
+	for i := 0; i < len(s.queue) && s.inFlight < s.options.MaxInFlight; {
+		m := s.queue[i]
+		key := strings.ToLower(m.dst.Addr)
+		if s.perDst[key] >= s.options.MaxInFlightPerDestination ||
+			s.sleepy[key] && now.Sub(s.seen[key]) > s.options.AwakeWindow {
+			i++
+			continue
+		}
+		s.queue = append(s.queue[:i], s.queue[i+1:]...)
+		s.inFlight++
+		s.perDst[key]++
+		go s.send(m, key)
+	}
+}
+
+func (s *Scheduler) send(m *message, key string) {
+	err := s.sender.SendAF(m.ctx, m.dst, m.cluster, m.data, m.options)
+	s.mu.Lock()
+	s.inFlight--
+	if s.perDst[key]--; s.perDst[key] == 0 {
+		delete(s.perDst, key)
+	}
+	requeue := false
+	switch {
+	case errors.Is(err, af.ErrBusy):
+		s.backoff = min(max(2*s.backoff, s.options.Backoff), s.options.MaxBackoff)
+		s.pausedUntil = time.Now().Add(s.backoff)
+		requeue = true
+	case errors.Is(err, af.ErrExpired):
+		//the device didn't poll in time, hold the message until it is seen again
+		s.sleepy[key] = true
+		delete(s.seen, key)
+		requeue = true
+	case err == nil:
+		s.backoff = 0
+	}
+	if requeue && !s.stopped && m.ctx.Err() == nil {
+		s.enqueueLocked(m)
+	} else {
+		m.done <- err
+	}
+	s.mu.Unlock()
+	s.signal()
+}
+
+func (s *Scheduler) watch() {
+	for async := range s.subscription.Events() {
+		switch async := async.(type) {
+		case *znp.ZdoEndDeviceAnnceInd:
+			key := strings.ToLower(async.NwkAddr)
+			s.mu.Lock()
+			s.sleepy[key] = async.Capabilities != nil && async.Capabilities.ReceiverOnWhenIdle == 0
+			s.seen[key] = time.Now()
+			s.mu.Unlock()
+		case *znp.AfIncomingMessage:
+			s.saw(async.SrcAddr)
+		case *znp.AfIncomingMessageExt:
+			if async.SrcAddrMode == znp.AddrModeAddr16Bit && len(async.SrcAddr) == 18 {
+				s.saw("0x" + async.SrcAddr[14:])
+			}
+		default:
+			continue
+		}
+		s.signal()
+	}
+}
+
+func (s *Scheduler) saw(addr string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seen[strings.ToLower(addr)] = time.Now()
+}
@@ -0,0 +1,93 @@
+package outbound
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/af"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+//fakeAdapter rejects the first requests with the statuses and confirms the others
+type fakeAdapter struct {
+	*znptest.Adapter
+
+	mu       sync.Mutex
+	statuses []znp.Status
+	clusters []uint16 //clusters of the accepted requests
+}
+
+func (a *fakeAdapter) handle(r *znptest.Request) []byte {
+	req := &znp.AfDataRequestExt{}
+	r.Decode(req)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.statuses) > 0 {
+		status := a.statuses[0]
+		a.statuses = a.statuses[1:]
+		return []byte{uint8(status)}
+	}
+	a.clusters = append(a.clusters, req.ClusterID)
+	r.Reply(unp.S_AF, 0x80, &znp.AfDataConfirm{Endpoint: req.SrcEndpoint, TransID: req.TransID})
+	return nil
+}
+
+func (a *fakeAdapter) accepted() []uint16 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]uint16(nil), a.clusters...)
+}
+
+func (a *fakeAdapter) connect() *Scheduler {
+	a.Adapter = znptest.New()
+	a.Handle(unp.S_AF, 0x02, a.handle)
+	z := znp.New(a.Unp())
+	z.Start()
+	return New(z, af.NewSender(z), Options{Backoff: time.Millisecond})
+}
+
+var dst = af.Destination{Addr: "0x1a2b", Endpoint: 1}
+
+func (s *MySuite) TestBackoff(c *C) {
+	a := &fakeAdapter{statuses: []znp.Status{znp.StatusBufferFull, znp.StatusMemError}}
+	scheduler := a.connect()
+	defer scheduler.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c.Assert(scheduler.Send(ctx, PriorityDefault, dst, 0x0006, []byte{0x01}, nil), IsNil)
+	c.Assert(a.accepted(), DeepEquals, []uint16{0x0006})
+}
+
+func (s *MySuite) TestSleepyDevice(c *C) {
+	a := &fakeAdapter{}
+	scheduler := a.connect()
+	defer scheduler.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	scheduler.SetSleepy(dst.Addr, true)
+
+	results := make(chan error, 2)
+	go func() { results <- scheduler.Send(ctx, PriorityReporting, dst, 0x0001, []byte{0x01}, nil) }()
+	time.Sleep(10 * time.Millisecond)
+	go func() { results <- scheduler.Send(ctx, PriorityCommand, dst, 0x0006, []byte{0x01}, nil) }()
+	time.Sleep(10 * time.Millisecond)
+	c.Assert(scheduler.Pending(), Equals, 2)
+	c.Assert(a.accepted(), HasLen, 0)
+
+	a.Send(unp.S_ZDO, 0xC1, &znp.ZdoEndDeviceAnnceInd{SrcAddr: "0x1a2b", NwkAddr: "0x1a2b",
+		IEEEAddr: "0x00124b0001020304", Capabilities: &znp.CapInfo{}})
+	c.Assert(<-results, IsNil)
+	c.Assert(<-results, IsNil)
+	c.Assert(a.accepted(), DeepEquals, []uint16{0x0006, 0x0001})
+}
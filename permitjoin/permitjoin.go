@@ -0,0 +1,249 @@
+//Package permitjoin keeps devices allowed to join for arbitrary durations. ZDO_MGMT_PERMIT_JOIN_REQ opens joining
+//for at most 254 seconds, the controller re-issues it until the requested duration is over.
+//
+//	c := permitjoin.New(z)
+//	c.Open(5 * time.Minute)
+//	for state := range c.Events() {
+//		fmt.Println(state.Open, state.Remaining)
+//	}
+package permitjoin
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/dyrkin/znp-go"
+)
+
+const (
+	//Network targets the coordinator and all routers
+	Network = "0xfffc"
+	//Coordinator targets the adapter only
+	Coordinator = "0x0000"
+)
+
+//State of joining
+type State struct {
+	Open bool
+	//Target is Network, Coordinator or the network address of the router joining was opened through
+	Target    string
+	Remaining time.Duration
+	//Err is set when joining closed early because it couldn't be re-opened
+	Err error
+}
+
+//Controller opens and closes joining and reports its state
+type Controller struct {
+	z            *znp.Znp
+	subscription *znp.Subscription
+	events       chan State
+
+	tick      time.Duration //interval of the countdown events
+	maxWindow time.Duration //longest duration of a single request
+	margin    time.Duration //time left in a window when the request is re-issued
+
+	mu       sync.Mutex
+	open     bool
+	target   string
+	deadline time.Time
+	session  chan struct{} //closed to stop the countdown of the current session
+	owned    bool          //the session was opened by the controller
+}
+
+//New returns a controller watching the ZdoPermitJoinInd of the adapter, so that joining opened by other means is
+//reported too. Call Stop when it is no longer needed.
+func New(z *znp.Znp) *Controller {
+	c := &Controller{
+		z:            z,
+		subscription: z.Subscribe(),
+		events:       make(chan State, 100),
+		tick:         time.Second,
+		maxWindow:    254 * time.Second,
+		margin:       5 * time.Second,
+	}
+	go c.watch()
+	return c
+}
+
+//Events returns the channel state changes and the countdown, once per second, are delivered to. Events are
+//dropped when nobody reads the channel.
+func (c *Controller) Events() chan State {
+	return c.events
+}
+
+//State returns the current state
+func (c *Controller) State() State {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stateLocked(time.Now())
+}
+
+//Open allows devices to join through the coordinator and all routers for the duration
+func (c *Controller) Open(duration time.Duration) error {
+	return c.OpenVia(Network, duration)
+}
+
+//OpenVia allows devices to join only through the router, or the adapter when the target is Coordinator. A
+//session opened before is replaced.
+func (c *Controller) OpenVia(target string, duration time.Duration) error {
+	if duration <= 0 {
+		return c.Close()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	window := c.window(duration)
+	if err := c.permit(target, window); err != nil {
+		return err
+	}
+	c.start(target, now.Add(duration), now.Add(window))
+	c.owned = true
+	return nil
+}
+
+//Close disallows joining through the target of the current session. Joining is closed on the whole network when
+//no session is active.
+func (c *Controller) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	target := c.target
+	if !c.open {
+		target = Network
+	}
+	if err := c.permit(target, 0); err != nil {
+		return err
+	}
+	c.stopLocked()
+	c.open = false
+	c.emitLocked(nil)
+	return nil
+}
+
+//Stop stops the countdown and the watching of the adapter. Joining is left as it is.
+func (c *Controller) Stop() {
+	c.subscription.Unsubscribe()
+	c.mu.Lock()
+	c.stopLocked()
+	c.mu.Unlock()
+}
+
+func (c *Controller) permit(target string, window time.Duration) error {
+	addrMode := znp.AddrModeAddr16Bit
+	if target == Network {
+		addrMode = znp.AddrModeAddrBroadcast
+	}
+	seconds := uint8(math.Ceil(window.Seconds()))
+	rsp, err := c.z.ZdoMgmtPermitJoinReq(addrMode, target, seconds, 0)
+	if err != nil {
+		return err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return fmt.Errorf("permitjoin: request to %s failed: %s", target, rsp.Status)
+	}
+	return nil
+}
+
+//window is the duration of the next request
+func (c *Controller) window(remaining time.Duration) time.Duration {
+	return min(remaining, c.maxWindow)
+}
+
+//start begins a countdown session. Requests are re-issued while the current window ends before the deadline.
+func (c *Controller) start(target string, deadline time.Time, windowEnd time.Time) {
+	c.stopLocked()
+	session := make(chan struct{})
+	c.open, c.target, c.deadline, c.session, c.owned = true, target, deadline, session, false
+	c.emitLocked(nil)
+	go c.countdown(session, target, deadline, windowEnd)
+}
+
+//countdown emits the remaining time and re-issues the request. The request is sent under the lock, so that a
+//session closed meanwhile isn't opened again.
+func (c *Controller) countdown(session chan struct{}, target string, deadline time.Time, windowEnd time.Time) {
+	ticker := time.NewTicker(c.tick)
+	defer ticker.Stop()
+	var err error
+	for {
+		select {
+		case <-session:
+			return
+		case now := <-ticker.C:
+			c.mu.Lock()
+			if c.session != session {
+				c.mu.Unlock()
+				return
+			}
+			if windowEnd.Before(deadline) && windowEnd.Sub(now) <= c.margin {
+				window := c.window(deadline.Sub(now))
+				if err = c.permit(target, window); err == nil {
+					windowEnd = now.Add(window)
+				}
+			}
+			if !now.Before(windowEnd) {
+				c.open, c.session = false, nil
+				if now.Before(deadline) {
+					c.emitLocked(err)
+				} else {
+					c.emitLocked(nil)
+				}
+				c.mu.Unlock()
+				return
+			}
+			c.emitLocked(nil)
+			c.mu.Unlock()
+		}
+	}
+}
+
+func (c *Controller) watch() {
+	for async := range c.subscription.Events() {
+		if ind, ok := async.(*znp.ZdoPermitJoinInd); ok {
+			c.indicated(time.Duration(ind.PermitJoinDuration) * time.Second)
+		}
+	}
+}
+
+//indicated tracks joining opened by other means, e.g. by another application. The indications of the adapter
+//are ignored during the sessions of the controller, as they are caused by the re-issued requests.
+func (c *Controller) indicated(duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session != nil && c.owned {
+		return
+	}
+	if duration == 0 {
+		c.stopLocked()
+		if c.open {
+			c.open = false
+			c.emitLocked(nil)
+		}
+		return
+	}
+	now := time.Now()
+	c.start(Coordinator, now.Add(duration), now.Add(duration))
+}
+
+func (c *Controller) stopLocked() {
+	if c.session != nil {
+		close(c.session)
+		c.session = nil
+	}
+}
+
+func (c *Controller) stateLocked(now time.Time) State {
+	if !c.open {
+		return State{}
+	}
+	return State{Open: true, Target: c.target, Remaining: max(c.deadline.Sub(now), 0).Round(time.Second)}
+}
+
+func (c *Controller) emitLocked(err error) {
+	state := c.stateLocked(time.Now())
+	state.Err = err
+	select {
+	case c.events <- state:
+	default:
+	}
+}
@@ -0,0 +1,123 @@
+package permitjoin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+//connect returns a controller talking to a fake adapter, which records the permit join requests
+func connect() (*Controller, *znptest.Adapter, chan *znp.ZdoMgmtPermitJoinReq) {
+	a := znptest.New()
+	requests := make(chan *znp.ZdoMgmtPermitJoinReq, 100)
+	a.Handle(unp.S_ZDO, 0x36, func(r *znptest.Request) []byte {
+		req := &znp.ZdoMgmtPermitJoinReq{}
+		r.Decode(req)
+		requests <- req
+		return nil
+	})
+	z := znp.New(a.Unp())
+	z.Start()
+	c := New(z)
+	c.tick, c.maxWindow, c.margin = 20*time.Millisecond, time.Second, 900*time.Millisecond
+	return c, a, requests
+}
+
+func lastEvent(c *Controller, timeout time.Duration) (state State) {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case state = <-c.Events():
+			if !state.Open {
+				return
+			}
+		case <-deadline:
+			return
+		}
+	}
+}
+
+func (s *MySuite) TestOpenIsReissued(c *C) {
+	controller, _, requests := connect()
+	defer controller.Stop()
+
+	c.Assert(controller.Open(1500*time.Millisecond), IsNil)
+	c.Assert(controller.State().Open, Equals, true)
+	c.Assert(controller.State().Target, Equals, Network)
+
+	first := <-requests
+	c.Assert(first, DeepEquals, &znp.ZdoMgmtPermitJoinReq{AddrMode: znp.AddrModeAddrBroadcast, DstAddr: "0xfffc",
+		Duration: 1})
+	c.Assert(<-requests, NotNil)
+
+	c.Assert(lastEvent(controller, 3*time.Second), Equals, State{})
+	c.Assert(controller.State(), Equals, State{})
+}
+
+func (s *MySuite) TestCloseTargetsTheRouter(c *C) {
+	controller, _, requests := connect()
+	defer controller.Stop()
+
+	c.Assert(controller.OpenVia("0x1234", time.Minute), IsNil)
+	c.Assert(controller.Close(), IsNil)
+	c.Assert(<-requests, DeepEquals, &znp.ZdoMgmtPermitJoinReq{AddrMode: znp.AddrModeAddr16Bit, DstAddr: "0x1234",
+		Duration: 1})
+	c.Assert(<-requests, DeepEquals, &znp.ZdoMgmtPermitJoinReq{AddrMode: znp.AddrModeAddr16Bit, DstAddr: "0x1234"})
+	c.Assert(controller.State().Open, Equals, false)
+}
+
+func (s *MySuite) TestCloseDuringReissue(c *C) {
+	controller, a, requests := connect()
+	defer controller.Stop()
+	reissued, release := make(chan struct{}), make(chan struct{})
+	a.Handle(unp.S_ZDO, 0x36, func(r *znptest.Request) []byte {
+		req := &znp.ZdoMgmtPermitJoinReq{}
+		r.Decode(req)
+		if len(requests) == 1 {
+			close(reissued)
+			<-release
+		}
+		requests <- req
+		return nil
+	})
+
+	c.Assert(controller.Open(time.Minute), IsNil)
+	<-reissued
+	closed := make(chan error)
+	go func() { closed <- controller.Close() }()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	c.Assert(<-closed, IsNil)
+
+	c.Assert(<-requests, NotNil)
+	c.Assert(<-requests, NotNil)
+	c.Assert(<-requests, DeepEquals, &znp.ZdoMgmtPermitJoinReq{AddrMode: znp.AddrModeAddrBroadcast,
+		DstAddr: "0xfffc"})
+	time.Sleep(100 * time.Millisecond)
+	c.Assert(requests, HasLen, 0)
+	c.Assert(controller.State().Open, Equals, false)
+}
+
+func (s *MySuite) TestIndicationIsTracked(c *C) {
+	controller, a, _ := connect()
+	defer controller.Stop()
+
+	a.Send(unp.S_ZDO, 0xCB, []byte{30})
+	state := <-controller.Events()
+	c.Assert(state.Open, Equals, true)
+	c.Assert(state.Target, Equals, Coordinator)
+	c.Assert(state.Remaining, Equals, 30*time.Second)
+
+	a.Send(unp.S_ZDO, 0xCB, []byte{0})
+	c.Assert(lastEvent(controller, time.Second), Equals, State{})
+}
@@ -3,6 +3,7 @@ package znp
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/dyrkin/unp-go"
@@ -13,16 +14,23 @@ import (
 )
 
 func (znp *Znp) Start() {
+	znp.started = true
 	startProcessors(znp)
 	startIncomingFrameLoop(znp)
-	znp.started = true
+	znp.probing = make(chan struct{})
+	go znp.probeOnStart(znp.probing)
+	znp.log(slog.LevelInfo, "znp started")
 }
 
 func (znp *Znp) Stop() {
 	znp.started = false
+	znp.log(slog.LevelInfo, "znp stopped")
 }
 
 func (znp *Znp) ProcessRequest(commandType unp.CommandType, subsystem unp.Subsystem, command byte, req interface{}, resp interface{}) error {
+	if err := znp.requireSubsystem(subsystem); err != nil {
+		return err
+	}
 	frame := &unp.Frame{
 		CommandType: commandType,
 		Subsystem:   subsystem,
@@ -45,6 +53,7 @@ func processFrame(znp *Znp, frame *unp.Frame, resp interface{}) (err error) {
 			select {
 			case frame := <-outgoing.SyncRsp():
 				bin.Decode(frame.Payload, resp)
+				znp.logMessage("response received", resp)
 			case err = <-outgoing.SyncErr():
 			}
 		case unp.C_AREQ:
@@ -89,8 +98,10 @@ func startProcessors(znp *Znp) {
 				case unp.C_AREQ:
 					asyncResponseProcessor(frame)
 				default:
+					err := fmt.Errorf("unsupported frame received type: %v ", frame)
+					znp.log(slog.LevelWarn, "unsupported frame received", "error", err)
 					select {
-					case znp.errors <- fmt.Errorf("unsupported frame received type: %v ", frame):
+					case znp.errors <- err:
 					default:
 					}
 				}
@@ -106,12 +117,13 @@ func startIncomingFrameLoop(znp *Znp) {
 		for znp.started {
 			frame, err := znp.u.ReadFrame()
 			if err != nil {
+				znp.log(slog.LevelError, "failed to read frame", "error", err)
 				select {
 				case znp.errors <- err:
 				default:
 				}
 			} else {
-				logFrame(frame, znp.inFramesLog)
+				znp.frameReceived(frame)
 				znp.inbound <- frame
 			}
 		}
@@ -123,22 +135,31 @@ func makeSyncRequestProcessor(znp *Znp, syncRsp chan *unp.Frame, syncErr chan er
 	return func(req *request.Sync) {
 		frame := req.Frame()
 		deadline := time.NewTimer(5 * time.Second)
-		logFrame(frame, znp.outFramesLog)
+		znp.frameSent(frame)
+		sent := time.Now()
 		err := znp.u.WriteFrame(frame)
 		if err != nil {
+			znp.metrics.SyncRequestFailed(frame.Subsystem, frame.Command, err)
+			znp.log(slog.LevelError, "failed to write frame", "error", err)
 			req.SyncErr() <- err
 			return
 		}
 		select {
 		case _ = <-deadline.C:
 			if !deadline.Stop() {
-				req.SyncErr() <- fmt.Errorf("timed out while waiting response for command: 0x%x sent to subsystem: %s ", frame.Command, frame.Subsystem)
+				err := fmt.Errorf("timed out while waiting response for command: 0x%x sent to subsystem: %s ", frame.Command, frame.Subsystem)
+				znp.metrics.SyncRequestTimedOut(frame.Subsystem, frame.Command)
+				znp.log(slog.LevelWarn, "request timed out", "error", err)
+				req.SyncErr() <- err
 			}
 		case response := <-syncRsp:
 			deadline.Stop()
+			znp.metrics.SyncRequestCompleted(frame.Subsystem, frame.Command, time.Since(sent))
 			req.SyncRsp() <- response
 		case err := <-syncErr:
 			deadline.Stop()
+			znp.metrics.SyncRequestFailed(frame.Subsystem, frame.Command, err)
+			znp.log(slog.LevelWarn, "request rejected", "subsystem", frame.Subsystem.String(), "command", int(frame.Command), "error", err)
 			req.SyncErr() <- err
 		}
 	}
@@ -146,8 +167,10 @@ func makeSyncRequestProcessor(znp *Znp, syncRsp chan *unp.Frame, syncErr chan er
 
 func makeAsyncRequestProcessor(znp *Znp) func(req *request.Async) {
 	return func(req *request.Async) {
-		logFrame(req.Frame(), znp.outFramesLog)
-		znp.u.WriteFrame(req.Frame())
+		znp.frameSent(req.Frame())
+		if err := znp.u.WriteFrame(req.Frame()); err != nil {
+			znp.log(slog.LevelError, "failed to write frame", "error", err)
+		}
 	}
 }
 
@@ -162,7 +185,11 @@ func makeSyncResponseProcessor(syncRsp chan *unp.Frame, syncErr chan error) func
 	return func(frame *unp.Frame) {
 		if frame.Subsystem == unp.S_RES0 && frame.Command == 0 {
 			errorCode := frame.Payload[0]
-			syncErr <- errors.New(errorMessages[errorCode])
+			message, ok := errorMessages[errorCode]
+			if !ok {
+				message = fmt.Sprintf("RPC error 0x%02x", errorCode)
+			}
+			syncErr <- errors.New(message)
 		} else {
 			syncRsp <- frame
 		}
@@ -175,25 +202,40 @@ func makeAsyncResponseProcessor(znp *Znp) func(frame *unp.Frame) {
 		if value, ok := asyncCommandRegistry[key]; ok {
 			cp := reflection.Copy(value)
 			bin.Decode(frame.Payload, cp)
+			znp.logMessage("async command received", cp)
+			znp.publish(frame, cp)
 			select {
 			case znp.asyncInbound <- cp:
 			default:
+				znp.metrics.AsyncDropped(frame.Subsystem, frame.Command)
 			}
 		} else {
+			err := fmt.Errorf("unknown async command received: %v", frame)
+			znp.metrics.UnknownAsync(frame.Subsystem, frame.Command)
+			znp.log(slog.LevelWarn, "unknown async command received", "error", err)
 			select {
-			case znp.errors <- fmt.Errorf("unknown async command received: %v", frame):
+			case znp.errors <- err:
 			default:
 			}
 		}
 	}
 }
 
-func logFrame(frame *unp.Frame, logger chan *unp.Frame) {
-	go func() {
-		select {
-		case logger <- frame:
-		default:
-		}
-	}()
+func (znp *Znp) frameSent(frame *unp.Frame) {
+	znp.metrics.FrameSent(frame)
+	znp.logFrame("frame sent", frame)
+	copyFrame(frame, znp.outFramesLog)
+}
 
+func (znp *Znp) frameReceived(frame *unp.Frame) {
+	znp.metrics.FrameReceived(frame)
+	znp.logFrame("frame received", frame)
+	copyFrame(frame, znp.inFramesLog)
+}
+
+func copyFrame(frame *unp.Frame, log chan *unp.Frame) {
+	select {
+	case log <- frame:
+	default:
+	}
 }
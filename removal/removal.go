@@ -0,0 +1,244 @@
+//Package removal removes devices from the network and from the tables of the adapter.
+//
+//	report, err := removal.RemoveDevice(ctx, z, "0x00124b0001020304", &removal.Options{Force: true})
+//	for _, step := range report.Steps {
+//		fmt.Println(step)
+//	}
+package removal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dyrkin/znp-go"
+)
+
+//ErrNoResponse is the error of the leave step when the device didn't answer
+var ErrNoResponse = errors.New("removal: no response to the leave request")
+
+//Options of RemoveDevice. The zero value is a valid configuration.
+type Options struct {
+	//NwkAddr is the network address of the device. Default is the address known by the adapter
+	NwkAddr        string
+	RemoveChildren bool
+	Rejoin         bool
+	Timeout        time.Duration //Time the device has to answer the leave request. Default is 10s
+	//Force removes the device from the tables of the adapter even when it didn't leave
+	Force bool
+}
+
+//Step is the outcome of a step of the removal
+type Step struct {
+	Name string
+	Err  error
+	//Skipped is set when the step wasn't needed, e.g. the trust center already forgot the device which left
+	Skipped bool
+}
+
+func (s Step) String() string {
+	if s.Err != nil {
+		return fmt.Sprintf("%s: %s", s.Name, s.Err)
+	}
+	if s.Skipped {
+		return s.Name + ": skipped"
+	}
+	return s.Name + ": ok"
+}
+
+//Report lists the steps of the removal
+type Report struct {
+	IEEEAddr string
+	NwkAddr  string
+	//Left is set when the device confirmed it left
+	Left  bool
+	Steps []Step
+}
+
+func (r *Report) step(name string, err error) error {
+	r.Steps = append(r.Steps, Step{Name: name, Err: err})
+	return err
+}
+
+//RemoveDevice asks the device to leave with ZdoMgmtLeaveReq and waits for ZdoMgmtLeaveRsp or ZdoLeaveInd. Then the
+//device is removed from the trust center with ZdoSecDeviceRemove, its link key with ZdoRemoveLinkKey and the
+//bindings of the adapter to it with ZdoUnbindReq. Only the binding table of the adapter is cleaned up, the bindings
+//other devices hold to the removed device are left alone. When the device doesn't leave, the removal stops unless
+//Force is set. The returned error is the one of the first failed step. The trust center forgets a device which
+//left on its own, so a failed trust center step is recorded as skipped then. The link key step fails when the
+//adapter already forgot the key.
+func RemoveDevice(ctx context.Context, z *znp.Znp, ieeeAddr string, options *Options) (*Report, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	ieeeAddr = strings.ToLower(ieeeAddr)
+	report := &Report{IEEEAddr: ieeeAddr, NwkAddr: strings.ToLower(options.NwkAddr)}
+	if report.NwkAddr == "" {
+		rsp, err := z.UtilAddrMgrExtAddrLookup(ieeeAddr)
+		if err == nil && (rsp.NwkAddr == "0xfffe" || rsp.NwkAddr == "0xffff") {
+			err = errors.New("removal: network address is unknown")
+		}
+		if report.step("lookup", err) != nil && !options.Force {
+			return report, err
+		}
+		if err == nil {
+			report.NwkAddr = rsp.NwkAddr
+		}
+	}
+
+	var failed error
+	if report.NwkAddr != "" {
+		failed = report.step("leave", leave(ctx, z, report, options))
+	}
+	if failed != nil && !options.Force {
+		return report, failed
+	}
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+
+	for _, step := range []struct {
+		name string
+		run  func() error
+	}{
+		{"trust center", func() error {
+			rsp, err := z.ZdoSecDeviceRemove(ieeeAddr)
+			return checkStatus(rsp, err)
+		}},
+		{"link key", func() error {
+			rsp, err := z.ZdoRemoveLinkKey(ieeeAddr)
+			return checkStatus(rsp, err)
+		}},
+		{"bindings", func() error {
+			return unbind(ctx, z, ieeeAddr, durationOrDefault(options.Timeout, 10*time.Second))
+		}},
+	} {
+		err := step.run()
+		if err != nil && step.name == "trust center" && report.Left {
+			report.Steps = append(report.Steps, Step{Name: step.name, Skipped: true})
+			continue
+		}
+		if report.step(step.name, err) != nil && failed == nil {
+			failed = err
+		}
+	}
+	return report, failed
+}
+
+func leave(ctx context.Context, z *znp.Znp, report *Report, options *Options) error {
+	subscription := z.Subscribe()
+	defer subscription.Unsubscribe()
+	flags := &znp.RemoveChildrenRejoin{}
+	if options.RemoveChildren {
+		flags.RemoveChildren = 1
+	}
+	if options.Rejoin {
+		flags.Rejoin = 1
+	}
+	rsp, err := z.ZdoMgmtLeaveReq(report.NwkAddr, report.IEEEAddr, flags)
+	if err := checkStatus(rsp, err); err != nil {
+		return err
+	}
+	deadline := time.NewTimer(durationOrDefault(options.Timeout, 10*time.Second))
+	defer deadline.Stop()
+	for {
+		select {
+		case async := <-subscription.Events():
+			switch async := async.(type) {
+			case *znp.ZdoMgmtLeaveRsp:
+				if async.SrcAddr == report.NwkAddr {
+					if async.Status != znp.StatusSuccess {
+						return fmt.Errorf("removal: leave refused: %s", async.Status)
+					}
+					report.Left = true
+					return nil
+				}
+			case *znp.ZdoLeaveInd:
+				if async.ExtAddr == report.IEEEAddr || async.SrcAddr == report.NwkAddr {
+					report.Left = true
+					return nil
+				}
+			}
+		case <-deadline.C:
+			return ErrNoResponse
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+//unbind removes the entries of the binding table of the adapter with the device as destination. The sources of the
+//entries are the endpoints of the adapter.
+func unbind(ctx context.Context, z *znp.Znp, ieeeAddr string, timeout time.Duration) error {
+	subscription := z.Subscribe()
+	defer subscription.Unsubscribe()
+	var bindings []*znp.Binding
+	for index, total := 0, 1; index < total; {
+		rsp, err := z.ZdoMgmtBindReq("0x0000", uint8(index))
+		if err := checkStatus(rsp, err); err != nil {
+			return err
+		}
+		page, err := bindRsp(ctx, subscription, timeout)
+		if err != nil {
+			return err
+		}
+		if len(page.BindTable) == 0 {
+			break
+		}
+		for _, binding := range page.BindTable {
+			dst := binding.DstAddr
+			if dst != nil && dst.AddrMode == znp.AddrModeAddr64Bit && dst.ExtendedAddr == ieeeAddr {
+				bindings = append(bindings, binding)
+			}
+		}
+		index, total = index+len(page.BindTable), int(page.BindTableEntries)
+	}
+	for _, binding := range bindings {
+		dst := binding.DstAddr
+		rsp, err := z.ZdoUnbindReq("0x0000", binding.SrcAddr, binding.SrcEndpoint, binding.ClusterID,
+			znp.AddrModeAddr64Bit, dst.ExtendedAddr, dst.DstEndpoint)
+		if err := checkStatus(rsp, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bindRsp(ctx context.Context, subscription *znp.Subscription, timeout time.Duration) (*znp.ZdoMgmtBindRsp, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		select {
+		case async := <-subscription.Events():
+			if rsp, ok := async.(*znp.ZdoMgmtBindRsp); ok && rsp.SrcAddr == "0x0000" {
+				if rsp.Status != znp.StatusSuccess {
+					return nil, fmt.Errorf("removal: reading the binding table failed: %s", rsp.Status)
+				}
+				return rsp, nil
+			}
+		case <-deadline.C:
+			return nil, errors.New("removal: no binding table received")
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func checkStatus(rsp *znp.StatusResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return fmt.Errorf("removal: %s", rsp.Status)
+	}
+	return nil
+}
+
+func durationOrDefault(d time.Duration, def time.Duration) time.Duration {
+	if d == 0 {
+		return def
+	}
+	return d
+}
@@ -0,0 +1,138 @@
+package removal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+const (
+	device      = "0x00124b0001020304"
+	coordinator = "0x00124b00aaaaaaaa"
+)
+
+//fakeAdapter answers the leave request when the device is online and records the cleanup requests
+type fakeAdapter struct {
+	*znptest.Adapter
+	online bool
+	//forgotten makes the trust center fail the removal, as it does for a device which left
+	forgotten bool
+	unbinds   []*znp.ZdoBindUnbindReq
+	removals  []string
+	keys      []string
+}
+
+func connect(a *fakeAdapter) *znp.Znp {
+	a.Adapter = znptest.New()
+	a.Respond(unp.S_UTIL, 0x40, []byte{0x2b, 0x1a})
+	a.Handle(unp.S_ZDO, 0x34, func(r *znptest.Request) []byte {
+		if a.online {
+			r.Reply(unp.S_ZDO, 0xB4, &znp.ZdoMgmtLeaveRsp{SrcAddr: "0x1a2b", Status: znp.StatusSuccess})
+		}
+		return nil
+	})
+	a.Handle(unp.S_ZDO, 0x33, func(r *znptest.Request) []byte {
+		r.Reply(unp.S_ZDO, 0xB3, &znp.ZdoMgmtBindRsp{SrcAddr: "0x0000", Status: znp.StatusSuccess,
+			BindTableEntries: 4, BindTable: []*znp.Binding{
+				{SrcAddr: coordinator, SrcEndpoint: 1, ClusterID: 0x0006, DstAddr: &znp.Addr{
+					AddrMode: znp.AddrModeAddr64Bit, ExtendedAddr: device, DstEndpoint: 1}},
+				{SrcAddr: coordinator, SrcEndpoint: 1, ClusterID: 0x0006, DstAddr: &znp.Addr{
+					AddrMode: znp.AddrModeAddrGroup, ShortAddr: "0x0005"}},
+				{SrcAddr: coordinator, SrcEndpoint: 1, ClusterID: 0x0006, DstAddr: &znp.Addr{
+					AddrMode: znp.AddrModeAddr64Bit, ExtendedAddr: "0x00124b00bbbbbbbb", DstEndpoint: 1}},
+				{SrcAddr: coordinator, SrcEndpoint: 2, ClusterID: 0x0008, DstAddr: &znp.Addr{
+					AddrMode: znp.AddrModeAddr64Bit, ExtendedAddr: device, DstEndpoint: 2}},
+			}})
+		return nil
+	})
+	a.Handle(unp.S_ZDO, 0x22, func(r *znptest.Request) []byte {
+		req := &znp.ZdoBindUnbindReq{}
+		r.Decode(req)
+		a.unbinds = append(a.unbinds, req)
+		return nil
+	})
+	a.Handle(unp.S_ZDO, 0x44, func(r *znptest.Request) []byte {
+		req := &znp.ZdoSecDeviceRemove{}
+		r.Decode(req)
+		a.removals = append(a.removals, req.ExtendedAddress)
+		if a.forgotten {
+			return []byte{uint8(znp.StatusFailure)}
+		}
+		return nil
+	})
+	a.Handle(unp.S_ZDO, 0x24, func(r *znptest.Request) []byte {
+		req := &znp.ZdoRemoveLinkKey{}
+		r.Decode(req)
+		a.keys = append(a.keys, req.IEEEAddr)
+		return nil
+	})
+	z := znp.New(a.Unp())
+	z.Start()
+	return z
+}
+
+func (s *MySuite) TestRemoveDevice(c *C) {
+	a := &fakeAdapter{online: true}
+	z := connect(a)
+	report, err := RemoveDevice(context.Background(), z, "0x00124B0001020304", nil)
+	c.Assert(err, IsNil)
+	c.Assert(report.NwkAddr, Equals, "0x1a2b")
+	c.Assert(report.Left, Equals, true)
+	c.Assert(report.Steps, HasLen, 5)
+	c.Assert(a.removals, DeepEquals, []string{device})
+	c.Assert(a.keys, DeepEquals, []string{device})
+	c.Assert(a.unbinds, HasLen, 2)
+	for _, unbind := range a.unbinds {
+		c.Assert(unbind.SrcAddress, Equals, coordinator)
+		c.Assert(unbind.DstAddress, Equals, device)
+	}
+	c.Assert(a.unbinds[0].ClusterID, Equals, uint16(0x0006))
+	c.Assert(a.unbinds[1].SrcEndpoint, Equals, uint8(2))
+	c.Assert(a.unbinds[1].ClusterID, Equals, uint16(0x0008))
+}
+
+func (s *MySuite) TestUnresponsiveDevice(c *C) {
+	a := &fakeAdapter{}
+	z := connect(a)
+	report, err := RemoveDevice(context.Background(), z, device, &Options{NwkAddr: "0x1a2b",
+		Timeout: 50 * time.Millisecond})
+	c.Assert(err, Equals, ErrNoResponse)
+	c.Assert(report.Steps, DeepEquals, []Step{{Name: "leave", Err: ErrNoResponse}})
+	c.Assert(a.removals, HasLen, 0)
+
+	report, err = RemoveDevice(context.Background(), z, device, &Options{NwkAddr: "0x1a2b",
+		Timeout: 50 * time.Millisecond, Force: true})
+	c.Assert(err, Equals, ErrNoResponse)
+	c.Assert(report.Left, Equals, false)
+	c.Assert(report.Steps, HasLen, 4)
+	c.Assert(a.removals, DeepEquals, []string{device})
+}
+
+func (s *MySuite) TestDeviceForgottenByTheTrustCenter(c *C) {
+	a := &fakeAdapter{online: true, forgotten: true}
+	z := connect(a)
+	report, err := RemoveDevice(context.Background(), z, device, nil)
+	c.Assert(err, IsNil)
+	c.Assert(report.Left, Equals, true)
+	c.Assert(report.Steps[2], DeepEquals, Step{Name: "trust center", Skipped: true})
+	c.Assert(report.Steps[2].String(), Equals, "trust center: skipped")
+	c.Assert(a.keys, DeepEquals, []string{device})
+	c.Assert(a.unbinds, HasLen, 2)
+
+	a.online = false
+	//the device didn't leave, the failure of the trust center is reported
+	report, err = RemoveDevice(context.Background(), z, device, &Options{Timeout: 50 * time.Millisecond, Force: true})
+	c.Assert(err, Equals, ErrNoResponse)
+	c.Assert(report.Steps[2].Err, ErrorMatches, "removal: .*")
+}
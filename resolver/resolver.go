@@ -0,0 +1,211 @@
+//Package resolver caches the mapping between the IEEE and the network addresses of the devices. Network addresses
+//change when devices rejoin, the cache follows the announcements and the address responses seen on the adapter.
+//
+//	r := resolver.New(z)
+//	nwkAddr, err := r.NwkAddr(ctx, "0x00124b0001020304")
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/af"
+)
+
+//ErrNotFound is returned when neither the adapter nor the network know the address
+var ErrNotFound = errors.New("resolver: address not found")
+
+//Entry maps an IEEE address to a network address
+type Entry struct {
+	IEEEAddr string
+	NwkAddr  string
+}
+
+//Resolver caches the addresses and resolves the unknown ones through the address manager of the adapter and then
+//through ZDO requests
+type Resolver struct {
+	z            *znp.Znp
+	subscription *znp.Subscription
+	timeout      time.Duration //time the network has to answer an address request
+
+	mu     sync.Mutex
+	byIEEE map[string]string
+	byNwk  map[string]string
+}
+
+//New returns a resolver watching the adapter. Call Stop when it is no longer needed.
+func New(z *znp.Znp) *Resolver {
+	r := &Resolver{
+		z:            z,
+		subscription: z.Subscribe(),
+		timeout:      10 * time.Second,
+		byIEEE:       map[string]string{},
+		byNwk:        map[string]string{},
+	}
+	go r.watch()
+	return r
+}
+
+//NwkAddr returns the network address of the device. A network address is returned as is, so destinations can be
+//given either way.
+func (r *Resolver) NwkAddr(ctx context.Context, addr string) (string, error) {
+	addr = strings.ToLower(addr)
+	switch len(addr) {
+	case 6:
+		return addr, nil
+	case 18:
+	default:
+		return "", fmt.Errorf("resolver: invalid address %q", addr)
+	}
+	if nwkAddr, ok := r.lookup(r.byIEEE, addr); ok {
+		return nwkAddr, nil
+	}
+	if rsp, err := r.z.UtilAddrMgrExtAddrLookup(addr); err == nil && rsp.NwkAddr != "0xfffe" &&
+		rsp.NwkAddr != "0xffff" {
+		r.Set(addr, rsp.NwkAddr)
+		return rsp.NwkAddr, nil
+	}
+	return r.request(ctx, func(async interface{}) (string, bool) {
+		if rsp, ok := async.(*znp.ZdoNwkAddrRsp); ok && rsp.Status == znp.StatusSuccess && rsp.IEEEAddr == addr {
+			r.Set(addr, rsp.NwkAddr)
+			return rsp.NwkAddr, true
+		}
+		return "", false
+	}, func() (*znp.StatusResponse, error) {
+		return r.z.ZdoNwkAddrReq(addr, znp.ReqTypeSingleDeviceResponse, 0)
+	})
+}
+
+//Resolve returns the destination with its network address, so that messages can be sent to IEEE addresses
+//without relying on the address manager of the adapter
+func (r *Resolver) Resolve(ctx context.Context, dst af.Destination) (af.Destination, error) {
+	nwkAddr, err := r.NwkAddr(ctx, dst.Addr)
+	if err != nil {
+		return dst, err
+	}
+	dst.Addr = nwkAddr
+	return dst, nil
+}
+
+//IEEEAddr returns the IEEE address of the device with the network address
+func (r *Resolver) IEEEAddr(ctx context.Context, nwkAddr string) (string, error) {
+	nwkAddr = strings.ToLower(nwkAddr)
+	if len(nwkAddr) != 6 {
+		return "", fmt.Errorf("resolver: invalid network address %q", nwkAddr)
+	}
+	if ieeeAddr, ok := r.lookup(r.byNwk, nwkAddr); ok {
+		return ieeeAddr, nil
+	}
+	if rsp, err := r.z.UtilAddrMgrAddrLookup(nwkAddr); err == nil && rsp.ExtAddr != "0x0000000000000000" &&
+		rsp.ExtAddr != "0xffffffffffffffff" {
+		r.Set(rsp.ExtAddr, nwkAddr)
+		return rsp.ExtAddr, nil
+	}
+	return r.request(ctx, func(async interface{}) (string, bool) {
+		if rsp, ok := async.(*znp.ZdoIEEEAddrRsp); ok && rsp.Status == znp.StatusSuccess && rsp.NwkAddr == nwkAddr {
+			r.Set(rsp.IEEEAddr, nwkAddr)
+			return rsp.IEEEAddr, true
+		}
+		return "", false
+	}, func() (*znp.StatusResponse, error) {
+		return r.z.ZdoIeeeAddrReq(nwkAddr, znp.ReqTypeSingleDeviceResponse, 0)
+	})
+}
+
+//Set adds or updates the mapping, the stale mappings of both addresses are removed
+func (r *Resolver) Set(ieeeAddr string, nwkAddr string) {
+	ieeeAddr, nwkAddr = strings.ToLower(ieeeAddr), strings.ToLower(nwkAddr)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.byNwk, r.byIEEE[ieeeAddr])
+	delete(r.byIEEE, r.byNwk[nwkAddr])
+	r.byIEEE[ieeeAddr], r.byNwk[nwkAddr] = nwkAddr, ieeeAddr
+}
+
+//Forget removes the device with the IEEE address
+func (r *Resolver) Forget(ieeeAddr string) {
+	ieeeAddr = strings.ToLower(ieeeAddr)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.byNwk, r.byIEEE[ieeeAddr])
+	delete(r.byIEEE, ieeeAddr)
+}
+
+//Entries returns the cached mappings ordered by IEEE address
+func (r *Resolver) Entries() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := make([]Entry, 0, len(r.byIEEE))
+	for ieeeAddr, nwkAddr := range r.byIEEE {
+		entries = append(entries, Entry{IEEEAddr: ieeeAddr, NwkAddr: nwkAddr})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].IEEEAddr < entries[j].IEEEAddr
+	})
+	return entries
+}
+
+//Stop stops watching the adapter
+func (r *Resolver) Stop() {
+	r.subscription.Unsubscribe()
+}
+
+func (r *Resolver) lookup(m map[string]string, addr string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	resolved, ok := m[addr]
+	return resolved, ok
+}
+
+//request sends the ZDO request and waits for the matching response
+func (r *Resolver) request(ctx context.Context, match func(async interface{}) (string, bool),
+	send func() (*znp.StatusResponse, error)) (string, error) {
+	subscription := r.z.Subscribe()
+	defer subscription.Unsubscribe()
+	rsp, err := send()
+	if err != nil {
+		return "", err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return "", fmt.Errorf("resolver: address request failed: %s", rsp.Status)
+	}
+	deadline := time.NewTimer(r.timeout)
+	defer deadline.Stop()
+	for {
+		select {
+		case async := <-subscription.Events():
+			if resolved, ok := match(async); ok {
+				return resolved, nil
+			}
+		case <-deadline.C:
+			return "", ErrNotFound
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+func (r *Resolver) watch() {
+	for async := range r.subscription.Events() {
+		switch async := async.(type) {
+		case *znp.ZdoEndDeviceAnnceInd:
+			r.Set(async.IEEEAddr, async.NwkAddr)
+		case *znp.ZdoTcDevInd:
+			r.Set(async.SrcIEEEAddr, async.SrcNwkAddr)
+		case *znp.ZdoNwkAddrRsp:
+			if async.Status == znp.StatusSuccess {
+				r.Set(async.IEEEAddr, async.NwkAddr)
+			}
+		case *znp.ZdoIEEEAddrRsp:
+			if async.Status == znp.StatusSuccess {
+				r.Set(async.IEEEAddr, async.NwkAddr)
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/af"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+//fakeAdapter has an empty address manager and answers the address requests of the devices in the network
+type fakeAdapter struct {
+	*znptest.Adapter
+	network  map[string]string //IEEE address by network address
+	requests int               //ZDO address requests
+}
+
+func newFakeAdapter(network map[string]string) *fakeAdapter {
+	a := &fakeAdapter{Adapter: znptest.New(), network: network}
+	a.Respond(unp.S_UTIL, 0x40, []byte{0xFE, 0xFF})
+	a.Respond(unp.S_UTIL, 0x41, make([]byte, 8))
+	a.Handle(unp.S_ZDO, 0x00, func(r *znptest.Request) []byte {
+		a.requests++
+		req := &znp.ZdoNwkAddrReq{}
+		r.Decode(req)
+		for nwkAddr, ieeeAddr := range a.network {
+			if ieeeAddr == req.IEEEAddress {
+				r.Reply(unp.S_ZDO, 0x80, &znp.ZdoNwkAddrRsp{IEEEAddr: ieeeAddr, NwkAddr: nwkAddr})
+			}
+		}
+		return nil
+	})
+	a.Handle(unp.S_ZDO, 0x01, func(r *znptest.Request) []byte {
+		a.requests++
+		req := &znp.ZdoIeeeAddrReq{}
+		r.Decode(req)
+		if ieeeAddr, ok := a.network[req.ShortAddr]; ok {
+			r.Reply(unp.S_ZDO, 0x81, &znp.ZdoIEEEAddrRsp{IEEEAddr: ieeeAddr, NwkAddr: req.ShortAddr})
+		}
+		return nil
+	})
+	return a
+}
+
+func (s *MySuite) TestResolve(c *C) {
+	const ieeeAddr = "0x00124b0001020304"
+	a := newFakeAdapter(map[string]string{"0x1a2b": ieeeAddr})
+	z := znp.New(a.Unp())
+	z.Start()
+	r := New(z)
+	defer r.Stop()
+	r.timeout = 20 * time.Millisecond
+	ctx := context.Background()
+
+	dst, err := r.Resolve(ctx, af.Destination{Addr: "0x00124B0001020304", Endpoint: 1})
+	c.Assert(err, IsNil)
+	c.Assert(dst, Equals, af.Destination{Addr: "0x1a2b", Endpoint: 1})
+	ieee, err := r.IEEEAddr(ctx, "0x1a2b")
+	c.Assert(err, IsNil)
+	c.Assert(ieee, Equals, ieeeAddr)
+	c.Assert(a.requests, Equals, 1)
+
+	//the device rejoined with another network address
+	delete(a.network, "0x1a2b")
+	announce := &znp.ZdoEndDeviceAnnceInd{SrcAddr: "0x3c4d", NwkAddr: "0x3c4d", IEEEAddr: ieeeAddr,
+		Capabilities: &znp.CapInfo{}}
+	a.Send(unp.S_ZDO, 0xC1, announce)
+	for deadline := time.Now().Add(time.Second); r.Entries()[0].NwkAddr != "0x3c4d" && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	c.Assert(r.Entries(), DeepEquals, []Entry{{IEEEAddr: ieeeAddr, NwkAddr: "0x3c4d"}})
+	_, err = r.IEEEAddr(ctx, "0x1a2b")
+	c.Assert(err, Equals, ErrNotFound)
+	c.Assert(a.requests, Equals, 2)
+}
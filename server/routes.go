@@ -0,0 +1,225 @@
+package server
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/dyrkin/znp-go"
+)
+
+type route struct {
+	method  string      //name of the Znp method to call
+	request interface{} //request model. Its fields are passed to the method in declaration order
+}
+
+//routes lists the commands exposed over http. The path of each command is derived from the method name,
+//see path. AfInterPanCtl can't be mapped onto a json body and is not exposed.
+var routes = []route{
+	//AF
+	{"AfRegister", &znp.AfRegister{}},
+	{"AfDataRequest", &znp.AfDataRequest{}},
+	{"AfDataRequestExt", &znp.AfDataRequestExt{}},
+	{"AfDataRequestSrcRtg", &znp.AfDataRequestSrcRtg{}},
+	{"AfDataStore", &znp.AfDataStore{}},
+	{"AfDataRetrieve", &znp.AfDataRetrieve{}},
+	{"AfApsfConfigSet", &znp.AfApsfConfigSet{}},
+
+	//APP
+	{"AppMsg", &znp.AppMsg{}},
+	{"AppUserTest", &znp.AppUserTest{}},
+
+	//DEBUG
+	{"DebugSetThreshold", &znp.DebugSetThreshold{}},
+	{"DebugMsg", &znp.DebugMsg{}},
+
+	//NWK
+	{"NwkInit", nil},
+	{"NwkNldeDataReq", &znp.NwkNldeDataReq{}},
+	{"NwkNlmeNetworkFormationReq", &znp.NwkNlmeNetworkFormationReq{}},
+	{"NwkNlmePermitJoiningReq", &znp.NwkNlmePermitJoiningReq{}},
+	{"NwkNlmeJoinReq", &znp.NwkNlmeJoinReq{}},
+	{"NwkNlmeLeaveReq", &znp.NwkNlmeLeaveReq{}},
+	{"NwkNlmeNetworkDiscoveryReq", &znp.NwkNlmeNetworkDiscoveryReq{}},
+	{"NwkNlmeRouteDiscoveryReq", &znp.NwkNlmeRouteDiscoveryReq{}},
+
+	//SAPI
+	{"SapiZbSystemReset", nil},
+	{"SapiZbStartRequest", nil},
+	{"SapiZbPermitJoiningRequest", &znp.SapiZbPermitJoiningRequest{}},
+	{"SapiZbBindDevice", &znp.SapiZbBindDevice{}},
+	{"SapiZbAllowBind", &znp.SapiZbAllowBind{}},
+	{"SapiZbSendDataRequest", &znp.SapiZbSendDataRequest{}},
+	{"SapiZbReadConfiguration", &znp.SapiZbReadConfiguration{}},
+	{"SapiZbWriteConfiguration", &znp.SapiZbWriteConfiguration{}},
+	{"SapiZbGetDeviceInfo", &znp.SapiZbGetDeviceInfo{}},
+	{"SapiZbFindDeviceRequest", &znp.SapiZbFindDeviceRequest{}},
+
+	//SYS
+	{"SysResetReq", &znp.SysResetReq{}},
+	{"SysPing", nil},
+	{"SysVersion", nil},
+	{"SysSetExtAddr", &znp.SysSetExtAddr{}},
+	{"SysGetExtAddr", nil},
+	{"SysRamRead", &znp.SysRamRead{}},
+	{"SysRamWrite", &znp.SysRamWrite{}},
+	{"SysOsalNvRead", &znp.SysOsalNvRead{}},
+	{"SysOsalNvWrite", &znp.SysOsalNvWrite{}},
+	{"SysOsalNvItemInit", &znp.SysOsalNvItemInit{}},
+	{"SysOsalNvDelete", &znp.SysOsalNvDelete{}},
+	{"SysOsalNvLength", &znp.SysOsalNvLength{}},
+	{"SysOsalStartTimer", &znp.SysOsalStartTimer{}},
+	{"SysOsalStopTimer", &znp.SysOsalStopTimer{}},
+	{"SysRandom", nil},
+	{"SysAdcRead", &znp.SysAdcRead{}},
+	{"SysGpio", &znp.SysGpio{}},
+	{"SysSetTime", &znp.SysTime{}},
+	{"SysGetTime", nil},
+	{"SysSetTxPower", &znp.SysSetTxPower{}},
+	{"SysZDiagsInitStats", nil},
+	{"SysZDiagsClearStats", &znp.SysZDiagsClearStats{}},
+	{"SysZDiagsGetStats", &znp.SysZDiagsGetStats{}},
+	{"SysZDiagsRestoreStatsNv", nil},
+	{"SysZDiagsSaveStatsToNv", nil},
+	{"SysNvCreate", &znp.SysNvCreate{}},
+	{"SysNvDelete", &znp.SysNvDelete{}},
+	{"SysNvLength", &znp.SysNvLength{}},
+	{"SysNvRead", &znp.SysNvRead{}},
+	{"SysNvWrite", &znp.SysNvWrite{}},
+	{"SysNvUpdate", &znp.SysNvUpdate{}},
+	{"SysNvCompact", &znp.SysNvCompact{}},
+	{"SysNvReadExt", &znp.SysNvReadExt{}},
+	{"SysNvWriteExt", &znp.SysNvWriteExt{}},
+
+	//UTIL
+	{"UtilGetDeviceInfo", nil},
+	{"UtilGetNvInfo", nil},
+	{"UtilSetPanId", &znp.UtilSetPanId{}},
+	{"UtilSetChannels", &znp.UtilSetChannels{}},
+	{"UtilSetSecLevel", &znp.UtilSetSecLevel{}},
+	{"UtilSetPreCfgKey", &znp.UtilSetPreCfgKey{}},
+	{"UtilCallbackSubCmd", &znp.UtilCallbackSubCmd{}},
+	{"UtilKeyEvent", &znp.UtilKeyEvent{}},
+	{"UtilTimeAlive", nil},
+	{"UtilLedControl", &znp.UtilLedControl{}},
+	{"UtilLoopback", &znp.UtilLoopback{}},
+	{"UtilDataReq", &znp.UtilDataReq{}},
+	{"UtilSrcMatchEnable", nil},
+	{"UtilSrcMatchAddEntry", &znp.UtilSrcMatchAddEntry{}},
+	{"UtilSrcMatchDelEntry", &znp.UtilSrcMatchDelEntry{}},
+	{"UtilSrcMatchCheckSrcAddr", &znp.UtilSrcMatchCheckSrcAddr{}},
+	{"UtilSrcMatchAckAllPending", &znp.UtilSrcMatchAckAllPending{}},
+	{"UtilSrcMatchCheckAllPending", nil},
+	{"UtilAddrMgrExtAddrLookup", &znp.UtilAddrMgrExtAddrLookup{}},
+	{"UtilAddrMgrAddrLookup", &znp.UtilAddrMgrAddrLookup{}},
+	{"UtilApsmeLinkKeyDataGet", &znp.UtilApsmeLinkKeyDataGet{}},
+	{"UtilApsmeLinkKeyNvIdGet", &znp.UtilApsmeLinkKeyNvIdGet{}},
+	{"UtilApsmeRequestKeyCmd", &znp.UtilApsmeRequestKeyCmd{}},
+	{"UtilAssocCount", &znp.UtilAssocCount{}},
+	{"UtilAssocFindDevice", &znp.UtilAssocFindDevice{}},
+	{"UtilAssocGetWithAddr", &znp.UtilAssocGetWithAddr{}},
+	{"UtilBindAddEntry", &znp.UtilBindAddEntry{}},
+	{"UtilZclKeyEstInitEst", &znp.UtilZclKeyEstInitEst{}},
+	{"UtilZclKeyEstSign", &znp.UtilZclKeyEstSign{}},
+	{"UtilSrngGen", nil},
+	{"UtilSyncReq", nil},
+
+	//ZDO
+	{"ZdoNwkAddrReq", &znp.ZdoNwkAddrReq{}},
+	{"ZdoIeeeAddrReq", &znp.ZdoIeeeAddrReq{}},
+	{"ZdoNodeDescReq", &znp.ZdoNodeDescReq{}},
+	{"ZdoPowerDescReq", &znp.ZdoPowerDescReq{}},
+	{"ZdoSimpleDescReq", &znp.ZdoSimpleDescReq{}},
+	{"ZdoActiveEpReq", &znp.ZdoActiveEpReq{}},
+	{"ZdoMatchDescReq", &znp.ZdoMatchDescReq{}},
+	{"ZdoComplexDescReq", &znp.ZdoComplexDescReq{}},
+	{"ZdoUserDescReq", &znp.ZdoUserDescReq{}},
+	{"ZdoEndDeviceAnnce", &znp.ZdoEndDeviceAnnce{}},
+	{"ZdoUserDescSet", &znp.ZdoUserDescSet{}},
+	{"ZdoServerDiscReq", &znp.ZdoServerDiscReq{}},
+	{"ZdoEndDeviceBindReq", &znp.ZdoEndDeviceBindReq{}},
+	{"ZdoBindReq", &znp.ZdoBindUnbindReq{}},
+	{"ZdoUnbindReq", &znp.ZdoBindUnbindReq{}},
+	{"ZdoMgmtNwkDiskReq", &znp.ZdoMgmtNwkDiskReq{}},
+	{"ZdoMgmtLqiReq", &znp.ZdoMgmtLqiReq{}},
+	{"ZdoMgmtRtgReq", &znp.ZdoMgmtRtgReq{}},
+	{"ZdoMgmtBindReq", &znp.ZdoMgmtBindReq{}},
+	{"ZdoMgmtLeaveReq", &znp.ZdoMgmtLeaveReq{}},
+	{"ZdoMgmtDirectJoinReq", &znp.ZdoMgmtDirectJoinReq{}},
+	{"ZdoMgmtPermitJoinReq", &znp.ZdoMgmtPermitJoinReq{}},
+	{"ZdoMgmtNwkUpdateReq", &znp.ZdoMgmtNwkUpdateReq{}},
+	{"ZdoMsgCbRegister", &znp.ZdoMsgCbRegister{}},
+	{"ZdoMsgCbRemove", &znp.ZdoMsgCbRemove{}},
+	{"ZdoStartupFromApp", &znp.ZdoStartupFromApp{}},
+	{"ZdoSetLinkKey", &znp.ZdoSetLinkKey{}},
+	{"ZdoRemoveLinkKey", &znp.ZdoRemoveLinkKey{}},
+	{"ZdoGetLinkKey", &znp.ZdoGetLinkKey{}},
+	{"ZdoNwkDiscoveryReq", &znp.ZdoNwkDiscoveryReq{}},
+	{"ZdoJoinReq", &znp.ZdoJoinReq{}},
+	{"ZdoSetRejoinParameters", &znp.ZdoSetRejoinParameters{}},
+	{"ZdoSecAddLinkKey", &znp.ZdoSecAddLinkKey{}},
+	{"ZdoSecEntryLookupExt", &znp.ZdoSecEntryLookupExt{}},
+	{"ZdoSecDeviceRemove", &znp.ZdoSecDeviceRemove{}},
+	{"ZdoExtRouteDisc", &znp.ZdoExtRouteDisc{}},
+	{"ZdoExtRouteCheck", &znp.ZdoExtRouteCheck{}},
+	{"ZdoExtRemoveGroup", &znp.ZdoExtRemoveGroup{}},
+	{"ZdoExtRemoveAllGroup", &znp.ZdoExtRemoveAllGroup{}},
+	{"ZdoExtFindAllGroupsEndpoint", &znp.ZdoExtFindAllGroupsEndpoint{}},
+	{"ZdoExtFindGroup", &znp.ZdoExtFindGroup{}},
+	{"ZdoExtAddGroup", &znp.ZdoExtAddGroup{}},
+	{"ZdoExtCountAllGroups", nil},
+	{"ZdoExtRxIdle", &znp.ZdoExtRxIdle{}},
+	{"ZdoExtUpdateNwkKey", &znp.ZdoExtUpdateNwkKey{}},
+	{"ZdoExtSwitchNwkKey", &znp.ZdoExtSwitchNwkKey{}},
+	{"ZdoExtNwkInfo", nil},
+	{"ZdoExtSeqApsRemoveReq", &znp.ZdoExtSeqApsRemoveReq{}},
+	{"ZdoForceConcentratorChange", nil},
+	{"ZdoExtSetParams", &znp.ZdoExtSetParams{}},
+	{"ZdoNwkAddrOfInterestReq", &znp.ZdoNwkAddrOfInterestReq{}},
+
+	//APP_CNF
+	{"AppCnfSetNwkFrameCounter", &znp.AppCnfSetNwkFrameCounter{}},
+	{"AppCnfSetDefaultEndDeviceTimeout", &znp.AppCnfSetDefaultEndDeviceTimeout{}},
+	{"AppCnfSetEndDeviceTimeout", &znp.AppCnfSetEndDeviceTimeout{}},
+	{"AppCnfSetAllowRejoinTcPolicy", &znp.AppCnfSetAllowRejoinTcPolicy{}},
+	{"AppCnfBdbStartCommissioning", &znp.AppCnfBdbStartCommissioning{}},
+	{"AppCnfBdbSetChannel", &znp.AppCnfBdbSetChannel{}},
+	{"AppCnfBdbAddInstallCode", &znp.AppCnfBdbAddInstallCode{}},
+	{"AppCnfBdbSetTcRequireKeyExchange", &znp.AppCnfBdbSetTcRequireKeyExchange{}},
+	{"AppCnfBdbSetJoinUsesInstallCodeKey", &znp.AppCnfBdbSetJoinUsesInstallCodeKey{}},
+	{"AppCnfBdbSetActiveDefaultCentralizedKey", &znp.AppCnfBdbSetActiveDefaultCentralizedKey{}},
+	{"AppCnfBdbZedAttemptRecoverNwk", nil},
+
+	//GP
+	{"GpDataReq", &znp.GpDataReq{}},
+	{"GpSecRsp", &znp.GpSecRsp{}},
+}
+
+var subsystems = []string{"AppCnf", "Af", "App", "Debug", "Nwk", "Sapi", "Sys", "Util", "Zdo", "Gp"}
+
+//path converts a method name to the http path, e.g. ZdoBindReq becomes /zdo/bind and
+//SysOsalNvRead becomes /sys/osal-nv-read
+func path(method string) string {
+	for _, subsystem := range subsystems {
+		if strings.HasPrefix(method, subsystem) {
+			command := strings.TrimPrefix(method, subsystem)
+			command = strings.TrimSuffix(command, "Request")
+			command = strings.TrimSuffix(command, "Req")
+			return "/" + kebab(subsystem) + "/" + kebab(command)
+		}
+	}
+	return "/" + kebab(method)
+}
+
+func kebab(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteRune('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
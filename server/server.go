@@ -0,0 +1,139 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/dyrkin/znp-go"
+	"github.com/gorilla/websocket"
+)
+
+//Server exposes the Znp command set over http.
+//
+//Every command is available as POST /<subsystem>/<command>, e.g. POST /sys/ping or POST /zdo/bind.
+//The json body is decoded into the command's request model and the response model is sent back as json.
+//Commands which don't wait for a response reply with 204 No Content, commands the adapter doesn't implement with
+//501 Not Implemented.
+//
+//GET /events upgrades the connection to a WebSocket and streams every async command received from the device.
+type Server struct {
+	znp      *znp.Znp
+	mux      *http.ServeMux
+	upgrader websocket.Upgrader
+}
+
+//Event is the json message sent to WebSocket clients for every async command.
+type Event struct {
+	Type    string      `json:"type"`
+	Message interface{} `json:"message"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func New(z *znp.Znp) *Server {
+	s := &Server{znp: z, mux: http.NewServeMux()}
+	for _, r := range routes {
+		s.mux.Handle(path(r.method), s.commandHandler(r))
+	}
+	s.mux.HandleFunc("/events", s.eventsHandler)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) commandHandler(route route) http.HandlerFunc {
+	method := reflect.ValueOf(s.znp).MethodByName(route.method)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+			return
+		}
+		var args []reflect.Value
+		if route.request != nil {
+			req := reflect.New(reflect.TypeOf(route.request).Elem())
+			if r.ContentLength != 0 {
+				if err := json.NewDecoder(r.Body).Decode(req.Interface()); err != nil {
+					writeError(w, http.StatusBadRequest, err)
+					return
+				}
+			}
+			args = fields(req.Elem())
+		}
+		res := method.Call(args)
+		if err, _ := res[len(res)-1].Interface().(error); err != nil {
+			status := http.StatusBadGateway
+			if errors.Is(err, znp.ErrUnsupported) {
+				status = http.StatusNotImplemented
+			}
+			writeError(w, status, err)
+			return
+		}
+		if len(res) == 1 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusOK, res[0].Interface())
+	}
+}
+
+func (s *Server) eventsHandler(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	subscription := s.znp.Subscribe()
+	defer subscription.Unsubscribe()
+
+	closed := make(chan struct{})
+	go func() {
+		//drain control frames until the client goes away
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case async, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+			event := &Event{Type: reflect.Indirect(reflect.ValueOf(async)).Type().Name(), Message: async}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+func fields(v reflect.Value) []reflect.Value {
+	args := make([]reflect.Value, v.NumField())
+	for i := range args {
+		args[i] = v.Field(i)
+	}
+	return args
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, &errorResponse{Error: err.Error()})
+}
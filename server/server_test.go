@@ -0,0 +1,135 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	"github.com/gorilla/websocket"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) TestPath(c *C) {
+	c.Assert(path("SysPing"), Equals, "/sys/ping")
+	c.Assert(path("ZdoBindReq"), Equals, "/zdo/bind")
+	c.Assert(path("SysOsalNvRead"), Equals, "/sys/osal-nv-read")
+	c.Assert(path("AppCnfBdbStartCommissioning"), Equals, "/app-cnf/bdb-start-commissioning")
+	c.Assert(path("SapiZbPermitJoiningRequest"), Equals, "/sapi/zb-permit-joining")
+}
+
+func (s *MySuite) TestRoutesMatchMethods(c *C) {
+	z := reflect.TypeOf(&znp.Znp{})
+	paths := map[string]bool{}
+	for _, r := range routes {
+		p := path(r.method)
+		c.Assert(paths[p], Equals, false, Commentf("duplicate path %s", p))
+		paths[p] = true
+
+		method, ok := z.MethodByName(r.method)
+		c.Assert(ok, Equals, true, Commentf("unknown method %s", r.method))
+		var fields []reflect.Type
+		if r.request != nil {
+			req := reflect.TypeOf(r.request).Elem()
+			for i := 0; i < req.NumField(); i++ {
+				fields = append(fields, req.Field(i).Type)
+			}
+		}
+		c.Assert(method.Type.NumIn()-1, Equals, len(fields), Commentf("%s arguments", r.method))
+		for i, field := range fields {
+			c.Assert(field.AssignableTo(method.Type.In(i+1)), Equals, true, Commentf("%s argument %d", r.method, i))
+		}
+	}
+}
+
+//serve returns a test server in front of a Znp connected to the adapter
+func serve(a *znptest.Adapter) *httptest.Server {
+	z := znp.New(a.Unp())
+	z.Start()
+	return httptest.NewServer(New(z))
+}
+
+func (s *MySuite) TestCommand(c *C) {
+	a := znptest.New()
+	requests := make(chan *znp.ZdoMgmtPermitJoinReq, 1)
+	a.Handle(unp.S_ZDO, 0x36, func(r *znptest.Request) []byte {
+		req := &znp.ZdoMgmtPermitJoinReq{}
+		r.Decode(req)
+		requests <- req
+		return []byte{0x00}
+	})
+	server := serve(a)
+	defer server.Close()
+
+	rsp, err := http.Post(server.URL+"/zdo/mgmt-permit-join", "application/json",
+		strings.NewReader(`{"AddrMode":2,"DstAddr":"0x0000","Duration":60,"TCSignificance":0}`))
+	c.Assert(err, IsNil)
+	defer rsp.Body.Close()
+	c.Assert(rsp.StatusCode, Equals, http.StatusOK)
+	status := &znp.StatusResponse{}
+	c.Assert(json.NewDecoder(rsp.Body).Decode(status), IsNil)
+	c.Assert(status.Status, Equals, znp.StatusSuccess)
+	c.Assert(<-requests, DeepEquals, &znp.ZdoMgmtPermitJoinReq{AddrMode: znp.AddrModeAddr16Bit,
+		DstAddr: "0x0000", Duration: 60})
+
+	rsp, err = http.Get(server.URL + "/zdo/mgmt-permit-join")
+	c.Assert(err, IsNil)
+	rsp.Body.Close()
+	c.Assert(rsp.StatusCode, Equals, http.StatusMethodNotAllowed)
+}
+
+func (s *MySuite) TestUnsupportedCommand(c *C) {
+	a := znptest.New()
+	a.Respond(unp.S_SYS, 0x02, []byte{0x02, 0x00, 0x02, 0x06, 0x03})
+	server := serve(a)
+	defer server.Close()
+
+	rsp, err := http.Post(server.URL+"/app-cnf/bdb-start-commissioning", "application/json",
+		strings.NewReader(`{"CommissioningMode":4}`))
+	c.Assert(err, IsNil)
+	rsp.Body.Close()
+	c.Assert(rsp.StatusCode, Equals, http.StatusNotImplemented)
+}
+
+func (s *MySuite) TestEvents(c *C) {
+	a := znptest.New()
+	server := serve(a)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/events", nil)
+	c.Assert(err, IsNil)
+	defer conn.Close()
+
+	//the subscription is made after the upgrade, send the event until the client receives it
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			a.Send(unp.S_ZDO, 0xC0, []byte{0x09})
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	event := map[string]interface{}{}
+	c.Assert(conn.ReadJSON(&event), IsNil)
+	c.Assert(event["type"], Equals, "ZdoStateChangeInd")
+	c.Assert(event["message"], DeepEquals, map[string]interface{}{"State": float64(9)})
+}
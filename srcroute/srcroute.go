@@ -0,0 +1,131 @@
+//Package srcroute keeps the source routes reported to the adapter acting as a concentrator and sends messages
+//along them. In large networks this spares routers the route discoveries towards the devices.
+//
+//	sender := af.NewSender(z)
+//	r := srcroute.New(z, sender)
+//	r.Refresh()
+//	err := r.Send(ctx, af.Destination{Addr: "0x1a2b", Endpoint: 1}, 0x0006, zcl, nil)
+package srcroute
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/af"
+)
+
+//Route to a device, the relays are in the order reported by ZdoSrcRtgInd
+type Route struct {
+	DstAddr string
+	Relays  []string
+	Updated time.Time
+}
+
+//Router caches the relay lists of ZdoSrcRtgInd
+type Router struct {
+	z            *znp.Znp
+	sender       *af.Sender
+	subscription *znp.Subscription
+
+	mu     sync.Mutex
+	routes map[string]*Route
+}
+
+//New returns a router watching the route records of the adapter. Call Stop when it is no longer needed.
+func New(z *znp.Znp, sender *af.Sender) *Router {
+	r := &Router{
+		z:            z,
+		sender:       sender,
+		subscription: z.Subscribe(),
+		routes:       map[string]*Route{},
+	}
+	go r.watch()
+	return r
+}
+
+//Refresh has the adapter broadcast a many-to-one route request with ZdoForceConcentratorChange. The devices answer
+//with route records which update the table.
+func (r *Router) Refresh() error {
+	return r.z.ZdoForceConcentratorChange()
+}
+
+//Routes returns the route table ordered by destination
+func (r *Router) Routes() []Route {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	routes := make([]Route, 0, len(r.routes))
+	for _, route := range r.routes {
+		routes = append(routes, *route)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].DstAddr < routes[j].DstAddr
+	})
+	return routes
+}
+
+//Route returns the route to the device with the network address
+func (r *Router) Route(addr string) (Route, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	route, ok := r.routes[strings.ToLower(addr)]
+	if !ok {
+		return Route{}, false
+	}
+	return *route, true
+}
+
+//Forget removes the route to the device, for example after it changed its network address
+func (r *Router) Forget(addr string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.routes, strings.ToLower(addr))
+}
+
+//Stop stops watching the route records
+func (r *Router) Stop() {
+	r.subscription.Unsubscribe()
+}
+
+//Send sends the message along the known route to the device, or with the routing of the adapter when there is
+//none or the data is too long for it. When the route is broken, i.e. the delivery fails with af.ErrNoAck or
+//af.ErrNoRoute, the route is forgotten, a route discovery is started with ZdoExtRouteDisc and the message is sent
+//again with the routing of the adapter. Other failures, e.g. af.ErrBusy, are returned and the route is kept.
+func (r *Router) Send(ctx context.Context, dst af.Destination, cluster uint16, data []byte,
+	options *af.SendOptions) error {
+	route, ok := r.Route(dst.Addr)
+	if !ok {
+		return r.sender.SendAF(ctx, dst, cluster, data, options)
+	}
+	err := r.sender.SendSourceRouted(ctx, dst, route.Relays, cluster, data, options)
+	if errors.Is(err, af.ErrTooLong) {
+		return r.sender.SendAF(ctx, dst, cluster, data, options)
+	}
+	if !errors.Is(err, af.ErrNoAck) && !errors.Is(err, af.ErrNoRoute) {
+		return err
+	}
+	r.Forget(dst.Addr)
+	rsp, err := r.z.ZdoExtRouteDisc(dst.Addr, 0, af.DefaultRadius)
+	if err != nil {
+		return err
+	}
+	if rsp.Status != znp.StatusSuccess {
+		return &af.StatusError{Command: "ZdoExtRouteDisc", Status: rsp.Status}
+	}
+	return r.sender.SendAF(ctx, dst, cluster, data, options)
+}
+
+func (r *Router) watch() {
+	for async := range r.subscription.Events() {
+		if ind, ok := async.(*znp.ZdoSrcRtgInd); ok {
+			r.mu.Lock()
+			r.routes[strings.ToLower(ind.DstAddr)] = &Route{DstAddr: ind.DstAddr, Relays: ind.RelayList,
+				Updated: time.Now()}
+			r.mu.Unlock()
+		}
+	}
+}
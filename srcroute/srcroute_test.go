@@ -0,0 +1,97 @@
+package srcroute
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dyrkin/unp-go"
+	"github.com/dyrkin/znp-go"
+	"github.com/dyrkin/znp-go/af"
+	"github.com/dyrkin/znp-go/internal/znptest"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+//fakeAdapter reports a route record on a concentrator change and fails the source routed messages with the status
+type fakeAdapter struct {
+	*znptest.Adapter
+	status     znp.Status
+	requests   []uint8 //AF commands
+	relays     []string
+	discovered []string
+}
+
+func newFakeAdapter() *fakeAdapter {
+	a := &fakeAdapter{Adapter: znptest.New()}
+	a.Handle(unp.S_ZDO, 0x52, func(r *znptest.Request) []byte {
+		r.Reply(unp.S_ZDO, 0xC4, &znp.ZdoSrcRtgInd{DstAddr: "0x1a2b", RelayList: []string{"0x1111", "0x2222"}})
+		return nil
+	})
+	a.Handle(unp.S_ZDO, 0x45, func(r *znptest.Request) []byte {
+		req := &znp.ZdoExtRouteDisc{}
+		r.Decode(req)
+		a.discovered = append(a.discovered, req.DestinationAddress)
+		return nil
+	})
+	a.Handle(unp.S_AF, 0x02, func(r *znptest.Request) []byte {
+		req := &znp.AfDataRequestExt{}
+		r.Decode(req)
+		a.requests = append(a.requests, r.Command)
+		r.Reply(unp.S_AF, 0x80, &znp.AfDataConfirm{Status: znp.StatusSuccess, Endpoint: req.SrcEndpoint,
+			TransID: req.TransID})
+		return nil
+	})
+	a.Handle(unp.S_AF, 0x03, func(r *znptest.Request) []byte {
+		req := &znp.AfDataRequestSrcRtg{}
+		r.Decode(req)
+		a.requests, a.relays = append(a.requests, r.Command), req.RelayList
+		r.Reply(unp.S_AF, 0x80, &znp.AfDataConfirm{Status: a.status, Endpoint: req.SrcEndpoint, TransID: req.TransID})
+		return nil
+	})
+	return a
+}
+
+func (s *MySuite) TestSend(c *C) {
+	a := newFakeAdapter()
+	z := znp.New(a.Unp())
+	z.Start()
+	r := New(z, af.NewSender(z))
+	defer r.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	dst := af.Destination{Addr: "0x1a2b", Endpoint: 1}
+
+	c.Assert(r.Refresh(), IsNil)
+	for deadline := time.Now().Add(time.Second); len(r.Routes()) == 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	route, ok := r.Route("0x1A2B")
+	c.Assert(ok, Equals, true)
+	c.Assert(route.Relays, DeepEquals, []string{"0x1111", "0x2222"})
+
+	c.Assert(r.Send(ctx, dst, 0x0006, []byte{0x01}, nil), IsNil)
+	c.Assert(a.relays, DeepEquals, route.Relays)
+
+	//the adapter is busy, the route is kept
+	a.status = znp.StatusBufferFull
+	err := r.Send(ctx, dst, 0x0006, []byte{0x01}, nil)
+	c.Assert(errors.Is(err, af.ErrBusy), Equals, true)
+	c.Assert(a.requests, DeepEquals, []uint8{0x03, 0x03})
+	c.Assert(a.discovered, HasLen, 0)
+	_, ok = r.Route(dst.Addr)
+	c.Assert(ok, Equals, true)
+
+	//the route is broken, the message is sent again after a route discovery
+	a.status = znp.StatusMacNoACK
+	c.Assert(r.Send(ctx, dst, 0x0006, []byte{0x01}, nil), IsNil)
+	c.Assert(a.requests, DeepEquals, []uint8{0x03, 0x03, 0x03, 0x02})
+	c.Assert(a.discovered, DeepEquals, []string{"0x1a2b"})
+	c.Assert(r.Routes(), HasLen, 0)
+}
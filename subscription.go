@@ -0,0 +1,52 @@
+package znp
+
+import (
+	"sync"
+
+	"github.com/dyrkin/unp-go"
+)
+
+//Subscription receives a copy of every decoded async command. Unlike AsyncInbound, which has a single
+//consumer, any number of subscriptions can be active at the same time.
+type Subscription struct {
+	znp    *Znp
+	events chan interface{}
+	once   sync.Once
+}
+
+//Subscribe registers a new subscription. Call Unsubscribe when it is no longer needed, otherwise
+//the subscription keeps buffering async commands until its buffer is full.
+func (znp *Znp) Subscribe() *Subscription {
+	s := &Subscription{znp: znp, events: make(chan interface{}, 100)}
+	znp.subscribersLock.Lock()
+	znp.subscribers[s] = struct{}{}
+	znp.subscribersLock.Unlock()
+	return s
+}
+
+//Events returns the channel async commands are delivered to. The channel is closed after Unsubscribe.
+func (s *Subscription) Events() chan interface{} {
+	return s.events
+}
+
+//Unsubscribe stops the delivery of async commands and closes the events channel.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.znp.subscribersLock.Lock()
+		delete(s.znp.subscribers, s)
+		close(s.events)
+		s.znp.subscribersLock.Unlock()
+	})
+}
+
+func (znp *Znp) publish(frame *unp.Frame, async interface{}) {
+	znp.subscribersLock.RLock()
+	defer znp.subscribersLock.RUnlock()
+	for s := range znp.subscribers {
+		select {
+		case s.events <- async:
+		default:
+			znp.metrics.AsyncDropped(frame.Subsystem, frame.Command)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package tcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+//ErrDisconnected is returned by Write while the connection to the adapter is being re-established.
+var ErrDisconnected = errors.New("tcp: adapter is disconnected")
+
+//ErrClosed is returned by Read and Write after Close has been called.
+var ErrClosed = errors.New("tcp: connection is closed")
+
+//Dialer contains options for connecting to an adapter behind a network bridge (ser2net, ESP-Link and so on).
+//The zero value is a valid configuration.
+type Dialer struct {
+	Timeout    time.Duration //Timeout of a single connection attempt. Default is 5s
+	Discovery  time.Duration //Time mDNS answers are collected for, before the connection attempt. Default is 1s
+	KeepAlive  time.Duration //TCP keep-alive period. Default is 15s
+	MinBackoff time.Duration //Delay before the first reconnection attempt. Default is 500ms
+	MaxBackoff time.Duration //Upper limit of the delay between reconnection attempts. Default is 30s
+}
+
+//Conn is a connection to a network attached adapter. It can be passed to unp.New in place of a serial port.
+//
+//When the connection breaks, the Read hitting the break returns the error of the connection, e.g. io.EOF, so that
+//the reader learns about it. Subsequent Reads block until the connection is re-established, while Writes fail
+//with ErrDisconnected, so that a request in flight fails instead of hanging.
+type Conn struct {
+	dialer  *Dialer
+	resolve func(ctx context.Context) (string, error)
+
+	mu     sync.Mutex
+	conn   net.Conn
+	closed bool
+	done   chan struct{}
+}
+
+//Dial connects to the adapter with the default options. See Dialer.Dial
+func Dial(address string) (*Conn, error) {
+	return (&Dialer{}).Dial(address)
+}
+
+//Dial connects to the adapter. The address is either tcp://host:port or mdns://<service> (e.g. mdns://_zigstar_gw._tcp),
+//in which case the first adapter announcing the service on the local network is used. The service is re-resolved
+//on every reconnection attempt, so an adapter which received a new IP address is found again.
+func (d *Dialer) Dial(address string) (*Conn, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+	c := &Conn{dialer: d, done: make(chan struct{})}
+	switch u.Scheme {
+	case "tcp":
+		c.resolve = func(ctx context.Context) (string, error) {
+			return u.Host, nil
+		}
+	case "mdns":
+		c.resolve = func(ctx context.Context) (string, error) {
+			adapters, err := Discover(ctx, u.Host)
+			if err != nil {
+				return "", err
+			}
+			if len(adapters) == 0 {
+				return "", fmt.Errorf("tcp: no adapters found for service %s", u.Host)
+			}
+			return adapters[0].Address(), nil
+		}
+	default:
+		return nil, fmt.Errorf("tcp: unsupported address scheme: %s", u.Scheme)
+	}
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn
+	return c, nil
+}
+
+//Read reads from the adapter, reconnecting if needed. The error of a broken connection is returned once, the next
+//Read reconnects.
+func (c *Conn) Read(b []byte) (int, error) {
+	conn, err := c.connected()
+	if err != nil {
+		return 0, err
+	}
+	n, err := conn.Read(b)
+	if err != nil {
+		c.drop(conn)
+		if n > 0 {
+			err = nil
+		}
+	}
+	return n, err
+}
+
+//Write writes to the adapter. It fails with ErrDisconnected while the connection is down.
+func (c *Conn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	conn, closed := c.conn, c.closed
+	c.mu.Unlock()
+	if closed {
+		return 0, ErrClosed
+	}
+	if conn == nil {
+		return 0, ErrDisconnected
+	}
+	n, err := conn.Write(b)
+	if err != nil {
+		c.drop(conn)
+	}
+	return n, err
+}
+
+//Close closes the connection and stops reconnecting.
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	close(c.done)
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+//connected returns the current connection or blocks until a new one is established.
+func (c *Conn) connected() (net.Conn, error) {
+	backoff := c.dialer.minBackoff()
+	for {
+		c.mu.Lock()
+		conn, closed := c.conn, c.closed
+		c.mu.Unlock()
+		if closed {
+			return nil, ErrClosed
+		}
+		if conn != nil {
+			return conn, nil
+		}
+		conn, err := c.dial()
+		if err == nil {
+			c.mu.Lock()
+			if c.closed {
+				c.mu.Unlock()
+				conn.Close()
+				return nil, ErrClosed
+			}
+			c.conn = conn
+			c.mu.Unlock()
+			return conn, nil
+		}
+		select {
+		case <-time.After(backoff):
+		case <-c.done:
+			return nil, ErrClosed
+		}
+		if backoff *= 2; backoff > c.dialer.maxBackoff() {
+			backoff = c.dialer.maxBackoff()
+		}
+	}
+}
+
+func (c *Conn) drop(conn net.Conn) {
+	c.mu.Lock()
+	if c.conn == conn {
+		c.conn = nil
+	}
+	c.mu.Unlock()
+	conn.Close()
+}
+
+func (c *Conn) dial() (net.Conn, error) {
+	//Discover waits for answers until the deadline, so the connection attempt gets a context of its own
+	resolveCtx, cancel := context.WithTimeout(context.Background(), c.dialer.discovery())
+	address, err := c.resolve(resolveCtx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.dialer.timeout())
+	defer cancel()
+	dialer := &net.Dialer{KeepAlive: c.dialer.keepAlive()}
+	return dialer.DialContext(ctx, "tcp", address)
+}
+
+func (d *Dialer) timeout() time.Duration {
+	return durationOrDefault(d.Timeout, 5*time.Second)
+}
+
+func (d *Dialer) discovery() time.Duration {
+	return durationOrDefault(d.Discovery, time.Second)
+}
+
+func (d *Dialer) keepAlive() time.Duration {
+	return durationOrDefault(d.KeepAlive, 15*time.Second)
+}
+
+func (d *Dialer) minBackoff() time.Duration {
+	return durationOrDefault(d.MinBackoff, 500*time.Millisecond)
+}
+
+func (d *Dialer) maxBackoff() time.Duration {
+	return durationOrDefault(d.MaxBackoff, 30*time.Second)
+}
+
+func durationOrDefault(d time.Duration, def time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return def
+}
+
+var _ io.ReadWriteCloser = &Conn{}
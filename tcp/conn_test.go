@@ -0,0 +1,128 @@
+package tcp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) TestReconnect(c *C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	defer listener.Close()
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	dialer := &Dialer{MinBackoff: 10 * time.Millisecond}
+	conn, err := dialer.Dial("tcp://" + listener.Addr().String())
+	c.Assert(err, IsNil)
+	defer conn.Close()
+
+	first := <-accepted
+	first.Write([]byte{0xfe})
+	buf := make([]byte, 1)
+	_, err = conn.Read(buf)
+	c.Assert(err, IsNil)
+	c.Assert(buf[0], Equals, byte(0xfe))
+
+	first.Close()
+	_, err = conn.Read(buf)
+	c.Assert(err, NotNil)
+	_, err = conn.Write([]byte{0x01})
+	c.Assert(err, Equals, ErrDisconnected)
+
+	read := make(chan byte)
+	go func() {
+		conn.Read(buf)
+		read <- buf[0]
+	}()
+	second := <-accepted
+	second.Write([]byte{0x02})
+	c.Assert(<-read, Equals, byte(0x02))
+	_, err = conn.Write([]byte{0x03})
+	c.Assert(err, IsNil)
+}
+
+func (s *MySuite) TestUnsupportedScheme(c *C) {
+	_, err := Dial("udp://127.0.0.1:1")
+	c.Assert(err, ErrorMatches, "tcp: unsupported address scheme: udp")
+}
+
+//announcement returns the mDNS answer announcing the listener as the _zigstar_gw._tcp service
+func announcement(c *C, listener net.Listener) []byte {
+	addr := listener.Addr().(*net.TCPAddr)
+	instance := dnsmessage.MustNewName("zigstar._zigstar_gw._tcp.local.")
+	host := dnsmessage.MustNewName("zigstar.local.")
+	answer := dnsmessage.Message{
+		Header: dnsmessage.Header{Response: true, Authoritative: true},
+		Answers: []dnsmessage.Resource{{
+			Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("_zigstar_gw._tcp.local."),
+				Class: dnsmessage.ClassINET},
+			Body: &dnsmessage.PTRResource{PTR: instance},
+		}},
+		Additionals: []dnsmessage.Resource{
+			{
+				Header: dnsmessage.ResourceHeader{Name: instance, Class: dnsmessage.ClassINET},
+				Body:   &dnsmessage.SRVResource{Target: host, Port: uint16(addr.Port)},
+			},
+			{
+				Header: dnsmessage.ResourceHeader{Name: host, Class: dnsmessage.ClassINET},
+				Body:   &dnsmessage.AResource{A: [4]byte(addr.IP.To4())},
+			},
+		},
+	}
+	packet, err := answer.Pack()
+	c.Assert(err, IsNil)
+	return packet
+}
+
+//announce answers the queries sent to the responder with the packet
+func announce(responder *net.UDPConn, packet []byte) {
+	buf := make([]byte, 9000)
+	for {
+		_, from, err := responder.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		responder.WriteTo(packet, from)
+	}
+}
+
+func (s *MySuite) TestDialMdns(c *C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, IsNil)
+	defer listener.Close()
+	responder, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	c.Assert(err, IsNil)
+	defer responder.Close()
+	go announce(responder, announcement(c, listener))
+	group := mdnsGroup
+	mdnsGroup = responder.LocalAddr().(*net.UDPAddr)
+	defer func() { mdnsGroup = group }()
+
+	//answers are collected for as long as a connection attempt may take, the dial must not inherit that deadline
+	dialer := &Dialer{Timeout: 100 * time.Millisecond, Discovery: 100 * time.Millisecond}
+	conn, err := dialer.Dial("mdns://_zigstar_gw._tcp")
+	c.Assert(err, IsNil)
+	defer conn.Close()
+	accepted, err := listener.Accept()
+	c.Assert(err, IsNil)
+	accepted.Close()
+}
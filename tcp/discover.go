@@ -0,0 +1,129 @@
+package tcp
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+var mdnsGroup = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+//Adapter is a network attached adapter announced over mDNS.
+type Adapter struct {
+	Instance string //Service instance name, e.g. "zigstar._zigstar_gw._tcp.local."
+	Host     string //Host name of the bridge
+	IP       net.IP
+	Port     uint16
+}
+
+//Address returns the host:port to connect to.
+func (a *Adapter) Address() string {
+	host := a.Host
+	if a.IP != nil {
+		host = a.IP.String()
+	}
+	return net.JoinHostPort(strings.TrimSuffix(host, "."), strconv.Itoa(int(a.Port)))
+}
+
+//Discover browses the local network for adapters announcing the given DNS-SD service, e.g. "_zigstar_gw._tcp".
+//It collects answers until the context is done, or for one second if the context has no deadline.
+func Discover(ctx context.Context, service string) ([]*Adapter, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Second)
+		defer cancel()
+	}
+	name, err := dnsmessage.NewName(strings.TrimSuffix(service, ".") + ".local.")
+	if err != nil {
+		return nil, err
+	}
+	query := dnsmessage.Message{
+		Questions: []dnsmessage.Question{{Name: name, Type: dnsmessage.TypePTR, Class: dnsmessage.ClassINET}},
+	}
+	packet, err := query.Pack()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	conn.SetReadDeadline(deadline)
+	//sending from an ephemeral port makes responders reply with unicast (RFC 6762, section 6.7)
+	if _, err = conn.WriteTo(packet, mdnsGroup); err != nil {
+		return nil, err
+	}
+
+	r := newResolution(name.String())
+	buf := make([]byte, 9000)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				return r.adapters(), nil
+			}
+			return nil, err
+		}
+		var msg dnsmessage.Message
+		if err := msg.Unpack(buf[:n]); err != nil {
+			continue
+		}
+		r.add(msg.Answers)
+		r.add(msg.Additionals)
+	}
+}
+
+type resolution struct {
+	service   string
+	instances []string
+	srv       map[string]*dnsmessage.SRVResource
+	ips       map[string]net.IP
+}
+
+func newResolution(service string) *resolution {
+	return &resolution{service: service, srv: map[string]*dnsmessage.SRVResource{}, ips: map[string]net.IP{}}
+}
+
+func (r *resolution) add(resources []dnsmessage.Resource) {
+	for _, resource := range resources {
+		name := resource.Header.Name.String()
+		switch body := resource.Body.(type) {
+		case *dnsmessage.PTRResource:
+			if strings.EqualFold(name, r.service) && !r.known(body.PTR.String()) {
+				r.instances = append(r.instances, body.PTR.String())
+			}
+		case *dnsmessage.SRVResource:
+			r.srv[name] = body
+		case *dnsmessage.AResource:
+			r.ips[name] = net.IP(body.A[:])
+		}
+	}
+}
+
+func (r *resolution) known(instance string) bool {
+	for _, i := range r.instances {
+		if i == instance {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *resolution) adapters() []*Adapter {
+	var adapters []*Adapter
+	for _, instance := range r.instances {
+		srv, ok := r.srv[instance]
+		if !ok {
+			continue
+		}
+		host := srv.Target.String()
+		adapters = append(adapters, &Adapter{Instance: instance, Host: host, IP: r.ips[host], Port: srv.Port})
+	}
+	return adapters
+}
@@ -0,0 +1,41 @@
+package znp
+
+import "sync"
+
+//TransIDs allocates the transaction IDs of the AF messages sent from the endpoints of the adapter. The AfDataConfirm
+//of a message is only told apart by its endpoint and transaction ID, so every sender of a Znp takes them from the
+//same allocator.
+type TransIDs struct {
+	mu   sync.Mutex
+	next map[uint8]uint8
+	used map[[2]uint8]bool
+}
+
+//TransIDs returns the allocator of the transaction IDs of the adapter
+func (znp *Znp) TransIDs() *TransIDs {
+	return znp.transIDs
+}
+
+//Acquire returns the next transaction ID of the endpoint which isn't in use. It fails when all of them are.
+func (t *TransIDs) Acquire(endpoint uint8) (uint8, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	transID := t.next[endpoint]
+	for i := 0; i <= 0xFF; i++ {
+		if !t.used[[2]uint8{endpoint, transID}] {
+			t.used[[2]uint8{endpoint, transID}] = true
+			t.next[endpoint] = transID + 1
+			return transID, true
+		}
+		transID++
+	}
+	return 0, false
+}
+
+//Release makes the transaction ID available again, once the confirmation of its message arrived or is no longer
+//awaited
+func (t *TransIDs) Release(endpoint uint8, transID uint8) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.used, [2]uint8{endpoint, transID})
+}
@@ -1,6 +1,11 @@
+//go:generate go run ./internal/mtgen
+
 package znp
 
 import (
+	"log/slog"
+	"sync"
+
 	"github.com/dyrkin/unp-go"
 
 	"github.com/dyrkin/znp-go/request"
@@ -15,6 +20,18 @@ type Znp struct {
 	inFramesLog  chan *unp.Frame
 	outFramesLog chan *unp.Frame
 	started      bool
+	metrics      Metrics
+	logger       *slog.Logger
+	logPayloads  bool
+
+	deviceInfo     *DeviceInfo
+	deviceInfoLock sync.RWMutex
+	probing        chan struct{} //closed when the probe of Start ended
+
+	subscribers     map[*Subscription]struct{}
+	subscribersLock sync.RWMutex
+
+	transIDs *TransIDs
 }
 
 func New(u *unp.Unp) *Znp {
@@ -26,6 +43,9 @@ func New(u *unp.Unp) *Znp {
 		errors:       make(chan error, 100),
 		inFramesLog:  make(chan *unp.Frame, 100),
 		outFramesLog: make(chan *unp.Frame, 100),
+		metrics:      noopMetrics{},
+		subscribers:  make(map[*Subscription]struct{}),
+		transIDs:     &TransIDs{next: map[uint8]uint8{}, used: map[[2]uint8]bool{}},
 	}
 	return znp
 }
@@ -38,10 +58,16 @@ func (znp *Znp) AsyncInbound() chan interface{} {
 	return znp.asyncInbound
 }
 
+//InFramesLog returns the channel received frames are copied to. Frames are dropped when nobody reads the channel.
+//
+//Deprecated: use SetLogger, which receives every frame in order.
 func (znp *Znp) InFramesLog() chan *unp.Frame {
 	return znp.inFramesLog
 }
 
+//OutFramesLog returns the channel sent frames are copied to. Frames are dropped when nobody reads the channel.
+//
+//Deprecated: use SetLogger, which receives every frame in order.
 func (znp *Znp) OutFramesLog() chan *unp.Frame {
 	return znp.outFramesLog
 }